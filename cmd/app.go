@@ -5,13 +5,21 @@ import (
 	"evently/internal/delivery/http/routes"
 	"evently/internal/di"
 	"log"
+	"net"
 	"net/http"
+	"sync"
 	"time"
 )
 
+// shutdownTimeout bounds how long requests in flight may take to finish.
+const shutdownTimeout = 30 * time.Second
+
 type Application struct {
 	container *di.Container
 	server    *http.Server
+	// workers tracks the background loops and the server shutdown, which
+	// all use the pool until they return
+	workers sync.WaitGroup
 }
 
 func NewApplication(container *di.Container) *Application {
@@ -21,29 +29,123 @@ func NewApplication(container *di.Container) *Application {
 }
 
 func (a *Application) Start(ctx context.Context) {
+	routes.AllRoutes(a.container.Server, a.container, a.container.JWTMiddleware)
+
+	a.spawn(func() { a.purgeDeletedAccounts(ctx) })
+	a.spawn(func() { a.purgeLoginFailures(ctx) })
+	a.spawn(func() { a.deliverNotifications(ctx) })
+	a.spawn(func() { a.container.Broker.Run(ctx) })
+
+	listener, err := net.Listen("tcp", ":8080")
+	if err != nil {
+		log.Fatalf("failed to start server: %v", err)
+	}
+	log.Println("Starting server on :8080")
+	a.serve(ctx, listener)
+}
+
+// serve handles requests on listener until ctx is done and then shuts the
+// server down.
+func (a *Application) serve(ctx context.Context, listener net.Listener) {
 	a.server = &http.Server{
-		Addr:    ":8080",
 		Handler: a.container.Server,
 	}
-
-	routes.AllRoutes(a.container.Server, a.container, a.container.JWTMiddleware)
+	// Event streams never go idle, so Shutdown would wait for them until
+	// its deadline; ending their subscriptions lets them return
+	a.server.RegisterOnShutdown(a.container.Broker.Close)
 
 	go func() {
-		log.Println("Starting server on :8080")
-		if err := a.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("failed to start server: %v", err)
+		if err := a.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("server failed: %v", err)
 		}
 	}()
 
-	go func() {
+	a.spawn(func() {
 		<-ctx.Done()
 		log.Println("shutting down server...")
 
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 		defer cancel()
 
 		if err := a.server.Shutdown(shutdownCtx); err != nil {
 			log.Printf("server shutdown error: %v", err)
 		}
+	})
+}
+
+// Wait blocks until the server has shut down and the background loops have
+// returned after the context given to Start is done. Sends already under
+// way finish first, so the pool must stay open until Wait returns.
+func (a *Application) Wait() {
+	a.workers.Wait()
+}
+
+// spawn runs fn in a goroutine that Wait waits for.
+func (a *Application) spawn(fn func()) {
+	a.workers.Add(1)
+	go func() {
+		defer a.workers.Done()
+		fn()
 	}()
 }
+
+// purgeDeletedAccounts anonymises accounts whose deletion grace period has
+// ended, hourly until ctx is done.
+func (a *Application) purgeDeletedAccounts(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		purged, err := a.container.PrivacyUseCase.PurgeDue(ctx)
+		if err != nil {
+			log.Printf("account deletion failed: %v", err)
+		} else if purged > 0 {
+			log.Printf("deleted %d accounts after their grace period", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeLoginFailures forgets failed logins and lockouts once they no longer
+// count, hourly until ctx is done.
+func (a *Application) purgeLoginFailures(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if _, err := a.container.AuthUseCase.PurgeLoginFailures(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("login failure cleanup failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverNotifications sends notifications out through the configured
+// channels in the background, so requests that create them never wait for
+// email or push services.
+func (a *Application) deliverNotifications(ctx context.Context) {
+	ticker := time.NewTicker(a.container.Config.Delivery.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := a.container.DispatchUseCase.Dispatch(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("notification delivery failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
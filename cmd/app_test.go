@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"evently/internal/delivery/http/handler"
+	"evently/internal/di"
+	"evently/internal/domain/events"
+	"evently/internal/usecase/realtime"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeEventUsecase struct {
+	events.EventUsecase
+}
+
+func (u *fakeEventUsecase) GetEvent(ctx context.Context, eventID string) (*events.Event, error) {
+	return &events.Event{ID: eventID, TotalCapacity: 100, AvailableSeats: 40}, nil
+}
+
+func TestShutdownEndsStreams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	broker := realtime.NewBroker(nil, nil)
+	streams := handler.NewStreamHandler(broker, nil, &fakeEventUsecase{})
+	server := gin.New()
+	server.GET("/events/:id/availability/stream", streams.AvailabilityStream)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := NewApplication(&di.Container{Server: server, Broker: broker})
+	app.serve(ctx, listener)
+
+	resp, err := http.Get("http://" + listener.Addr().String() + "/events/event-1/availability/stream")
+	if err != nil {
+		t.Fatalf("failed to open the stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// The stream is open once its initial event arrives
+	body := bufio.NewReader(resp.Body)
+	line, err := body.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "event:availability") {
+		t.Fatalf("first line = %q, %v", line, err)
+	}
+
+	cancel()
+	stopped := make(chan struct{})
+	go func() {
+		app.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout / 3):
+		t.Fatal("shutdown waited for the open stream")
+	}
+	if _, err := io.ReadAll(body); err != nil {
+		t.Errorf("stream did not end cleanly: %v", err)
+	}
+}
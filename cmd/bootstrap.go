@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"evently/internal/di"
+	"evently/internal/domain/model"
+)
+
+// runBootstrapAdmin creates the first admin account. Further admins and
+// organizers are invited through the API. The password is read from
+// BOOTSTRAP_ADMIN_PASSWORD, or from stdin when that is unset, so it does
+// not end up in the shell history.
+func runBootstrapAdmin(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("bootstrap-admin", flag.ContinueOnError)
+	email := flags.String("email", "", "email of the admin account")
+	name := flags.String("name", "Admin", "name of the admin account")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" {
+		return fmt.Errorf("-email is required")
+	}
+
+	password := os.Getenv("BOOTSTRAP_ADMIN_PASSWORD")
+	if password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+	if password == "" {
+		return fmt.Errorf("password is required")
+	}
+
+	container, err := di.NewContainer(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.Pool.Close()
+
+	return container.AuthUseCase.BootstrapAdmin(&model.RegisterRequest{
+		Name:     *name,
+		Email:    *email,
+		Password: password,
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	// Embedded zone database so event time zones resolve on minimal images
+	_ "time/tzdata"
 
 	"evently/internal/di"
 )
@@ -14,6 +16,35 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
+	if len(os.Args) > 1 && os.Args[1] == "bootstrap-admin" {
+		if err := runBootstrapAdmin(ctx, os.Args[2:]); err != nil {
+			log.Fatalf("bootstrap-admin: %v", err)
+		}
+		log.Println("admin account created")
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "mock-oidc" {
+		if err := runMockOIDC(ctx, os.Args[2:]); err != nil {
+			log.Fatalf("mock-oidc: %v", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "mock-smtp" {
+		if err := runMockSMTP(ctx, os.Args[2:]); err != nil {
+			log.Fatalf("mock-smtp: %v", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "generate-vapid-key" {
+		if err := runGenerateVAPIDKey(); err != nil {
+			log.Fatalf("generate-vapid-key: %v", err)
+		}
+		return
+	}
+
 	// Initialize dependency injection container
 	container, err := di.NewContainer(ctx)
 	if err != nil {
@@ -28,4 +59,7 @@ func main() {
 
 	<-ctx.Done()
 	log.Println("shutdown signal received, closing resources...")
+	// The pool is closed by the deferred call only once nothing uses it
+	app.Wait()
+	log.Println("shutdown complete")
 }
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// mockSMTPServer is a minimal mail server for trying email delivery
+// locally. It accepts every message and logs it instead of passing it on.
+type mockSMTPServer struct {
+	hostname string
+	// reject holds recipients refused with a permanent error, to try how
+	// dead deliveries are handled.
+	reject map[string]bool
+}
+
+// runMockSMTP serves a mock mail server until ctx is done. Point SMTP_HOST
+// and SMTP_PORT at -addr and set MAIL_CHANNEL=smtp.
+func runMockSMTP(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("mock-smtp", flag.ContinueOnError)
+	addr := flags.String("addr", "127.0.0.1:2525", "address to listen on")
+	reject := flags.String("reject", "", "comma separated recipients to refuse")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	server := &mockSMTPServer{hostname: "mock-smtp.local", reject: map[string]bool{}}
+	for _, recipient := range strings.Split(*reject, ",") {
+		if recipient = strings.TrimSpace(recipient); recipient != "" {
+			server.reject[strings.ToLower(recipient)] = true
+		}
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	log.Printf("mock SMTP server listening on %s", *addr)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go server.serve(conn)
+	}
+}
+
+func (s *mockSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+
+	var from string
+	var to []string
+	reply := func(format string, args ...any) {
+		tp.PrintfLine(format, args...)
+	}
+
+	reply("220 %s mock ESMTP ready", s.hostname)
+	for {
+		conn.SetDeadline(time.Now().Add(5 * time.Minute))
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			reply("250-%s greets %s", s.hostname, arg)
+			reply("250 8BITMIME")
+		case "HELO":
+			reply("250 %s", s.hostname)
+		case "MAIL":
+			from, to = pathArgument(arg), nil
+			reply("250 2.1.0 OK")
+		case "RCPT":
+			recipient := pathArgument(arg)
+			if s.reject[strings.ToLower(recipient)] {
+				reply("550 5.1.1 <%s>: mailbox unavailable", recipient)
+				continue
+			}
+			to = append(to, recipient)
+			reply("250 2.1.5 OK")
+		case "DATA":
+			if from == "" || len(to) == 0 {
+				reply("503 5.5.1 need MAIL and RCPT first")
+				continue
+			}
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			lines, err := tp.ReadDotLines()
+			if err != nil {
+				return
+			}
+			log.Printf("message from %s to %s:\n%s\n", from, strings.Join(to, ", "), strings.Join(lines, "\n"))
+			from, to = "", nil
+			reply("250 2.0.0 OK queued")
+		case "RSET":
+			from, to = "", nil
+			reply("250 2.0.0 OK")
+		case "NOOP":
+			reply("250 2.0.0 OK")
+		case "QUIT":
+			reply("221 2.0.0 bye")
+			return
+		default:
+			reply("502 5.5.2 %s not implemented", verb)
+		}
+	}
+}
+
+// pathArgument takes the address out of "FROM:<a@example.com> SIZE=100".
+func pathArgument(arg string) string {
+	_, path, _ := strings.Cut(arg, ":")
+	path, _, _ = strings.Cut(strings.TrimSpace(path), " ")
+	return strings.TrimSuffix(strings.TrimPrefix(path, "<"), ">")
+}
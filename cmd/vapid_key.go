@@ -0,0 +1,21 @@
+package main
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// runGenerateVAPIDKey prints a new key pair for web push. Only the private
+// key goes into the configuration; the public key is derived from it.
+func runGenerateVAPIDKey() error {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	fmt.Printf("VAPID_PRIVATE_KEY=%s\n", base64.RawURLEncoding.EncodeToString(key.Bytes()))
+	fmt.Printf("# public key: %s\n", base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()))
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	domain_evently "evently/internal/domain/model"
@@ -40,6 +42,9 @@ func MigrateDB(cfg domain_evently.DBConfig) error {
 
 func LoadConfig() *domain_evently.Config {
 	return &domain_evently.Config{
+		Env:            getEnv("APP_ENV", "production"),
+		PublicURL:      getEnv("PUBLIC_URL", "http://localhost:8080"),
+		TrustedProxies: getListEnv("TRUSTED_PROXIES"),
 		DB: domain_evently.DBConfig{
 			URL:           getEnv("DATABASE_URL", ""),
 			Host:          getEnv("DB_HOST", "localhost"),
@@ -51,7 +56,57 @@ func LoadConfig() *domain_evently.Config {
 			MigrationsDir: getEnv("MIGRATIONS_DIR", "../migrations"),
 		},
 		JWT: domain_evently.JWTConfig{
-			SecretKey: getEnv("JWT_SECRET_KEY", "your-secret-key-change-in-production"),
+			KeysDir:      getEnv("JWT_KEYS_DIR", ""),
+			Issuer:       getEnv("JWT_ISSUER", "evently"),
+			Audience:     getEnv("JWT_AUDIENCE", "evently-api"),
+			AccessTTL:    getDurationEnv("JWT_ACCESS_TTL", 15*time.Minute),
+			RefreshTTL:   getDurationEnv("JWT_REFRESH_TTL", 30*24*time.Hour),
+			KeyRetention: getDurationEnv("JWT_KEY_RETENTION", 30*24*time.Hour),
+			KeysReload:   getDurationEnv("JWT_KEYS_RELOAD", 5*time.Minute),
+		},
+		Auth: domain_evently.AuthConfig{
+			RequireVerifiedEmail:    getEnv("REQUIRE_VERIFIED_EMAIL", "false") == "true",
+			RequireMFAForPrivileged: getEnv("MFA_REQUIRED_FOR_PRIVILEGED", "false") == "true",
+			AppURL:                  getEnv("APP_URL", "http://localhost:3000"),
+			DeletionGracePeriod:     getDurationEnv("ACCOUNT_DELETION_GRACE", 30*24*time.Hour),
+		},
+		OIDC: domain_evently.OIDCConfig{
+			Issuer:       getEnv("OIDC_ISSUER", ""),
+			ClientID:     getEnv("OIDC_CLIENT_ID", ""),
+			ClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:  getEnv("OIDC_REDIRECT_URL", "http://localhost:8080/api/auth/oidc/callback"),
+			Scopes:       getListEnv("OIDC_SCOPES"),
+			GroupsClaim:  getEnv("OIDC_GROUPS_CLAIM", "groups"),
+			GroupRoles:   getListEnv("OIDC_GROUP_ROLES"),
+		},
+		Mail: domain_evently.MailConfig{
+			Channel:      getEnv("MAIL_CHANNEL", "file"),
+			Dir:          getEnv("MAIL_DIR", "tmp/mail"),
+			From:         getEnv("MAIL_FROM", "Evently <no-reply@evently.local>"),
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnv("SMTP_PORT", "587"),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		},
+		SMS: domain_evently.SMSConfig{
+			Channel:          getEnv("SMS_CHANNEL", ""),
+			Dir:              getEnv("SMS_DIR", "tmp/sms"),
+			From:             getEnv("SMS_FROM", ""),
+			TwilioAccountSID: getEnv("TWILIO_ACCOUNT_SID", ""),
+			TwilioAuthToken:  getEnv("TWILIO_AUTH_TOKEN", ""),
+			TwilioURL:        getEnv("TWILIO_API_URL", "https://api.twilio.com"),
+		},
+		WebPush: domain_evently.WebPushConfig{
+			VAPIDPrivateKey: getEnv("VAPID_PRIVATE_KEY", ""),
+			Subject:         getEnv("VAPID_SUBJECT", ""),
+		},
+		Presale: domain_evently.PresaleConfig{
+			CodeSecret: getEnv("PRESALE_CODE_SECRET", ""),
+		},
+		Delivery: domain_evently.DeliveryConfig{
+			MaxAttempts:  getIntEnv("DELIVERY_MAX_ATTEMPTS", 6),
+			RetryBackoff: getDurationEnv("DELIVERY_RETRY_BACKOFF", 30*time.Second),
+			PollInterval: getDurationEnv("DELIVERY_POLL_INTERVAL", 5*time.Second),
 		},
 	}
 }
@@ -99,3 +154,34 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getListEnv reads a comma separated list.
+func getListEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getIntEnv reads a positive integer, falling back to the default when it is
+// unset or invalid.
+func getIntEnv(key string, defaultValue int) int {
+	n, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || n <= 0 {
+		return defaultValue
+	}
+	return n
+}
+
+// getDurationEnv reads a duration such as "15m" or "720h", falling back to
+// the default when it is unset or invalid.
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || d <= 0 {
+		return defaultValue
+	}
+	return d
+}
@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"strconv"
 
+	"evently/internal/delivery/http/middleware"
 	"evently/internal/domain/booking"
 	"evently/internal/domain/events"
+	"evently/internal/domain/rbac"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,7 +27,13 @@ func NewAdminHandler(eventUsecase events.EventUsecase, bookingUsecase booking.Bo
 func (h *AdminHandler) GetEventAnalytics(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	analytics, err := h.eventUsecase.GetMostPopularEvents(c.Request.Context(), limit)
+	var analytics []*events.EventAnalytics
+	var err error
+	if userID, scoped := managedScope(c); scoped {
+		analytics, err = h.eventUsecase.GetMostPopularManagedEvents(c.Request.Context(), userID, limit)
+	} else {
+		analytics, err = h.eventUsecase.GetMostPopularEvents(c.Request.Context(), limit)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -54,13 +62,19 @@ func (h *AdminHandler) GetAllEvents(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-	events, err := h.eventUsecase.ListAllEvents(c.Request.Context(), limit, offset)
+	var list []*events.Event
+	var err error
+	if userID, scoped := managedScope(c); scoped {
+		list, err = h.eventUsecase.ListManagedEvents(c.Request.Context(), userID, limit, offset)
+	} else {
+		list, err = h.eventUsecase.ListAllEvents(c.Request.Context(), limit, offset)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"events": events})
+	c.JSON(http.StatusOK, gin.H{"events": list})
 }
 
 func (h *AdminHandler) GetEventBookings(c *gin.Context) {
@@ -81,3 +95,31 @@ func (h *AdminHandler) GetEventBookings(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"bookings": bookings})
 }
+
+func (h *AdminHandler) GetSessionUtilization(c *gin.Context) {
+	eventID := c.Param("eventId")
+	if eventID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event ID is required"})
+		return
+	}
+
+	utilization, err := h.eventUsecase.GetSessionUtilization(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": utilization})
+}
+
+// managedScope returns the user ID when listings must be limited to the
+// events the user manages, which is the case without events:manage_all.
+func managedScope(c *gin.Context) (string, bool) {
+	if middleware.HasPermission(c, rbac.PermEventsManageAll) {
+		return "", false
+	}
+
+	userID, _ := c.Get("user_id")
+	id, _ := userID.(string)
+	return id, true
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"evently/internal/domain/audit"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditUsecase audit.AuditUsecase
+}
+
+func NewAuditHandler(auditUsecase audit.AuditUsecase) *AuditHandler {
+	return &AuditHandler{
+		auditUsecase: auditUsecase,
+	}
+}
+
+func (h *AuditHandler) ListEntries(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	entries, err := h.auditUsecase.ListEntries(c.Request.Context(), c.Query("action"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
@@ -1,24 +1,59 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"evently/internal/domain/auth"
 	"evently/internal/domain/model"
 	"evently/internal/domain/usecase"
 
 	"github.com/gin-gonic/gin"
 )
 
+// verificationEmailTimeout bounds the background send after sign up.
+const verificationEmailTimeout = time.Minute
+
 type AuthHandler struct {
-	authUsecase usecase.AuthUseCase
+	authUsecase    usecase.AuthUseCase
+	accountUsecase auth.AccountUsecase
 }
 
-func NewAuthHandler(authUsecase usecase.AuthUseCase) *AuthHandler {
+func NewAuthHandler(authUsecase usecase.AuthUseCase, accountUsecase auth.AccountUsecase) *AuthHandler {
 	return &AuthHandler{
-		authUsecase: authUsecase,
+		authUsecase:    authUsecase,
+		accountUsecase: accountUsecase,
 	}
 }
 
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type emailRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type resetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type verifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type verifyMFARequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
 func (h *AuthHandler) Register(c *gin.Context) {
 	var user model.RegisterRequest
 	if err := c.ShouldBindJSON(&user); err != nil {
@@ -31,9 +66,99 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	// The email goes out in the background so a slow mail server does not
+	// hold up sign up. The account exists either way; the user can ask for
+	// another email
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), verificationEmailTimeout)
+	go func() {
+		defer cancel()
+		if err := h.accountUsecase.RequestEmailVerification(ctx, user.Email); err != nil {
+			log.Printf("failed to send verification email: %v", err)
+		}
+	}()
+
 	c.JSON(http.StatusCreated, gin.H{"message": "user registered successfully"})
 }
 
+// ForgotPassword emails a reset link. The response is the same whether or
+// not the address has an account.
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req emailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountUsecase.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		log.Printf("failed to send password reset email: %v", err)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "if the address has an account, a reset link is on its way"})
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req resetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountUsecase.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		respondAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "password reset successfully, please log in again"})
+}
+
+// RequestEmailVerification sends another verification email. Like
+// ForgotPassword it does not reveal whether the address has an account.
+func (h *AuthHandler) RequestEmailVerification(c *gin.Context) {
+	var req emailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountUsecase.RequestEmailVerification(c.Request.Context(), req.Email); err != nil {
+		log.Printf("failed to send verification email: %v", err)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "if the address needs verifying, a link is on its way"})
+}
+
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req verifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountUsecase.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		respondAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "email verified successfully"})
+}
+
+// ConfirmEmailChange switches the account to the address the token was
+// mailed to.
+func (h *AuthHandler) ConfirmEmailChange(c *gin.Context) {
+	var req verifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountUsecase.ConfirmEmailChange(c.Request.Context(), req.Token); err != nil {
+		respondAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "email changed successfully"})
+}
+
 func (h *AuthHandler) Login(c *gin.Context) {
 	var loginReq struct {
 		Email    string `json:"email" binding:"required"`
@@ -45,11 +170,146 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := h.authUsecase.Login(loginReq.Email, loginReq.Password)
+	result, err := h.authUsecase.Login(c.Request.Context(), loginReq.Email, loginReq.Password, c.ClientIP())
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		respondAuthError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": token})
+	switch {
+	case result.Tokens != nil:
+		c.JSON(http.StatusOK, result.Tokens)
+	case result.MFAEnrollmentRequired:
+		respondMFAEnrollmentRequired(c, &auth.MFAEnrollmentRequiredError{MFAToken: result.MFAToken})
+	default:
+		c.JSON(http.StatusOK, gin.H{
+			"mfa_required": true,
+			"mfa_token":    result.MFAToken,
+			"expires_in":   int(auth.MFAChallengeTTL.Seconds()),
+		})
+	}
+}
+
+// VerifyMFA completes a login that needs a second factor. The code is a
+// current TOTP code or one of the recovery codes.
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req verifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := h.authUsecase.VerifyMFA(c.Request.Context(), req.MFAToken, req.Code, c.ClientIP())
+	if err != nil {
+		respondAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// UnlockAccount lifts a login lockout so the user can try again at once.
+func (h *AuthHandler) UnlockAccount(c *gin.Context) {
+	actorID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.authUsecase.UnlockAccount(c.Request.Context(), actorID.(string), c.Param("id"), c.ClientIP()); err != nil {
+		respondAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "account unlocked successfully"})
+}
+
+// Refresh exchanges a refresh token for a new access and refresh token.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req refreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := h.authUsecase.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		respondAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Logout ends the session of the refresh token. Access tokens already
+// issued stay valid until they expire.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req refreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authUsecase.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		respondAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out successfully"})
+}
+
+// RevokeSessions signs a user out everywhere.
+func (h *AuthHandler) RevokeSessions(c *gin.Context) {
+	revoked, err := h.authUsecase.RevokeSessions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "sessions revoked successfully", "revoked": revoked})
+}
+
+func respondAuthError(c *gin.Context, err error) {
+	if respondMFAEnrollmentRequired(c, err) {
+		return
+	}
+
+	var throttled *auth.ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, auth.ErrAccountDisabled):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, auth.ErrInvalidRefreshToken), errors.Is(err, auth.ErrRefreshTokenReused):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, auth.ErrInvalidMFAToken), errors.Is(err, auth.ErrInvalidMFACode):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, auth.ErrInvalidAccountToken), strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.HasSuffix(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// respondMFAEnrollmentRequired answers 403 with a step token for setting up
+// two-factor when err is a *auth.MFAEnrollmentRequiredError, and reports
+// whether it did. The token only opens the MFA setup endpoints.
+func respondMFAEnrollmentRequired(c *gin.Context, err error) bool {
+	var enroll *auth.MFAEnrollmentRequiredError
+	if !errors.As(err, &enroll) {
+		return false
+	}
+
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":      enroll.Error(),
+		"code":       "mfa_enrollment_required",
+		"mfa_token":  enroll.MFAToken,
+		"expires_in": int(auth.MFAEnrollTTL.Seconds()),
+	})
+	return true
 }
@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"evently/internal/delivery/http/middleware"
 	"evently/internal/domain/booking"
+	"evently/internal/domain/presale"
+	"evently/internal/domain/rbac"
 	"evently/internal/domain/waitlist"
 
 	"github.com/gin-gonic/gin"
@@ -24,8 +28,8 @@ func NewBookingHandler(bookingUsecase booking.BookingUsecase, waitlistUsecase wa
 }
 
 func (h *BookingHandler) CreateBooking(c *gin.Context) {
-	var booking booking.Booking
-	if err := c.ShouldBindJSON(&booking); err != nil {
+	var newBooking booking.Booking
+	if err := c.ShouldBindJSON(&newBooking); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -35,19 +39,27 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
-	booking.UserID = userID.(string)
+	newBooking.UserID = userID.(string)
 
-	err := h.bookingUsecase.CreateBooking(c.Request.Context(), &booking)
+	if !middleware.EventAllowed(c, newBooking.EventID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "this API key is not allowed for this event"})
+		return
+	}
+
+	err := h.bookingUsecase.CreateBooking(c.Request.Context(), &newBooking)
 	if err != nil {
 		if strings.Contains(err.Error(), "insufficient seats available") {
 
-			waitlistErr := h.waitlistUsecase.JoinWaitlist(c.Request.Context(), userID.(string), booking.EventID, booking.Quantity)
+			waitlistErr := h.waitlistUsecase.JoinWaitlist(c.Request.Context(), userID.(string), newBooking.EventID, newBooking.Quantity, newBooking.AccessCode)
 			if waitlistErr != nil {
+				if respondSalesClosed(c, waitlistErr) {
+					return
+				}
 				c.JSON(http.StatusBadRequest, gin.H{"error": "event is full and failed to join waitlist: " + waitlistErr.Error()})
 				return
 			}
 
-			position, posErr := h.waitlistUsecase.GetWaitlistPosition(c.Request.Context(), userID.(string), booking.EventID)
+			position, posErr := h.waitlistUsecase.GetWaitlistPosition(c.Request.Context(), userID.(string), newBooking.EventID)
 			if posErr != nil {
 				position = 0
 			}
@@ -60,11 +72,20 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, booking.ErrSessionSoldOut) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+
+		if respondSalesClosed(c, err) {
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "booking created successfully", "booking": booking, "status": "confirmed"})
+	c.JSON(http.StatusCreated, gin.H{"message": "booking created successfully", "booking": newBooking, "status": "confirmed"})
 }
 
 func (h *BookingHandler) CancelBooking(c *gin.Context) {
@@ -107,8 +128,7 @@ func (h *BookingHandler) GetBooking(c *gin.Context) {
 					c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 					return
 				}
-				userRole, _ := c.Get("user_role")
-				if wl.UserID != userID.(string) && userRole != "admin" {
+				if wl.UserID != userID.(string) && !middleware.HasPermission(c, rbac.PermBookingsReadAll) {
 					c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
 					return
 				}
@@ -140,8 +160,7 @@ func (h *BookingHandler) GetBooking(c *gin.Context) {
 		return
 	}
 
-	userRole, _ := c.Get("user_role")
-	if booking.UserID != userID.(string) && userRole != "admin" {
+	if booking.UserID != userID.(string) && !middleware.HasPermission(c, rbac.PermBookingsReadAll) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
 		return
 	}
@@ -149,6 +168,24 @@ func (h *BookingHandler) GetBooking(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"booking": booking})
 }
 
+// CheckIn admits the holder of a booking scanned at the door of the event.
+func (h *BookingHandler) CheckIn(c *gin.Context) {
+	b, err := h.bookingUsecase.CheckIn(c.Request.Context(), c.Param("id"), c.Param("bookingId"))
+	if err != nil {
+		switch {
+		case errors.Is(err, booking.ErrNotConfirmed), errors.Is(err, booking.ErrAlreadyCheckedIn):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case err.Error() == "booking not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "checked in successfully", "booking": b})
+}
+
 func (h *BookingHandler) GetUserBookings(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
@@ -170,3 +207,19 @@ func (h *BookingHandler) GetUserBookings(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"bookings": bookings, "waitlist": wl})
 }
+
+// respondSalesClosed answers 403 with the time sales open when err is a
+// *presale.SalesClosedError, and reports whether it did.
+func respondSalesClosed(c *gin.Context, err error) bool {
+	var closed *presale.SalesClosedError
+	if !errors.As(err, &closed) {
+		return false
+	}
+
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":    err.Error(),
+		"code":     "sales_closed",
+		"opens_at": closed.OpensAt,
+	})
+	return true
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/booking"
+	"evently/internal/domain/calendar"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarHandler struct {
+	calendarUsecase calendar.CalendarUsecase
+	bookingUsecase  booking.BookingUsecase
+	// publicURL is where clients reach the API, feed URLs are built on it
+	publicURL string
+}
+
+func NewCalendarHandler(calendarUsecase calendar.CalendarUsecase, bookingUsecase booking.BookingUsecase, publicURL string) *CalendarHandler {
+	return &CalendarHandler{
+		calendarUsecase: calendarUsecase,
+		bookingUsecase:  bookingUsecase,
+		publicURL:       strings.TrimSuffix(publicURL, "/"),
+	}
+}
+
+func (h *CalendarHandler) GetBookingICS(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	// Bookings of other users are not found either, so their IDs cannot be
+	// probed
+	b, err := h.bookingUsecase.GetBooking(c.Request.Context(), c.Param("id"))
+	if err != nil || (b.UserID != userID.(string) && !middleware.HasPermission(c, rbac.PermBookingsReadAll)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "booking not found"})
+		return
+	}
+
+	cal, err := h.calendarUsecase.BookingCalendar(c.Request.Context(), b)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeICS(c, "booking-"+b.ID+".ics", cal)
+}
+
+func (h *CalendarHandler) CreateFeedToken(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	token, err := h.calendarUsecase.CreateFeedToken(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "calendar feed created, previous feed URLs no longer work",
+		"url":     fmt.Sprintf("%s/api/calendar/feeds/%s.ics", h.publicURL, token),
+	})
+}
+
+func (h *CalendarHandler) GetUserFeed(c *gin.Context) {
+	cal, err := h.calendarUsecase.UserFeed(c.Request.Context(), icsParam(c, "token"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "calendar feed not found"})
+		return
+	}
+
+	writeICS(c, "evently.ics", cal)
+}
+
+func (h *CalendarHandler) GetCategoryFeed(c *gin.Context) {
+	cal, err := h.calendarUsecase.CategoryFeed(c.Request.Context(), icsParam(c, "id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "calendar feed not found"})
+		return
+	}
+
+	writeICS(c, "category.ics", cal)
+}
+
+func (h *CalendarHandler) GetVenueFeed(c *gin.Context) {
+	cal, err := h.calendarUsecase.VenueFeed(c.Request.Context(), icsParam(c, "id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "calendar feed not found"})
+		return
+	}
+
+	writeICS(c, "venue.ics", cal)
+}
+
+// icsParam returns a path parameter without the optional .ics suffix that
+// calendar apps like to see on feed URLs.
+func icsParam(c *gin.Context, key string) string {
+	return strings.TrimSuffix(c.Param(key), ".ics")
+}
+
+func writeICS(c *gin.Context, filename string, cal *calendar.Calendar) {
+	var buf bytes.Buffer
+	if err := calendar.Encode(&buf, cal); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, filename))
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"evently/internal/domain/catalog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CatalogHandler struct {
+	catalogUsecase catalog.CatalogUsecase
+}
+
+func NewCatalogHandler(catalogUsecase catalog.CatalogUsecase) *CatalogHandler {
+	return &CatalogHandler{
+		catalogUsecase: catalogUsecase,
+	}
+}
+
+// Categories
+
+func (h *CatalogHandler) ListCategories(c *gin.Context) {
+	categories, err := h.catalogUsecase.ListCategories(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
+
+func (h *CatalogHandler) CreateCategory(c *gin.Context) {
+	var category catalog.Category
+	if err := c.ShouldBindJSON(&category); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.catalogUsecase.CreateCategory(c.Request.Context(), &category); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "category created successfully", "category": category})
+}
+
+func (h *CatalogHandler) UpdateCategory(c *gin.Context) {
+	var category catalog.Category
+	if err := c.ShouldBindJSON(&category); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category.ID = c.Param("id")
+	if err := h.catalogUsecase.UpdateCategory(c.Request.Context(), &category); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "category updated successfully", "category": category})
+}
+
+func (h *CatalogHandler) DeleteCategory(c *gin.Context) {
+	if err := h.catalogUsecase.DeleteCategory(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "category deleted successfully"})
+}
+
+func (h *CatalogHandler) GetCategoryAnalytics(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	analytics, err := h.catalogUsecase.GetCategoryAnalytics(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"analytics": analytics})
+}
+
+// Tags
+
+func (h *CatalogHandler) ListTags(c *gin.Context) {
+	tags, err := h.catalogUsecase.ListTags(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+func (h *CatalogHandler) CreateTag(c *gin.Context) {
+	var tag catalog.Tag
+	if err := c.ShouldBindJSON(&tag); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.catalogUsecase.CreateTag(c.Request.Context(), &tag); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "tag created successfully", "tag": tag})
+}
+
+func (h *CatalogHandler) UpdateTag(c *gin.Context) {
+	var tag catalog.Tag
+	if err := c.ShouldBindJSON(&tag); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag.ID = c.Param("id")
+	if err := h.catalogUsecase.UpdateTag(c.Request.Context(), &tag); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "tag updated successfully", "tag": tag})
+}
+
+func (h *CatalogHandler) DeleteTag(c *gin.Context) {
+	if err := h.catalogUsecase.DeleteTag(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "tag deleted successfully"})
+}
+
+// Collections
+
+func (h *CatalogHandler) ListCollections(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	collections, err := h.catalogUsecase.ListCollections(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"collections": collections})
+}
+
+func (h *CatalogHandler) GetCollection(c *gin.Context) {
+	collection, err := h.catalogUsecase.GetCollection(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "collection not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"collection": collection})
+}
+
+func (h *CatalogHandler) CreateCollection(c *gin.Context) {
+	var collection catalog.Collection
+	if err := c.ShouldBindJSON(&collection); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	collection.CreatedBy = userID.(string)
+
+	if err := h.catalogUsecase.CreateCollection(c.Request.Context(), &collection); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "collection created successfully", "collection": collection})
+}
+
+func (h *CatalogHandler) UpdateCollection(c *gin.Context) {
+	var collection catalog.Collection
+	if err := c.ShouldBindJSON(&collection); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collection.ID = c.Param("id")
+	if err := h.catalogUsecase.UpdateCollection(c.Request.Context(), &collection); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "collection updated successfully", "collection": collection})
+}
+
+func (h *CatalogHandler) DeleteCollection(c *gin.Context) {
+	if err := h.catalogUsecase.DeleteCollection(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "collection deleted successfully"})
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"evently/internal/domain/dispatch"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DispatchHandler struct {
+	dispatchUsecase dispatch.DispatchUsecase
+}
+
+func NewDispatchHandler(dispatchUsecase dispatch.DispatchUsecase) *DispatchHandler {
+	return &DispatchHandler{
+		dispatchUsecase: dispatchUsecase,
+	}
+}
+
+// GetVAPIDPublicKey returns the applicationServerKey browsers subscribe
+// with.
+func (h *DispatchHandler) GetVAPIDPublicKey(c *gin.Context) {
+	key := h.dispatchUsecase.VAPIDPublicKey()
+	if key == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "web push is not enabled"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"public_key": key})
+}
+
+// Subscribe takes the JSON of a browser PushSubscription.
+func (h *DispatchHandler) Subscribe(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dispatch.PushSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subscription, err := h.dispatchUsecase.SubscribePush(c.Request.Context(), userID.(string), &req)
+	if err != nil {
+		respondDispatchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+func (h *DispatchHandler) ListSubscriptions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	subscriptions, err := h.dispatchUsecase.ListPushSubscriptions(c.Request.Context(), userID.(string))
+	if err != nil {
+		respondDispatchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subscriptions": subscriptions})
+}
+
+func (h *DispatchHandler) Unsubscribe(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.dispatchUsecase.UnsubscribePush(c.Request.Context(), userID.(string), c.Param("id")); err != nil {
+		respondDispatchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "push subscription removed"})
+}
+
+// ListDeliveries lets admins watch deliveries, status=dead lists the
+// dead letters.
+func (h *DispatchHandler) ListDeliveries(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	deliveries, total, err := h.dispatchUsecase.ListDeliveries(c.Request.Context(), dispatch.DeliveryFilter{
+		Status:         c.Query("status"),
+		Channel:        c.Query("channel"),
+		NotificationID: c.Query("notification_id"),
+		Limit:          limit,
+		Offset:         offset,
+	})
+	if err != nil {
+		respondDispatchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries, "total": total})
+}
+
+func (h *DispatchHandler) RetryDelivery(c *gin.Context) {
+	delivery, err := h.dispatchUsecase.RetryDelivery(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondDispatchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+func respondDispatchError(c *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.HasSuffix(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
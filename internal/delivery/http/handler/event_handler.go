@@ -1,21 +1,29 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"evently/internal/domain/events"
+	"evently/internal/domain/pass"
+	"evently/internal/domain/venue"
 
 	"github.com/gin-gonic/gin"
 )
 
 type EventHandler struct {
 	eventUsecase events.EventUsecase
+	passUsecase  pass.PassUsecase
 }
 
-func NewEventHandler(eventUsecase events.EventUsecase) *EventHandler {
+func NewEventHandler(eventUsecase events.EventUsecase, passUsecase pass.PassUsecase) *EventHandler {
 	return &EventHandler{
 		eventUsecase: eventUsecase,
+		passUsecase:  passUsecase,
 	}
 }
 
@@ -23,7 +31,13 @@ func (h *EventHandler) ListUpcomingEvents(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-	events, err := h.eventUsecase.ListUpcomingEvents(c.Request.Context(), limit, offset)
+	filter := events.EventFilter{
+		VenueID:  c.Query("venue_id"),
+		Category: c.Query("category"),
+		Tags:     splitQueryList(c, "tag"),
+	}
+
+	events, err := h.eventUsecase.ListUpcomingEvents(c.Request.Context(), filter, limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -32,6 +46,89 @@ func (h *EventHandler) ListUpcomingEvents(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"events": events})
 }
 
+func (h *EventHandler) SearchEvents(c *gin.Context) {
+	params := &events.SearchParams{
+		Query:        c.Query("q"),
+		Venue:        c.Query("venue"),
+		Category:     c.Query("category"),
+		Tags:         splitQueryList(c, "tag"),
+		Availability: events.Availability(c.Query("availability")),
+		Sort:         events.SearchSort(c.Query("sort")),
+	}
+	params.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "10"))
+	params.Offset, _ = strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	var err error
+	if params.From, err = parseTimeQuery(c, "from"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if params.To, err = parseTimeQuery(c, "to"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if params.MinPrice, err = parseFloatQuery(c, "min_price"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if params.MaxPrice, err = parseFloatQuery(c, "max_price"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.eventUsecase.SearchEvents(c.Request.Context(), params)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") || strings.HasPrefix(err.Error(), "min_price") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// splitQueryList accepts both repeated (?tag=a&tag=b) and comma separated
+// (?tag=a,b) query values.
+func splitQueryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(key) {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+func parseTimeQuery(c *gin.Context, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: must be an RFC 3339 timestamp", key)
+	}
+	return &t, nil
+}
+
+func parseFloatQuery(c *gin.Context, key string) (*float64, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: must be a number", key)
+	}
+	return &f, nil
+}
+
 func (h *EventHandler) GetEvent(c *gin.Context) {
 	eventID := c.Param("id")
 	if eventID == "" {
@@ -63,6 +160,10 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 	event.CreatedBy = userID.(string)
 
 	if err := h.eventUsecase.CreateEvent(c.Request.Context(), &event); err != nil {
+		if errors.Is(err, venue.ErrCapacityExceeded) || strings.HasPrefix(err.Error(), "validation failed") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -85,6 +186,14 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 
 	event.ID = eventID
 	if err := h.eventUsecase.UpdateEvent(c.Request.Context(), &event); err != nil {
+		if errors.Is(err, events.ErrCapacityConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, venue.ErrCapacityExceeded) || strings.HasPrefix(err.Error(), "validation failed") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -106,3 +215,106 @@ func (h *EventHandler) DeleteEvent(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "event deleted successfully"})
 }
+
+func (h *EventHandler) ListSessions(c *gin.Context) {
+	eventID := c.Param("id")
+	if eventID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event ID is required"})
+		return
+	}
+
+	sessions, err := h.eventUsecase.ListSessions(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+func (h *EventHandler) CreateSession(c *gin.Context) {
+	eventID := c.Param("id")
+	if eventID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event ID is required"})
+		return
+	}
+
+	var session events.Event
+	if err := c.ShouldBindJSON(&session); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	session.CreatedBy = userID.(string)
+
+	if err := h.eventUsecase.CreateSession(c.Request.Context(), eventID, &session); err != nil {
+		if errors.Is(err, venue.ErrCapacityExceeded) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "session created successfully", "session": session})
+}
+
+func (h *EventHandler) ListPasses(c *gin.Context) {
+	eventID := c.Param("id")
+	if eventID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event ID is required"})
+		return
+	}
+
+	passes, err := h.passUsecase.ListEventPasses(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"passes": passes})
+}
+
+func (h *EventHandler) CreatePass(c *gin.Context) {
+	eventID := c.Param("id")
+	if eventID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event ID is required"})
+		return
+	}
+
+	var p pass.Pass
+	if err := c.ShouldBindJSON(&p); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	p.EventID = eventID
+	if err := h.passUsecase.CreatePass(c.Request.Context(), &p); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "pass created successfully", "pass": p})
+}
+
+func (h *EventHandler) DeletePass(c *gin.Context) {
+	err := h.passUsecase.DeletePass(c.Request.Context(), c.Param("id"), c.Param("passId"))
+	if err != nil {
+		switch {
+		case errors.Is(err, pass.ErrPassInUse):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case strings.HasSuffix(err.Error(), "not found"):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "pass deleted successfully"})
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/venue"
+	"evently/internal/usecase/impl"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeEventRepo struct {
+	events.EventRepository
+	created []*events.Event
+}
+
+func (r *fakeEventRepo) Create(event *events.Event) error {
+	r.created = append(r.created, event)
+	return nil
+}
+
+type fakeVenueRepo struct {
+	venue.VenueRepository
+}
+
+func (r *fakeVenueRepo) GetByName(ctx context.Context, name string) (*venue.Venue, error) {
+	return &venue.Venue{ID: "venue-1", Name: name, Timezone: "UTC", MaxCapacity: 1000}, nil
+}
+
+func TestCreateEventIgnoresLinks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := &fakeEventRepo{}
+	h := NewEventHandler(impl.NewEventUsecase(repo, &fakeVenueRepo{}, nil), nil)
+
+	router := gin.New()
+	router.POST("/events", func(c *gin.Context) {
+		c.Set("user_id", "organizer-1")
+		h.CreateEvent(c)
+	})
+
+	body := `{
+		"name": "Launch",
+		"venue": "Main Hall",
+		"event_time": "` + time.Now().Add(24*time.Hour).UTC().Format(time.RFC3339) + `",
+		"total_capacity": 100,
+		"parent_event_id": "someone-elses-event",
+		"series_id": "someone-elses-series",
+		"sequence": 7
+	}`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body)))
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if len(repo.created) != 1 {
+		t.Fatalf("created %d events", len(repo.created))
+	}
+	if stored := repo.created[0]; stored.ParentEventID != nil || stored.SeriesID != nil || stored.Sequence != 0 {
+		t.Errorf("stored parent %v, series %v, sequence %d; want none", stored.ParentEventID, stored.SeriesID, stored.Sequence)
+	}
+
+	var response struct {
+		Event map[string]any `json:"event"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if _, ok := response.Event["parent_event_id"]; ok {
+		t.Errorf("response links the event to a parent: %s", rec.Body)
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"evently/internal/domain/invitation"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InvitationHandler struct {
+	invitationUsecase invitation.InvitationUsecase
+}
+
+func NewInvitationHandler(invitationUsecase invitation.InvitationUsecase) *InvitationHandler {
+	return &InvitationHandler{
+		invitationUsecase: invitationUsecase,
+	}
+}
+
+// CreateInvitation issues an invitation. The response carries the signed
+// token, which has to be passed on to the invitee.
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	var req invitation.CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	inv, err := h.invitationUsecase.CreateInvitation(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		respondInvitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "invitation created successfully", "invitation": inv})
+}
+
+func (h *InvitationHandler) ListInvitations(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	status := invitation.Status(c.Query("status"))
+
+	switch status {
+	case "", invitation.StatusPending, invitation.StatusAccepted, invitation.StatusRevoked, invitation.StatusExpired:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+
+	invitations, err := h.invitationUsecase.ListInvitations(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+func (h *InvitationHandler) RevokeInvitation(c *gin.Context) {
+	if err := h.invitationUsecase.RevokeInvitation(c.Request.Context(), c.Param("id")); err != nil {
+		respondInvitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "invitation revoked successfully"})
+}
+
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	var req invitation.AcceptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.invitationUsecase.AcceptInvitation(c.Request.Context(), &req)
+	if err != nil {
+		respondInvitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "user registered successfully", "user": user})
+}
+
+func respondInvitationError(c *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.HasPrefix(err.Error(), "invitation is no longer valid"):
+		c.JSON(http.StatusGone, gin.H{"error": "invitation is no longer valid"})
+	case strings.HasSuffix(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+
+	"evently/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct {
+	tokens *middleware.JWTConfig
+}
+
+func NewJWKSHandler(tokens *middleware.JWTConfig) *JWKSHandler {
+	return &JWKSHandler{
+		tokens: tokens,
+	}
+}
+
+// GetJWKS publishes the public signing keys so other services can verify
+// our tokens. Keys scheduled for rotation are listed ahead of time, so
+// caching the set for a few minutes is safe.
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{"keys": h.tokens.JWKS()})
+}
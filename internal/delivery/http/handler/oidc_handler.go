@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"evently/internal/domain/auth"
+	"evently/internal/domain/oidc"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// oidcStateCookie keeps the login state in the browser that started
+	// the login, until the provider sends it back to the callback.
+	oidcStateCookie     = "evently_oidc_state"
+	oidcStateCookiePath = "/api/auth/oidc"
+)
+
+type OIDCHandler struct {
+	oidcUsecase  oidc.OIDCUsecase
+	secureCookie bool
+}
+
+// NewOIDCHandler returns the single sign-on handler. secureCookie limits
+// the state cookie to HTTPS, which needs the callback to be served over it.
+func NewOIDCHandler(oidcUsecase oidc.OIDCUsecase, secureCookie bool) *OIDCHandler {
+	return &OIDCHandler{
+		oidcUsecase:  oidcUsecase,
+		secureCookie: secureCookie,
+	}
+}
+
+// Login sends the browser to the identity provider.
+func (h *OIDCHandler) Login(c *gin.Context) {
+	authURL, state, err := h.oidcUsecase.StartLogin(c.Request.Context())
+	if err != nil {
+		respondOIDCError(c, err)
+		return
+	}
+
+	// Lax, as the provider sends the browser back with a top level GET
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, int(oidc.LoginStateTTL.Seconds()), oidcStateCookiePath, "", h.secureCookie, true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback is where the provider sends the browser back. It answers with
+// the same token pair as a password login.
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	if providerError := c.Query("error"); providerError != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": providerError, "error_description": c.Query("error_description")})
+		return
+	}
+
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code and state are required"})
+		return
+	}
+
+	// The state is single use, so the cookie goes whatever the outcome
+	browserState, _ := c.Cookie(oidcStateCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, "", -1, oidcStateCookiePath, "", h.secureCookie, true)
+
+	tokens, err := h.oidcUsecase.CompleteLogin(c.Request.Context(), state, browserState, code, c.ClientIP())
+	if err != nil {
+		respondOIDCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func respondOIDCError(c *gin.Context, err error) {
+	if respondMFAEnrollmentRequired(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, oidc.ErrInvalidState), errors.Is(err, oidc.ErrLoginRejected):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, auth.ErrAccountDisabled):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
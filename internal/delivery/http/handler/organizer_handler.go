@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"evently/internal/domain/organizer"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizerHandler struct {
+	organizerUsecase organizer.OrganizerUsecase
+}
+
+func NewOrganizerHandler(organizerUsecase organizer.OrganizerUsecase) *OrganizerHandler {
+	return &OrganizerHandler{
+		organizerUsecase: organizerUsecase,
+	}
+}
+
+type addCollaboratorRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+func (h *OrganizerHandler) ListCollaborators(c *gin.Context) {
+	collaborators, err := h.organizerUsecase.ListCollaborators(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondOrganizerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"collaborators": collaborators})
+}
+
+func (h *OrganizerHandler) AddCollaborator(c *gin.Context) {
+	var req addCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	collaborator, err := h.organizerUsecase.AddCollaborator(c.Request.Context(), c.Param("id"), req.Email, userID.(string))
+	if err != nil {
+		respondOrganizerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "collaborator added successfully", "collaborator": collaborator})
+}
+
+func (h *OrganizerHandler) RemoveCollaborator(c *gin.Context) {
+	if err := h.organizerUsecase.RemoveCollaborator(c.Request.Context(), c.Param("id"), c.Param("userId")); err != nil {
+		respondOrganizerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "collaborator removed successfully"})
+}
+
+func respondOrganizerError(c *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.HasSuffix(err.Error(), "not found") || strings.Contains(err.Error(), "not found:"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"evently/internal/domain/presale"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PresaleHandler struct {
+	presaleUsecase presale.PresaleUsecase
+}
+
+func NewPresaleHandler(presaleUsecase presale.PresaleUsecase) *PresaleHandler {
+	return &PresaleHandler{
+		presaleUsecase: presaleUsecase,
+	}
+}
+
+// GetSchedule returns the public sale schedule of an event.
+func (h *PresaleHandler) GetSchedule(c *gin.Context) {
+	schedule, err := h.presaleUsecase.GetSchedule(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "event not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "event not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sales": schedule})
+}
+
+func (h *PresaleHandler) ListPresales(c *gin.Context) {
+	presales, err := h.presaleUsecase.ListPresales(c.Request.Context(), c.Param("eventId"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"presales": presales})
+}
+
+func (h *PresaleHandler) CreatePresale(c *gin.Context) {
+	var p presale.Presale
+	if err := c.ShouldBindJSON(&p); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	p.EventID = c.Param("eventId")
+
+	if err := h.presaleUsecase.CreatePresale(c.Request.Context(), &p); err != nil {
+		switch {
+		case strings.HasPrefix(err.Error(), "validation failed"):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case strings.HasPrefix(err.Error(), "event not found"):
+			c.JSON(http.StatusNotFound, gin.H{"error": "event not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "presale created successfully", "presale": p})
+}
+
+func (h *PresaleHandler) DeletePresale(c *gin.Context) {
+	err := h.presaleUsecase.DeletePresale(c.Request.Context(), c.Param("eventId"), c.Param("presaleId"))
+	if err != nil {
+		if err.Error() == "presale not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "presale deleted successfully"})
+}
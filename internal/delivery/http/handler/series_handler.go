@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/series"
+	"evently/internal/domain/venue"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SeriesHandler struct {
+	seriesUsecase series.SeriesUsecase
+}
+
+func NewSeriesHandler(seriesUsecase series.SeriesUsecase) *SeriesHandler {
+	return &SeriesHandler{
+		seriesUsecase: seriesUsecase,
+	}
+}
+
+func (h *SeriesHandler) ListSeries(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	list, err := h.seriesUsecase.ListSeries(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"series": list})
+}
+
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	seriesID := c.Param("id")
+	if seriesID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "series ID is required"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	s, err := h.seriesUsecase.GetSeries(c.Request.Context(), seriesID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "series not found"})
+		return
+	}
+
+	occurrences, err := h.seriesUsecase.ListUpcomingOccurrences(c.Request.Context(), seriesID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"series": s, "occurrences": occurrences})
+}
+
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	var s series.Series
+	if err := c.ShouldBindJSON(&s); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	s.CreatedBy = userID.(string)
+
+	occurrences, err := h.seriesUsecase.CreateSeries(c.Request.Context(), &s)
+	if err != nil {
+		if errors.Is(err, venue.ErrCapacityExceeded) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "series created successfully", "series": s, "occurrences": occurrences})
+}
+
+func (h *SeriesHandler) UpdateOccurrences(c *gin.Context) {
+	seriesID := c.Param("id")
+	eventID := c.Param("eventId")
+	if seriesID == "" || eventID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "series ID and event ID are required"})
+		return
+	}
+
+	var update series.OccurrenceUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scope := series.EditScope(c.DefaultQuery("scope", string(series.EditScopeThis)))
+
+	updated, err := h.seriesUsecase.UpdateOccurrences(c.Request.Context(), seriesID, eventID, scope, &update)
+	if err != nil {
+		// Nothing was changed, the occurrences are updated all or none
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, events.ErrCapacityConflict):
+			status = http.StatusConflict
+		case strings.HasPrefix(err.Error(), "validation failed"):
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "occurrences updated successfully", "updated": updated})
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/realtime"
+	"evently/internal/domain/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// streamHeartbeat keeps idle streams from being cut by proxies.
+const streamHeartbeat = 25 * time.Second
+
+type StreamHandler struct {
+	broker              realtime.Broker
+	notificationUsecase usecase.NotificationUsecase
+	eventUsecase        events.EventUsecase
+}
+
+func NewStreamHandler(broker realtime.Broker, notificationUsecase usecase.NotificationUsecase, eventUsecase events.EventUsecase) *StreamHandler {
+	return &StreamHandler{
+		broker:              broker,
+		notificationUsecase: notificationUsecase,
+		eventUsecase:        eventUsecase,
+	}
+}
+
+// NotificationStream pushes the user's new notifications as server-sent
+// "notification" events. It starts with an "unread" event carrying the
+// unread count, so reconnecting clients know whether they missed any.
+func (h *StreamHandler) NotificationStream(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	// Subscribe first so nothing created meanwhile is missed
+	updates, cancel := h.broker.SubscribeNotifications(userID.(string))
+	defer cancel()
+
+	unread, err := h.notificationUsecase.GetUnreadNotificationCount(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	startStream(c)
+	c.SSEvent("unread", gin.H{"unread": unread})
+	// c.Stream only flushes after its first step, which may be a heartbeat
+	// away
+	c.Writer.Flush()
+	streamUpdates(c, updates, "notification")
+}
+
+// AvailabilityStream pushes "availability" events with the event's seat
+// counts whenever they change, starting with the current counts.
+func (h *StreamHandler) AvailabilityStream(c *gin.Context) {
+	eventID := c.Param("id")
+
+	updates, cancel := h.broker.SubscribeAvailability(eventID)
+	defer cancel()
+
+	event, err := h.eventUsecase.GetEvent(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "event not found"})
+		return
+	}
+
+	startStream(c)
+	c.SSEvent("availability", &realtime.Availability{
+		EventID:        event.ID,
+		AvailableSeats: event.AvailableSeats,
+		TotalCapacity:  event.TotalCapacity,
+	})
+	c.Writer.Flush()
+	streamUpdates(c, updates, "availability")
+}
+
+func startStream(c *gin.Context) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Stops nginx from buffering the stream
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+}
+
+// streamUpdates writes each update as an event named name until the client
+// goes away or the subscription ends, for instance on shutdown.
+func streamUpdates[T any](c *gin.Context, updates <-chan T, name string) {
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return false
+			}
+			c.SSEvent(name, update)
+			return true
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/template"
+	"evently/internal/domain/venue"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TemplateHandler struct {
+	templateUsecase template.TemplateUsecase
+}
+
+func NewTemplateHandler(templateUsecase template.TemplateUsecase) *TemplateHandler {
+	return &TemplateHandler{
+		templateUsecase: templateUsecase,
+	}
+}
+
+func (h *TemplateHandler) ListTemplates(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	templates, err := h.templateUsecase.ListTemplates(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	t, err := h.templateUsecase.GetTemplate(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"template": t})
+}
+
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	var t template.Template
+	if err := c.ShouldBindJSON(&t); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	t.CreatedBy = userID.(string)
+
+	if err := h.templateUsecase.CreateTemplate(c.Request.Context(), &t); err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "template created successfully", "template": t})
+}
+
+func (h *TemplateHandler) CreateTemplateFromEvent(c *gin.Context) {
+	// The body is optional and only carries the template name and description
+	var t template.Template
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&t); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	t.CreatedBy = userID.(string)
+
+	if err := h.templateUsecase.CreateTemplateFromEvent(c.Request.Context(), c.Param("eventId"), &t); err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "template created successfully", "template": t})
+}
+
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	var t template.Template
+	if err := c.ShouldBindJSON(&t); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	t.ID = c.Param("id")
+	if err := h.templateUsecase.UpdateTemplate(c.Request.Context(), &t); err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "template updated successfully", "template": t})
+}
+
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	if err := h.templateUsecase.DeleteTemplate(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "template deleted successfully"})
+}
+
+func (h *TemplateHandler) CreateEventFromTemplate(c *gin.Context) {
+	var overrides template.Overrides
+	if err := c.ShouldBindJSON(&overrides); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	event, err := h.templateUsecase.CreateEventFromTemplate(c.Request.Context(), c.Param("id"), &overrides, userID.(string))
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "event created successfully", "event": event})
+}
+
+type cloneEventRequest struct {
+	EventTime time.Time `json:"event_time" binding:"required"`
+}
+
+func (h *TemplateHandler) CloneEvent(c *gin.Context) {
+	var req cloneEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	event, err := h.templateUsecase.CloneEvent(c.Request.Context(), c.Param("eventId"), req.EventTime, userID.(string))
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "event cloned successfully", "event": event})
+}
+
+func respondTemplateError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, template.ErrDuplicateName):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, venue.ErrCapacityExceeded), errors.Is(err, events.ErrCapacityConflict),
+		strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case strings.HasSuffix(err.Error(), "not found") || strings.Contains(err.Error(), "not found:"):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"evently/internal/domain/venue"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VenueHandler struct {
+	venueUsecase venue.VenueUsecase
+}
+
+func NewVenueHandler(venueUsecase venue.VenueUsecase) *VenueHandler {
+	return &VenueHandler{
+		venueUsecase: venueUsecase,
+	}
+}
+
+func (h *VenueHandler) ListVenues(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	venues, err := h.venueUsecase.ListVenues(c.Request.Context(), c.Query("q"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"venues": venues})
+}
+
+func (h *VenueHandler) GetVenue(c *gin.Context) {
+	v, err := h.venueUsecase.GetVenue(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "venue not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"venue": v})
+}
+
+func (h *VenueHandler) CreateVenue(c *gin.Context) {
+	var v venue.Venue
+	if err := c.ShouldBindJSON(&v); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.venueUsecase.CreateVenue(c.Request.Context(), &v); err != nil {
+		if strings.HasPrefix(err.Error(), "validation failed") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "venue created successfully", "venue": v})
+}
+
+func (h *VenueHandler) UpdateVenue(c *gin.Context) {
+	var v venue.Venue
+	if err := c.ShouldBindJSON(&v); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	v.ID = c.Param("id")
+	if err := h.venueUsecase.UpdateVenue(c.Request.Context(), &v); err != nil {
+		if errors.Is(err, venue.ErrCapacityExceeded) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.HasPrefix(err.Error(), "validation failed") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "venue updated successfully", "venue": v})
+}
+
+func (h *VenueHandler) DeleteVenue(c *gin.Context) {
+	if err := h.venueUsecase.DeleteVenue(c.Request.Context(), c.Param("id")); err != nil {
+		// Venues still referenced by events are protected by the foreign key
+		if strings.Contains(err.Error(), "foreign key") {
+			c.JSON(http.StatusConflict, gin.H{"error": "venue still has events or series scheduled"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "venue deleted successfully"})
+}
+
+func (h *VenueHandler) FindNearbyEvents(c *gin.Context) {
+	lat, err := parseFloatQuery(c, "lat")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	lng, err := parseFloatQuery(c, "lng")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if lat == nil || lng == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "lat and lng are required"})
+		return
+	}
+	radius, err := parseFloatQuery(c, "radius_km")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var radiusKM float64
+	if radius != nil {
+		radiusKM = *radius
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	results, err := h.venueUsecase.FindNearbyEvents(c.Request.Context(), *lat, *lng, radiusKM, limit, offset)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": results})
+}
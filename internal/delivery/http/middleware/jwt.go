@@ -1,37 +1,143 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
-	"os"
 	"strings"
+	"time"
+
+	"evently/internal/domain/apikey"
+	"evently/internal/domain/model"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type CustomClaims struct {
-	UserID   string `json:"user_id"`
-	UserType string `json:"user_type"`
+	UserID      string   `json:"user_id"`
+	UserType    string   `json:"user_type"`
+	Permissions []string `json:"permissions"`
 	jwt.RegisteredClaims
 }
 
 type JWTConfig struct {
-	SecretKey string
+	Issuer   string
+	Audience string
+	// AccessTTL is the lifetime of access tokens. Sessions outlive it
+	// through refresh tokens, which last RefreshTTL.
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+
+	keys     *keyRing
+	apiKeys  APIKeyAuthenticator
+	accounts AccountStatusChecker
+}
+
+// APIKeyAuthenticator resolves an API key to the user and permissions it
+// acts with.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, plaintext string) (*apikey.Principal, error)
 }
 
-func NewJWTConfig() *JWTConfig {
-	secretKey := os.Getenv("JWT_SECRET_KEY")
-	if secretKey == "" {
-		secretKey = "default-secret-key"
+// UseAPIKeys makes AuthMiddleware accept API keys next to access tokens.
+func (j *JWTConfig) UseAPIKeys(authenticator APIKeyAuthenticator) {
+	j.apiKeys = authenticator
+}
+
+// AccountStatusChecker reports whether an account has been disabled.
+type AccountStatusChecker interface {
+	IsDisabled(ctx context.Context, userID string) (bool, error)
+}
+
+// UseAccountStatus makes AuthMiddleware refuse access tokens of disabled
+// accounts before they expire.
+func (j *JWTConfig) UseAccountStatus(checker AccountStatusChecker) {
+	j.accounts = checker
+}
+
+// NewJWTConfig loads the signing keys from cfg.KeysDir. Without a key
+// directory it refuses to start unless devMode is set, in which case it
+// signs with a key generated for this process.
+func NewJWTConfig(cfg model.JWTConfig, devMode bool) (*JWTConfig, error) {
+	var keys *keyRing
+	var err error
+	switch {
+	case cfg.KeysDir != "":
+		keys, err = loadKeyRing(cfg.KeysDir, cfg.KeyRetention, cfg.KeysReload)
+	case devMode:
+		log.Println("JWT_KEYS_DIR is not set, signing tokens with a throwaway development key")
+		keys, err = ephemeralKeyRing()
+	default:
+		return nil, fmt.Errorf("JWT_KEYS_DIR is required outside development")
 	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
 	return &JWTConfig{
-		SecretKey: secretKey,
+		Issuer:     cfg.Issuer,
+		Audience:   cfg.Audience,
+		AccessTTL:  cfg.AccessTTL,
+		RefreshTTL: cfg.RefreshTTL,
+		keys:       keys,
+	}, nil
+}
+
+// JWKS returns the public keys that verify our tokens, including keys
+// scheduled to take over.
+func (j *JWTConfig) JWKS() []JWK {
+	now := time.Now()
+	j.keys.reloadIfStale(now)
+	return j.keys.publicKeys(now)
+}
+
+// sign signs claims with the active key and names it in the kid header.
+func (j *JWTConfig) sign(claims jwt.Claims) (string, error) {
+	now := time.Now()
+	j.keys.reloadIfStale(now)
+
+	key, err := j.keys.signingKey(now)
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
+	return token.SignedString(key.private)
+}
+
+// keyFunc picks the verification key named by the kid header and makes sure
+// the token uses that key's algorithm.
+func (j *JWTConfig) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, fmt.Errorf("token has no kid")
+	}
+
+	key, err := j.keys.verificationKey(kid, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 	}
+
+	return key.private.Public(), nil
 }
 
+// AuthMiddleware authenticates the request with a Bearer access token, or
+// with an API key given as X-API-Key or as the Bearer token.
 func (j *JWTConfig) AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if key, ok := apiKeyFromRequest(c); ok {
+			j.authenticateAPIKey(c, key)
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
@@ -49,12 +155,13 @@ func (j *JWTConfig) AuthMiddleware() gin.HandlerFunc {
 
 		tokenString := tokenParts[1]
 
-		token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return []byte(j.SecretKey), nil
-		})
+		// nbf is checked whenever the token carries it
+		token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, j.keyFunc,
+			jwt.WithExpirationRequired(),
+			jwt.WithIssuedAt(),
+			jwt.WithIssuer(j.Issuer),
+			jwt.WithAudience(j.Audience),
+		)
 
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token: " + err.Error()})
@@ -62,28 +169,175 @@ func (j *JWTConfig) AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid {
+		// Invitation tokens carry no user and must not authenticate requests
+		if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid && claims.UserID != "" {
 			c.Set("user_id", claims.UserID)
 			c.Set("user_role", claims.UserType)
+			c.Set("user_permissions", claims.Permissions)
 		} else {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			c.Abort()
 			return
 		}
 
+		if !j.accountActive(c) {
+			return
+		}
+
 		c.Next()
 	}
 }
 
-// GenerateToken creates a new JWT token for a user
-func (j *JWTConfig) GenerateToken(userID, userType string) (string, error) {
+// accountActive aborts the request when the authenticated user has been
+// disabled since their token was issued.
+func (j *JWTConfig) accountActive(c *gin.Context) bool {
+	if j.accounts == nil {
+		return true
+	}
+
+	disabled, err := j.accounts.IsDisabled(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		c.Abort()
+		return false
+	}
+	if disabled {
+		c.JSON(http.StatusForbidden, gin.H{"error": "account is disabled"})
+		c.Abort()
+		return false
+	}
+
+	return true
+}
+
+// StreamAuth is AuthMiddleware for event streams. Browsers' EventSource
+// cannot set headers, so the access token may also be passed as the
+// access_token query parameter.
+func (j *JWTConfig) StreamAuth() gin.HandlerFunc {
+	authenticate := j.AuthMiddleware()
+
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" && c.GetHeader("X-API-Key") == "" {
+			if token := c.Query("access_token"); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+
+		authenticate(c)
+	}
+}
+
+func apiKeyFromRequest(c *gin.Context) (string, bool) {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key, true
+	}
+	token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if found && strings.HasPrefix(token, apikey.KeyPrefix) {
+		return token, true
+	}
+	return "", false
+}
+
+func (j *JWTConfig) authenticateAPIKey(c *gin.Context, key string) {
+	if j.apiKeys == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "API keys are not accepted"})
+		c.Abort()
+		return
+	}
+
+	principal, err := j.apiKeys.Authenticate(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, apikey.ErrInvalidAPIKey) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else {
+			log.Printf("failed to authenticate api key: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to authenticate api key"})
+		}
+		c.Abort()
+		return
+	}
+
+	if len(principal.Key.EventIDs) > 0 && !eventScopedRoute(c.Request.Method, c.FullPath()) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "this API key is restricted to events and cannot be used here"})
+		c.Abort()
+		return
+	}
+
+	c.Set("user_id", principal.UserID)
+	c.Set("user_role", principal.Role)
+	c.Set("user_permissions", principal.Permissions)
+	c.Set("api_key", principal.Key)
+	c.Request = c.Request.WithContext(apikey.NewContext(c.Request.Context(), principal.Key))
+
+	c.Next()
+}
+
+// GenerateToken creates a short-lived access token for a user with the
+// permissions of their role
+func (j *JWTConfig) GenerateToken(userID, userType string, permissions []string) (string, error) {
+	now := time.Now()
 	claims := CustomClaims{
-		UserID:   userID,
-		UserType: userType,
+		UserID:      userID,
+		UserType:    userType,
+		Permissions: permissions,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   userID,
+			Issuer:    j.Issuer,
+			Audience:  jwt.ClaimStrings{j.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(j.AccessTTL)),
+		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(j.SecretKey))
+	return j.sign(claims)
+}
+
+// InvitationClaims are the claims of an invitation token. The token ID is
+// the invitation ID.
+type InvitationClaims struct {
+	Email   string `json:"email"`
+	Role    string `json:"role"`
+	Purpose string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+const invitationPurpose = "invitation"
+
+// GenerateInvitationToken signs an invitation token that expires at
+// expiresAt.
+func (j *JWTConfig) GenerateInvitationToken(id, email, role string, expiresAt time.Time) (string, error) {
+	claims := InvitationClaims{
+		Email:   email,
+		Role:    role,
+		Purpose: invitationPurpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Issuer:    j.Issuer,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	return j.sign(claims)
+}
+
+// ParseInvitationToken verifies the signature and expiry of an invitation
+// token and returns its claims.
+func (j *JWTConfig) ParseInvitationToken(tokenString string) (*InvitationClaims, error) {
+	claims := &InvitationClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, j.keyFunc,
+		jwt.WithExpirationRequired(), jwt.WithIssuer(j.Issuer))
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid || claims.Purpose != invitationPurpose || claims.ID == "" {
+		return nil, fmt.Errorf("not an invitation token")
+	}
+
+	return claims, nil
 }
 
 // GetUserFromContext extracts user information from the context
@@ -104,3 +358,73 @@ func GetUserFromContext(c *gin.Context) (userID, userType string, ok bool) {
 
 	return userID, userType, true
 }
+
+// Purposes of step tokens, which stand in for a session during a multi-step
+// login. They carry no permissions and AuthMiddleware rejects them.
+const (
+	// MFAChallengePurpose tokens are exchanged for a session together with a
+	// second factor.
+	MFAChallengePurpose = "mfa_challenge"
+	// MFAEnrollPurpose tokens let users whose role requires two-factor
+	// authentication enroll before their first full login.
+	MFAEnrollPurpose = "mfa_enroll"
+)
+
+// StepClaims are the claims of a step token. The subject is the user.
+type StepClaims struct {
+	Purpose string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+// GenerateStepToken signs a step token for the user that expires after ttl.
+func (j *JWTConfig) GenerateStepToken(userID, purpose string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := StepClaims{
+		Purpose: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   userID,
+			Issuer:    j.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	return j.sign(claims)
+}
+
+// ParseStepToken verifies a step token of the given purpose and returns the
+// user it was issued to.
+func (j *JWTConfig) ParseStepToken(tokenString, purpose string) (string, error) {
+	claims := &StepClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, j.keyFunc,
+		jwt.WithExpirationRequired(), jwt.WithIssuer(j.Issuer))
+	if err != nil {
+		return "", err
+	}
+
+	if !token.Valid || claims.Purpose != purpose || claims.Subject == "" {
+		return "", fmt.Errorf("not a %s token", purpose)
+	}
+
+	return claims.Subject, nil
+}
+
+// MFAEnrollmentAuth accepts an access token like AuthMiddleware, or an
+// enrollment step token, which only identifies the user.
+func (j *JWTConfig) MFAEnrollmentAuth() gin.HandlerFunc {
+	authenticate := j.AuthMiddleware()
+
+	return func(c *gin.Context) {
+		tokenString, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if found {
+			if userID, err := j.ParseStepToken(tokenString, MFAEnrollPurpose); err == nil {
+				c.Set("user_id", userID)
+				c.Next()
+				return
+			}
+		}
+
+		authenticate(c)
+	}
+}
@@ -0,0 +1,279 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signingKey is one key of the ring. It signs tokens from activeFrom until
+// the next key becomes active.
+type signingKey struct {
+	kid        string
+	method     jwt.SigningMethod
+	private    crypto.Signer
+	activeFrom time.Time
+}
+
+// keyRing holds the signing keys. Keys are PEM files named
+// "<YYYY-MM-DD>[-suffix].pem" in dir; the file name is the kid and the date
+// is when the key starts signing. Dropping in a key with a future date
+// schedules a rotation: it is published right away and takes over on that
+// date. A replaced key keeps verifying for retention, which must cover the
+// longest lived token, and can be deleted afterwards.
+type keyRing struct {
+	dir         string
+	retention   time.Duration
+	reloadEvery time.Duration
+
+	mu       sync.RWMutex
+	keys     []*signingKey // ordered by activeFrom
+	loadedAt time.Time
+}
+
+func loadKeyRing(dir string, retention, reloadEvery time.Duration) (*keyRing, error) {
+	ring := &keyRing{dir: dir, retention: retention, reloadEvery: reloadEvery}
+
+	keys, err := readKeys(dir)
+	if err != nil {
+		return nil, err
+	}
+	ring.keys = keys
+	ring.loadedAt = time.Now()
+
+	if _, err := ring.signingKey(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return ring, nil
+}
+
+// ephemeralKeyRing generates a single Ed25519 key that lives as long as the
+// process. Only for development: tokens stop verifying on restart.
+func ephemeralKeyRing() (*keyRing, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+
+	return &keyRing{
+		keys: []*signingKey{{
+			kid:     "dev-" + hex.EncodeToString(suffix),
+			method:  jwt.SigningMethodEdDSA,
+			private: private,
+		}},
+		loadedAt: time.Now(),
+	}, nil
+}
+
+func readKeys(dir string) ([]*signingKey, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*signingKey, 0, len(paths))
+	for _, path := range paths {
+		key, err := readKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing keys found in %s", dir)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].activeFrom.Before(keys[j].activeFrom)
+	})
+
+	return keys, nil
+}
+
+func readKey(path string) (*signingKey, error) {
+	kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+	if len(kid) < len("2006-01-02") {
+		return nil, fmt.Errorf("file name must start with the activation date (YYYY-MM-DD)")
+	}
+	activeFrom, err := time.Parse("2006-01-02", kid[:len("2006-01-02")])
+	if err != nil {
+		return nil, fmt.Errorf("file name must start with the activation date (YYYY-MM-DD)")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	var parsed any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &signingKey{kid: kid, activeFrom: activeFrom}
+	switch private := parsed.(type) {
+	case *rsa.PrivateKey:
+		if private.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("RSA keys must have at least 2048 bits")
+		}
+		key.method = jwt.SigningMethodRS256
+		key.private = private
+	case ed25519.PrivateKey:
+		key.method = jwt.SigningMethodEdDSA
+		key.private = private
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, use RSA or Ed25519", parsed)
+	}
+
+	return key, nil
+}
+
+// reloadIfStale rereads the key directory at most every reloadEvery, so
+// new keys are picked up without a restart. A failed reload keeps the
+// current keys.
+func (r *keyRing) reloadIfStale(now time.Time) {
+	if r.dir == "" {
+		return
+	}
+
+	r.mu.RLock()
+	stale := now.Sub(r.loadedAt) >= r.reloadEvery
+	r.mu.RUnlock()
+	if !stale {
+		return
+	}
+
+	keys, err := readKeys(r.dir)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loadedAt = now
+	if err != nil {
+		log.Printf("failed to reload signing keys, keeping the current ones: %v", err)
+		return
+	}
+	r.keys = keys
+}
+
+// signingKey returns the newest key that is already active.
+func (r *keyRing) signingKey(now time.Time) (*signingKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		if !r.keys[i].activeFrom.After(now) {
+			return r.keys[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no signing key is active yet")
+}
+
+// verificationKey returns the key for kid if it may have signed a token
+// that is still valid.
+func (r *keyRing) verificationKey(kid string, now time.Time) (*signingKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i, key := range r.keys {
+		if key.kid != kid {
+			continue
+		}
+		if key.activeFrom.After(now) || r.retired(i, now) {
+			break
+		}
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// retired reports whether the key at index i was replaced longer than the
+// retention ago. Callers hold the lock.
+func (r *keyRing) retired(i int, now time.Time) bool {
+	for _, next := range r.keys[i+1:] {
+		if !next.activeFrom.After(now) {
+			return now.Sub(next.activeFrom) > r.retention
+		}
+	}
+	return false
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// publicKeys returns every key that is scheduled, active or still
+// verifying.
+func (r *keyRing) publicKeys(now time.Time) []JWK {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := []JWK{}
+	for i, key := range r.keys {
+		if r.retired(i, now) {
+			continue
+		}
+
+		jwk := JWK{Kid: key.kid, Use: "sig", Alg: key.method.Alg()}
+		switch public := key.private.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		keys = append(keys, jwk)
+	}
+
+	return keys
+}
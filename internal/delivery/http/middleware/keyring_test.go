@@ -0,0 +1,282 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeKey(t *testing.T, dir, name string, key crypto.PrivateKey) {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+}
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return private
+}
+
+func TestReadKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	weakRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		key     crypto.PrivateKey
+		wantAlg string
+		wantErr string
+	}{
+		{name: "ed25519", file: "2026-11-01.pem", key: newEd25519Key(t), wantAlg: "EdDSA"},
+		{name: "rsa", file: "2026-11-01-b.pem", key: rsaKey, wantAlg: "RS256"},
+		{name: "weak rsa", file: "2026-11-01.pem", key: weakRSAKey, wantErr: "at least 2048 bits"},
+		{name: "ecdsa", file: "2026-11-01.pem", key: ecKey, wantErr: "unsupported key type"},
+		{name: "no date", file: "signing.pem", key: newEd25519Key(t), wantErr: "activation date"},
+		{name: "bad date", file: "2026-13-01.pem", key: newEd25519Key(t), wantErr: "activation date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeKey(t, dir, tt.file, tt.key)
+
+			key, err := readKey(filepath.Join(dir, tt.file))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readKey error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readKey failed: %v", err)
+			}
+			if key.kid != strings.TrimSuffix(tt.file, ".pem") {
+				t.Errorf("kid = %q", key.kid)
+			}
+			if key.method.Alg() != tt.wantAlg {
+				t.Errorf("alg = %q, want %q", key.method.Alg(), tt.wantAlg)
+			}
+			if want := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC); !key.activeFrom.Equal(want) {
+				t.Errorf("activeFrom = %s, want %s", key.activeFrom, want)
+			}
+		})
+	}
+}
+
+func TestReadKeyNotPEM(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "2026-11-01.pem")
+	if err := os.WriteFile(path, []byte("not a key"), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	if _, err := readKey(path); err == nil || !strings.Contains(err.Error(), "no PEM block") {
+		t.Fatalf("readKey error = %v, want no PEM block", err)
+	}
+}
+
+func TestLoadKeyRing(t *testing.T) {
+	t.Run("empty directory", func(t *testing.T) {
+		if _, err := loadKeyRing(t.TempDir(), time.Hour, time.Minute); err == nil {
+			t.Fatal("expected an error without keys")
+		}
+	})
+
+	t.Run("only future keys", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKey(t, dir, "2999-01-01.pem", newEd25519Key(t))
+		if _, err := loadKeyRing(dir, time.Hour, time.Minute); err == nil {
+			t.Fatal("expected an error without an active key")
+		}
+	})
+}
+
+func TestKeyRingRotation(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "2026-01-01.pem", newEd25519Key(t))
+	writeKey(t, dir, "2026-06-01.pem", newEd25519Key(t))
+	writeKey(t, dir, "2999-01-01.pem", newEd25519Key(t))
+
+	ring, err := loadKeyRing(dir, 30*24*time.Hour, time.Minute)
+	if err != nil {
+		t.Fatalf("loadKeyRing failed: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		now         time.Time
+		wantSigning string
+		verifies    []string
+		refuses     []string
+		published   []string
+	}{
+		{
+			name:        "before the rotation",
+			now:         time.Date(2026, 5, 31, 12, 0, 0, 0, time.UTC),
+			wantSigning: "2026-01-01",
+			verifies:    []string{"2026-01-01"},
+			refuses:     []string{"2026-06-01", "2999-01-01", "unknown"},
+			published:   []string{"2026-01-01", "2026-06-01", "2999-01-01"},
+		},
+		{
+			name:        "within the retention",
+			now:         time.Date(2026, 6, 20, 0, 0, 0, 0, time.UTC),
+			wantSigning: "2026-06-01",
+			verifies:    []string{"2026-01-01", "2026-06-01"},
+			refuses:     []string{"2999-01-01"},
+			published:   []string{"2026-01-01", "2026-06-01", "2999-01-01"},
+		},
+		{
+			name:        "after the retention",
+			now:         time.Date(2026, 7, 2, 0, 0, 0, 0, time.UTC),
+			wantSigning: "2026-06-01",
+			verifies:    []string{"2026-06-01"},
+			refuses:     []string{"2026-01-01", "2999-01-01"},
+			published:   []string{"2026-06-01", "2999-01-01"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ring.signingKey(tt.now)
+			if err != nil {
+				t.Fatalf("signingKey failed: %v", err)
+			}
+			if key.kid != tt.wantSigning {
+				t.Errorf("signing key = %q, want %q", key.kid, tt.wantSigning)
+			}
+
+			for _, kid := range tt.verifies {
+				if _, err := ring.verificationKey(kid, tt.now); err != nil {
+					t.Errorf("verificationKey(%q) failed: %v", kid, err)
+				}
+			}
+			for _, kid := range tt.refuses {
+				if _, err := ring.verificationKey(kid, tt.now); err == nil {
+					t.Errorf("verificationKey(%q) succeeded, want an error", kid)
+				}
+			}
+
+			// Scheduled keys are published before they verify
+			var published []string
+			for _, jwk := range ring.publicKeys(tt.now) {
+				published = append(published, jwk.Kid)
+			}
+			if strings.Join(published, ",") != strings.Join(tt.published, ",") {
+				t.Errorf("published keys = %v, want %v", published, tt.published)
+			}
+		})
+	}
+}
+
+func TestPublicKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	dir := t.TempDir()
+	writeKey(t, dir, "2026-01-01.pem", rsaKey)
+	writeKey(t, dir, "2026-01-02.pem", newEd25519Key(t))
+
+	ring, err := loadKeyRing(dir, 30*24*time.Hour, time.Minute)
+	if err != nil {
+		t.Fatalf("loadKeyRing failed: %v", err)
+	}
+
+	keys := ring.publicKeys(time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC))
+	if len(keys) != 2 {
+		t.Fatalf("got %d keys, want 2", len(keys))
+	}
+	if rsaJWK := keys[0]; rsaJWK.Kty != "RSA" || rsaJWK.Alg != "RS256" || rsaJWK.E != "AQAB" || rsaJWK.N == "" {
+		t.Errorf("RSA key = %+v", rsaJWK)
+	}
+	if edJWK := keys[1]; edJWK.Kty != "OKP" || edJWK.Crv != "Ed25519" || edJWK.Alg != "EdDSA" || edJWK.X == "" {
+		t.Errorf("Ed25519 key = %+v", edJWK)
+	}
+}
+
+func TestReloadIfStale(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "2026-01-01.pem", newEd25519Key(t))
+
+	ring, err := loadKeyRing(dir, time.Hour, time.Minute)
+	if err != nil {
+		t.Fatalf("loadKeyRing failed: %v", err)
+	}
+	writeKey(t, dir, "2026-02-01.pem", newEd25519Key(t))
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Not stale yet: the new key is not seen
+	ring.loadedAt = now.Add(-30 * time.Second)
+	ring.reloadIfStale(now)
+	if key, _ := ring.signingKey(now); key.kid != "2026-01-01" {
+		t.Fatalf("signing key = %q before the reload interval passed", key.kid)
+	}
+
+	ring.loadedAt = now.Add(-time.Minute)
+	ring.reloadIfStale(now)
+	if key, _ := ring.signingKey(now); key.kid != "2026-02-01" {
+		t.Fatalf("signing key = %q after reload, want 2026-02-01", key.kid)
+	}
+
+	// A broken directory keeps the current keys
+	if err := os.WriteFile(filepath.Join(dir, "broken.pem"), []byte("x"), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	later := now.Add(time.Hour)
+	ring.reloadIfStale(later)
+	if key, _ := ring.signingKey(later); key.kid != "2026-02-01" {
+		t.Fatalf("signing key = %q after a failed reload", key.kid)
+	}
+	if !ring.loadedAt.Equal(later) {
+		t.Errorf("loadedAt = %s, want %s", ring.loadedAt, later)
+	}
+}
+
+func TestEphemeralKeyRing(t *testing.T) {
+	ring, err := ephemeralKeyRing()
+	if err != nil {
+		t.Fatalf("ephemeralKeyRing failed: %v", err)
+	}
+	key, err := ring.signingKey(time.Now())
+	if err != nil {
+		t.Fatalf("signingKey failed: %v", err)
+	}
+	if !strings.HasPrefix(key.kid, "dev-") {
+		t.Errorf("kid = %q", key.kid)
+	}
+	// Never reloads, as there is no directory
+	ring.reloadIfStale(time.Now().Add(24 * time.Hour))
+	if _, err := ring.verificationKey(key.kid, time.Now()); err != nil {
+		t.Errorf("verificationKey failed: %v", err)
+	}
+}
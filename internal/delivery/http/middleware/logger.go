@@ -1 +1,34 @@
 package middleware
+
+import (
+	"log"
+	"time"
+
+	"evently/internal/domain/apikey"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestLogger logs one line per request with who made it: the user, and
+// the API key's ID and prefix when one was used.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+
+		c.Next()
+
+		principal := "-"
+		if userID, exists := c.Get("user_id"); exists {
+			principal = "user=" + userID.(string)
+		}
+		if value, exists := c.Get("api_key"); exists {
+			if key, ok := value.(*apikey.APIKey); ok {
+				principal += " api_key=" + key.ID + " (" + apikey.KeyPrefix + key.Prefix + ")"
+			}
+		}
+
+		log.Printf("%s %s %d %s %s %s",
+			c.Request.Method, path, c.Writer.Status(), time.Since(start), c.ClientIP(), principal)
+	}
+}
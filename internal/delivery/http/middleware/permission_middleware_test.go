@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"evently/internal/domain/apikey"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeAPIKeys map[string]*apikey.Principal
+
+func (f fakeAPIKeys) Authenticate(ctx context.Context, plaintext string) (*apikey.Principal, error) {
+	principal, ok := f[plaintext]
+	if !ok {
+		return nil, apikey.ErrInvalidAPIKey
+	}
+	return principal, nil
+}
+
+func TestRestrictedAPIKeyRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	j := &JWTConfig{}
+	j.UseAPIKeys(fakeAPIKeys{
+		"restricted": {Key: &apikey.APIKey{EventIDs: []string{"event-1"}}, UserID: "user-1"},
+		"open":       {Key: &apikey.APIKey{}, UserID: "user-1"},
+	})
+	ok := func(c *gin.Context) {
+		if !EventAllowed(c, c.Param("id")) {
+			c.Status(http.StatusForbidden)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+
+	router := gin.New()
+	api := router.Group("/api")
+	api.Use(j.AuthMiddleware())
+	api.GET("/admin/events", ok)
+	api.POST("/events", ok)
+	api.PUT("/events/:id", ok)
+	api.GET("/series", ok)
+
+	tests := []struct {
+		key    string
+		method string
+		path   string
+		want   int
+	}{
+		{key: "restricted", method: http.MethodPut, path: "/api/events/event-1", want: http.StatusOK},
+		{key: "restricted", method: http.MethodPut, path: "/api/events/event-2", want: http.StatusForbidden},
+		{key: "restricted", method: http.MethodGet, path: "/api/admin/events", want: http.StatusForbidden},
+		{key: "restricted", method: http.MethodPost, path: "/api/events", want: http.StatusForbidden},
+		{key: "restricted", method: http.MethodGet, path: "/api/series", want: http.StatusForbidden},
+		{key: "open", method: http.MethodGet, path: "/api/admin/events", want: http.StatusOK},
+		{key: "open", method: http.MethodPut, path: "/api/events/event-2", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+" "+tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("X-API-Key", tt.key)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
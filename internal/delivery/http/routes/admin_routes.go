@@ -3,20 +3,25 @@ package routes
 import (
 	"evently/internal/delivery/http/handler"
 	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Create, update, and manage events.
 // View booking analytics (total bookings, most popular events, capacity utilization).
-func SetupAdminRoutes(router *gin.RouterGroup, adminHandler *handler.AdminHandler, jwtMiddleware *middleware.JWTConfig) {
+// Without events:manage_all only managed events are visible.
+func SetupAdminRoutes(router *gin.RouterGroup, adminHandler *handler.AdminHandler, jwtMiddleware *middleware.JWTConfig, eventAccess middleware.EventAccessChecker) {
 	adminGroup := router.Group("/admin")
 	adminGroup.Use(jwtMiddleware.AuthMiddleware())
-	adminGroup.Use(middleware.AdminMiddleware())
+	adminGroup.Use(middleware.RequirePermission(rbac.PermAnalyticsRead))
 	{
+		canManage := middleware.RequireEventAccess(eventAccess, "eventId")
+
 		adminGroup.GET("/events", adminHandler.GetAllEvents)
-		adminGroup.GET("/events/:eventId/bookings", adminHandler.GetEventBookings)
-		adminGroup.GET("/events/:eventId/analytics", adminHandler.GetBookingAnalytics)
+		adminGroup.GET("/events/:eventId/bookings", canManage, adminHandler.GetEventBookings)
+		adminGroup.GET("/events/:eventId/analytics", canManage, adminHandler.GetBookingAnalytics)
+		adminGroup.GET("/events/:eventId/sessions/analytics", canManage, adminHandler.GetSessionUtilization)
 		adminGroup.GET("/analytics/events", adminHandler.GetEventAnalytics)
 	}
 }
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// API keys are managed by admins signed in as themselves; a key cannot
+// mint or revoke keys.
+func SetupAPIKeyRoutes(router *gin.RouterGroup, apiKeyHandler *handler.APIKeyHandler, jwtMiddleware *middleware.JWTConfig) {
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(jwtMiddleware.AuthMiddleware())
+	adminGroup.Use(middleware.RequireUserSession())
+	adminGroup.Use(middleware.RequirePermission(rbac.PermAPIKeysManage))
+	{
+		adminGroup.GET("/api-keys", apiKeyHandler.ListKeys)
+		adminGroup.POST("/api-keys", apiKeyHandler.CreateKey)
+		adminGroup.DELETE("/api-keys/:id", apiKeyHandler.RevokeKey)
+	}
+}
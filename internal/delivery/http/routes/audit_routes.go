@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAuditRoutes(router *gin.RouterGroup, auditHandler *handler.AuditHandler, jwtMiddleware *middleware.JWTConfig) {
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(jwtMiddleware.AuthMiddleware())
+	adminGroup.Use(middleware.RequirePermission(rbac.PermUsersManage))
+	{
+		adminGroup.GET("/audit-logs", auditHandler.ListEntries)
+	}
+}
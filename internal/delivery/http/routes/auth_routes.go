@@ -2,14 +2,32 @@ package routes
 
 import (
 	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupAuthRoutes(router *gin.RouterGroup, authHandler *handler.AuthHandler) {
+func SetupAuthRoutes(router *gin.RouterGroup, authHandler *handler.AuthHandler, jwtMiddleware *middleware.JWTConfig) {
 	authGroup := router.Group("/auth")
 	{
 		authGroup.POST("/register", authHandler.Register)
 		authGroup.POST("/login", authHandler.Login)
+		authGroup.POST("/refresh", authHandler.Refresh)
+		authGroup.POST("/logout", authHandler.Logout)
+
+		authGroup.POST("/password/forgot", authHandler.ForgotPassword)
+		authGroup.POST("/password/reset", authHandler.ResetPassword)
+		authGroup.POST("/email/verify", authHandler.VerifyEmail)
+		authGroup.POST("/email/verify/resend", authHandler.RequestEmailVerification)
+		authGroup.POST("/email/change/confirm", authHandler.ConfirmEmailChange)
+	}
+
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(jwtMiddleware.AuthMiddleware())
+	adminGroup.Use(middleware.RequirePermission(rbac.PermUsersManage))
+	{
+		adminGroup.DELETE("/users/:id/sessions", authHandler.RevokeSessions)
+		adminGroup.POST("/users/:id/unlock", authHandler.UnlockAccount)
 	}
 }
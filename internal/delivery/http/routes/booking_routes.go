@@ -3,17 +3,25 @@ package routes
 import (
 	"evently/internal/delivery/http/handler"
 	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupBookingRoutes(router *gin.RouterGroup, bookingHandler *handler.BookingHandler, jwtMiddleware *middleware.JWTConfig) {
+func SetupBookingRoutes(router *gin.RouterGroup, bookingHandler *handler.BookingHandler, jwtMiddleware *middleware.JWTConfig, emailCheck middleware.EmailVerificationChecker, eventAccess middleware.EventAccessChecker) {
 	bookingGroup := router.Group("/bookings")
 	bookingGroup.Use(jwtMiddleware.AuthMiddleware())
 	{
-		bookingGroup.POST("", bookingHandler.CreateBooking)
-		bookingGroup.GET("/my", bookingHandler.GetUserBookings)
-		bookingGroup.GET("/:id", bookingHandler.GetBooking)
-		bookingGroup.PUT("/:id/cancel", bookingHandler.CancelBooking)
+		bookingGroup.POST("", middleware.RequirePermission(rbac.PermBookingsCreate), middleware.RequireVerifiedEmail(emailCheck), bookingHandler.CreateBooking)
+		bookingGroup.GET("/my", middleware.RequirePermission(rbac.PermBookingsCreate), bookingHandler.GetUserBookings)
+		bookingGroup.GET("/:id", middleware.RequireAnyPermission(rbac.PermBookingsCreate, rbac.PermBookingsReadAll), bookingHandler.GetBooking)
+		bookingGroup.PUT("/:id/cancel", middleware.RequirePermission(rbac.PermBookingsCreate), bookingHandler.CancelBooking)
 	}
+
+	// Door staff scan tickets of the events they can manage
+	router.POST("/events/:id/bookings/:bookingId/check-in",
+		jwtMiddleware.AuthMiddleware(),
+		middleware.RequirePermission(rbac.PermCheckinScan),
+		middleware.RequireEventAccess(eventAccess, "id"),
+		bookingHandler.CheckIn)
 }
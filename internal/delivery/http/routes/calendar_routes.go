@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// iCalendar downloads and feeds. Feeds are fetched by calendar apps that
+// cannot send a JWT, so the user feed is protected by its secret token.
+func SetupCalendarRoutes(router *gin.RouterGroup, calendarHandler *handler.CalendarHandler, jwtMiddleware *middleware.JWTConfig) {
+	router.GET("/calendar/feeds/:token", calendarHandler.GetUserFeed)
+	router.GET("/calendar/categories/:id", calendarHandler.GetCategoryFeed)
+	router.GET("/calendar/venues/:id", calendarHandler.GetVenueFeed)
+
+	authGroup := router.Group("")
+	authGroup.Use(jwtMiddleware.AuthMiddleware())
+	{
+		authGroup.GET("/bookings/:id/ics", middleware.RequireAnyPermission(rbac.PermBookingsCreate, rbac.PermBookingsReadAll), calendarHandler.GetBookingICS)
+		authGroup.POST("/calendar/token", middleware.RequirePermission(rbac.PermBookingsCreate), calendarHandler.CreateFeedToken)
+	}
+}
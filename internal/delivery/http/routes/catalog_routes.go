@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Categories, tags and curated collections. Reads are public, changes need
+// catalog:write.
+func SetupCatalogRoutes(router *gin.RouterGroup, catalogHandler *handler.CatalogHandler, jwtMiddleware *middleware.JWTConfig) {
+	router.GET("/categories", catalogHandler.ListCategories)
+	router.GET("/tags", catalogHandler.ListTags)
+	router.GET("/collections", catalogHandler.ListCollections)
+	router.GET("/collections/:slug", catalogHandler.GetCollection)
+
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(jwtMiddleware.AuthMiddleware())
+	adminGroup.Use(middleware.RequirePermission(rbac.PermCatalogWrite))
+	{
+		adminGroup.POST("/categories", catalogHandler.CreateCategory)
+		adminGroup.PUT("/categories/:id", catalogHandler.UpdateCategory)
+		adminGroup.DELETE("/categories/:id", catalogHandler.DeleteCategory)
+
+		adminGroup.POST("/tags", catalogHandler.CreateTag)
+		adminGroup.PUT("/tags/:id", catalogHandler.UpdateTag)
+		adminGroup.DELETE("/tags/:id", catalogHandler.DeleteTag)
+
+		adminGroup.POST("/collections", catalogHandler.CreateCollection)
+		adminGroup.PUT("/collections/:id", catalogHandler.UpdateCollection)
+		adminGroup.DELETE("/collections/:id", catalogHandler.DeleteCollection)
+	}
+
+	// Category analytics cover every event, not only managed ones
+	analyticsGroup := router.Group("/admin")
+	analyticsGroup.Use(jwtMiddleware.AuthMiddleware())
+	analyticsGroup.Use(middleware.RequirePermission(rbac.PermAnalyticsRead, rbac.PermEventsManageAll))
+	{
+		analyticsGroup.GET("/analytics/categories", catalogHandler.GetCategoryAnalytics)
+	}
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Users manage the browsers they get web push on; admins watch and retry
+// deliveries.
+func SetupDispatchRoutes(router *gin.RouterGroup, dispatchHandler *handler.DispatchHandler, jwtMiddleware *middleware.JWTConfig) {
+	pushGroup := router.Group("/notifications")
+	pushGroup.Use(jwtMiddleware.AuthMiddleware())
+	pushGroup.Use(middleware.RequireUserSession())
+	{
+		pushGroup.GET("/push/public-key", dispatchHandler.GetVAPIDPublicKey)
+		pushGroup.GET("/push-subscriptions", dispatchHandler.ListSubscriptions)
+		pushGroup.POST("/push-subscriptions", dispatchHandler.Subscribe)
+		pushGroup.DELETE("/push-subscriptions/:id", dispatchHandler.Unsubscribe)
+	}
+
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(jwtMiddleware.AuthMiddleware())
+	adminGroup.Use(middleware.RequirePermission(rbac.PermNotificationsManage))
+	{
+		adminGroup.GET("/notification-deliveries", dispatchHandler.ListDeliveries)
+		adminGroup.POST("/notification-deliveries/:id/retry", dispatchHandler.RetryDelivery)
+	}
+}
@@ -3,24 +3,34 @@ package routes
 import (
 	"evently/internal/delivery/http/handler"
 	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupEventRoutes(router *gin.RouterGroup, eventHandler *handler.EventHandler, jwtMiddleware *middleware.JWTConfig) {
+func SetupEventRoutes(router *gin.RouterGroup, eventHandler *handler.EventHandler, jwtMiddleware *middleware.JWTConfig, eventAccess middleware.EventAccessChecker) {
 	eventGroup := router.Group("/events")
 	{
 		// Public routes
 		eventGroup.GET("", eventHandler.ListUpcomingEvents)
+		eventGroup.GET("/search", eventHandler.SearchEvents)
 		eventGroup.GET("/:id", eventHandler.GetEvent)
+		eventGroup.GET("/:id/sessions", eventHandler.ListSessions)
+		eventGroup.GET("/:id/passes", eventHandler.ListPasses)
 
-		adminGroup := eventGroup.Group("")
-		adminGroup.Use(jwtMiddleware.AuthMiddleware())
-		adminGroup.Use(middleware.AdminMiddleware())
+		// events:manage_all covers every event, events:write the events the
+		// user owns or collaborates on
+		manageGroup := eventGroup.Group("")
+		manageGroup.Use(jwtMiddleware.AuthMiddleware())
 		{
-			adminGroup.POST("", eventHandler.CreateEvent)
-			adminGroup.PUT("/:id", eventHandler.UpdateEvent)
-			adminGroup.DELETE("/:id", eventHandler.DeleteEvent)
+			manageGroup.POST("", middleware.RequirePermission(rbac.PermEventsWrite), eventHandler.CreateEvent)
+
+			canManage := middleware.RequireEventAccess(eventAccess, "id")
+			manageGroup.PUT("/:id", canManage, eventHandler.UpdateEvent)
+			manageGroup.DELETE("/:id", canManage, eventHandler.DeleteEvent)
+			manageGroup.POST("/:id/sessions", canManage, eventHandler.CreateSession)
+			manageGroup.POST("/:id/passes", canManage, eventHandler.CreatePass)
+			manageGroup.DELETE("/:id/passes/:passId", canManage, eventHandler.DeletePass)
 		}
 	}
 }
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Invitations are issued by admins and accepted without an account.
+func SetupInvitationRoutes(router *gin.RouterGroup, invitationHandler *handler.InvitationHandler, jwtMiddleware *middleware.JWTConfig) {
+	router.POST("/auth/invitations/accept", invitationHandler.AcceptInvitation)
+
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(jwtMiddleware.AuthMiddleware())
+	adminGroup.Use(middleware.RequirePermission(rbac.PermRolesManage))
+	{
+		adminGroup.GET("/invitations", invitationHandler.ListInvitations)
+		adminGroup.POST("/invitations", invitationHandler.CreateInvitation)
+		adminGroup.DELETE("/invitations/:id", invitationHandler.RevokeInvitation)
+	}
+}
@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupMFARoutes(router *gin.RouterGroup, authHandler *handler.AuthHandler, mfaHandler *handler.MFAHandler, jwtMiddleware *middleware.JWTConfig) {
+	mfaGroup := router.Group("/auth/mfa")
+	{
+		// Second step of a login, authenticated by the challenge token
+		mfaGroup.POST("/verify", authHandler.VerifyMFA)
+	}
+
+	// Users whose role requires two-factor can enroll with the token the
+	// login handed out instead of a session
+	enrollGroup := router.Group("/auth/mfa")
+	enrollGroup.Use(jwtMiddleware.MFAEnrollmentAuth())
+	enrollGroup.Use(middleware.RequireUserSession())
+	{
+		enrollGroup.POST("/setup", mfaHandler.Setup)
+		enrollGroup.POST("/enable", mfaHandler.Enable)
+	}
+
+	protectedGroup := router.Group("/auth/mfa")
+	protectedGroup.Use(jwtMiddleware.AuthMiddleware())
+	protectedGroup.Use(middleware.RequireUserSession())
+	{
+		protectedGroup.GET("", mfaHandler.GetStatus)
+		protectedGroup.POST("/disable", mfaHandler.Disable)
+		protectedGroup.POST("/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+	}
+}
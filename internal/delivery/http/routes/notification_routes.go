@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Every route acts on the signed in user's own notifications.
+func SetupNotificationRoutes(router *gin.RouterGroup, notificationHandler *handler.NotificationHandler, jwtMiddleware *middleware.JWTConfig) {
+	notificationGroup := router.Group("/notifications")
+	notificationGroup.Use(jwtMiddleware.AuthMiddleware())
+	notificationGroup.Use(middleware.RequireUserSession())
+	{
+		notificationGroup.GET("", notificationHandler.ListNotifications)
+		notificationGroup.GET("/unread-count", notificationHandler.GetUnreadCount)
+		notificationGroup.PUT("/read-all", notificationHandler.MarkAllAsRead)
+		notificationGroup.PUT("/:id/read", notificationHandler.MarkAsRead)
+		notificationGroup.DELETE("/:id", notificationHandler.DeleteNotification)
+	}
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Single sign-on is only routed when OIDC_ISSUER is set.
+func SetupOIDCRoutes(router *gin.RouterGroup, oidcHandler *handler.OIDCHandler) {
+	oidcGroup := router.Group("/auth/oidc")
+	{
+		oidcGroup.GET("/login", oidcHandler.Login)
+		oidcGroup.GET("/callback", oidcHandler.Callback)
+	}
+}
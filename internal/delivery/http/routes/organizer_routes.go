@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Collaborators share the management of an event with its owner.
+func SetupOrganizerRoutes(router *gin.RouterGroup, organizerHandler *handler.OrganizerHandler, jwtMiddleware *middleware.JWTConfig, eventAccess middleware.EventAccessChecker) {
+	collaboratorGroup := router.Group("/events/:id/collaborators")
+	collaboratorGroup.Use(jwtMiddleware.AuthMiddleware())
+	collaboratorGroup.Use(middleware.RequireEventAccess(eventAccess, "id"))
+	{
+		collaboratorGroup.GET("", organizerHandler.ListCollaborators)
+		collaboratorGroup.POST("", organizerHandler.AddCollaborator)
+		collaboratorGroup.DELETE("/:userId", organizerHandler.RemoveCollaborator)
+	}
+}
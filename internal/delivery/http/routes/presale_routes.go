@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Sale schedules are public, presales are managed by whoever manages the
+// event.
+func SetupPresaleRoutes(router *gin.RouterGroup, presaleHandler *handler.PresaleHandler, jwtMiddleware *middleware.JWTConfig, eventAccess middleware.EventAccessChecker) {
+	router.GET("/events/:id/sales", presaleHandler.GetSchedule)
+
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(jwtMiddleware.AuthMiddleware())
+	adminGroup.Use(middleware.RequireEventAccess(eventAccess, "eventId"))
+	{
+		adminGroup.GET("/events/:eventId/presales", presaleHandler.ListPresales)
+		adminGroup.POST("/events/:eventId/presales", presaleHandler.CreatePresale)
+		adminGroup.DELETE("/events/:eventId/presales/:presaleId", presaleHandler.DeletePresale)
+	}
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Data exports and account deletion. Only users signed in as themselves
+// reach their own data here; API keys cannot.
+func SetupPrivacyRoutes(router *gin.RouterGroup, privacyHandler *handler.PrivacyHandler, jwtMiddleware *middleware.JWTConfig) {
+	meGroup := router.Group("/users/me")
+	meGroup.Use(jwtMiddleware.AuthMiddleware())
+	meGroup.Use(middleware.RequireUserSession())
+	{
+		meGroup.GET("/export", privacyHandler.ExportData)
+		meGroup.POST("/deletion", privacyHandler.RequestDeletion)
+		meGroup.DELETE("/deletion", privacyHandler.CancelMyDeletion)
+	}
+
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(jwtMiddleware.AuthMiddleware())
+	adminGroup.Use(middleware.RequirePermission(rbac.PermUsersManage))
+	{
+		adminGroup.DELETE("/users/:id", privacyHandler.DeleteUser)
+		adminGroup.DELETE("/users/:id/deletion", privacyHandler.CancelDeletion)
+	}
+}
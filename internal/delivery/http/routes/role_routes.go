@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Roles, their permissions and role assignment.
+func SetupRoleRoutes(router *gin.RouterGroup, roleHandler *handler.RoleHandler, jwtMiddleware *middleware.JWTConfig) {
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(jwtMiddleware.AuthMiddleware())
+	adminGroup.Use(middleware.RequirePermission(rbac.PermRolesManage))
+	{
+		adminGroup.GET("/permissions", roleHandler.ListPermissions)
+
+		adminGroup.GET("/roles", roleHandler.ListRoles)
+		adminGroup.GET("/roles/:name", roleHandler.GetRole)
+		adminGroup.POST("/roles", roleHandler.CreateRole)
+		adminGroup.PUT("/roles/:name", roleHandler.UpdateRole)
+		adminGroup.DELETE("/roles/:name", roleHandler.DeleteRole)
+
+		adminGroup.PUT("/users/:id/role", roleHandler.AssignRole)
+	}
+}
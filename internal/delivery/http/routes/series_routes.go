@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSeriesRoutes(router *gin.RouterGroup, seriesHandler *handler.SeriesHandler, jwtMiddleware *middleware.JWTConfig) {
+	seriesGroup := router.Group("/series")
+	{
+		// Public routes
+		seriesGroup.GET("", seriesHandler.ListSeries)
+		seriesGroup.GET("/:id", seriesHandler.GetSeries)
+
+		adminGroup := seriesGroup.Group("")
+		adminGroup.Use(jwtMiddleware.AuthMiddleware())
+		adminGroup.Use(middleware.RequirePermission(rbac.PermSeriesWrite))
+		{
+			adminGroup.POST("", seriesHandler.CreateSeries)
+			adminGroup.PUT("/:id/occurrences/:eventId", seriesHandler.UpdateOccurrences)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"strings"
+
 	"evently/internal/delivery/http/handler"
 	"evently/internal/delivery/http/middleware"
 	"evently/internal/di"
@@ -10,16 +12,67 @@ import (
 
 func AllRoutes(router *gin.Engine, container *di.Container, jwtMiddleware *middleware.JWTConfig) {
 
-	authHandler := handler.NewAuthHandler(container.AuthUseCase)
-	eventHandler := handler.NewEventHandler(container.EventUseCase)
+	authHandler := handler.NewAuthHandler(container.AuthUseCase, container.AccountUseCase)
+	eventHandler := handler.NewEventHandler(container.EventUseCase, container.PassUseCase)
 	bookingHandler := handler.NewBookingHandler(container.BookingUseCase, container.WaitlistUseCase)
 	adminHandler := handler.NewAdminHandler(container.EventUseCase, container.BookingUseCase)
+	seriesHandler := handler.NewSeriesHandler(container.SeriesUseCase)
+	catalogHandler := handler.NewCatalogHandler(container.CatalogUseCase)
+	venueHandler := handler.NewVenueHandler(container.VenueUseCase)
+	calendarHandler := handler.NewCalendarHandler(container.CalendarUseCase, container.BookingUseCase, container.Config.PublicURL)
+	templateHandler := handler.NewTemplateHandler(container.TemplateUseCase)
+	presaleHandler := handler.NewPresaleHandler(container.PresaleUseCase)
+	organizerHandler := handler.NewOrganizerHandler(container.OrganizerUseCase)
+	roleHandler := handler.NewRoleHandler(container.RoleUseCase)
+	invitationHandler := handler.NewInvitationHandler(container.InvitationUseCase)
+	jwksHandler := handler.NewJWKSHandler(jwtMiddleware)
+	auditHandler := handler.NewAuditHandler(container.AuditUseCase)
+	mfaHandler := handler.NewMFAHandler(container.MFAUseCase)
+	apiKeyHandler := handler.NewAPIKeyHandler(container.APIKeyUseCase)
+	userHandler := handler.NewUserHandler(container.UserUseCase)
+	privacyHandler := handler.NewPrivacyHandler(container.PrivacyUseCase)
+	notificationHandler := handler.NewNotificationHandler(container.NotificationUseCase)
+	dispatchHandler := handler.NewDispatchHandler(container.DispatchUseCase)
+	streamHandler := handler.NewStreamHandler(container.Broker, container.NotificationUseCase, container.EventUseCase)
+
+	// Checks whether organizers may manage the event in a request
+	eventAccess := container.OrganizerUseCase
+
+	// Bookings need a verified email only when REQUIRE_VERIFIED_EMAIL is set
+	var emailCheck middleware.EmailVerificationChecker
+	if container.Config.Auth.RequireVerifiedEmail {
+		emailCheck = container.AccountUseCase
+	}
+
+	SetupWellKnownRoutes(router, jwksHandler)
 
 	api := router.Group("/api")
 	{
-		SetupAuthRoutes(api, authHandler)
-		SetupEventRoutes(api, eventHandler, jwtMiddleware)
-		SetupBookingRoutes(api, bookingHandler, jwtMiddleware)
-		SetupAdminRoutes(api, adminHandler, jwtMiddleware)
+		SetupAuthRoutes(api, authHandler, jwtMiddleware)
+		SetupMFARoutes(api, authHandler, mfaHandler, jwtMiddleware)
+		SetupEventRoutes(api, eventHandler, jwtMiddleware, eventAccess)
+		SetupBookingRoutes(api, bookingHandler, jwtMiddleware, emailCheck, eventAccess)
+		SetupAdminRoutes(api, adminHandler, jwtMiddleware, eventAccess)
+		SetupSeriesRoutes(api, seriesHandler, jwtMiddleware)
+		SetupCatalogRoutes(api, catalogHandler, jwtMiddleware)
+		SetupVenueRoutes(api, venueHandler, jwtMiddleware)
+		SetupCalendarRoutes(api, calendarHandler, jwtMiddleware)
+		SetupTemplateRoutes(api, templateHandler, jwtMiddleware)
+		SetupPresaleRoutes(api, presaleHandler, jwtMiddleware, eventAccess)
+		SetupOrganizerRoutes(api, organizerHandler, jwtMiddleware, eventAccess)
+		SetupRoleRoutes(api, roleHandler, jwtMiddleware)
+		SetupInvitationRoutes(api, invitationHandler, jwtMiddleware)
+		SetupAuditRoutes(api, auditHandler, jwtMiddleware)
+		SetupAPIKeyRoutes(api, apiKeyHandler, jwtMiddleware)
+		SetupUserRoutes(api, userHandler, jwtMiddleware)
+		SetupPrivacyRoutes(api, privacyHandler, jwtMiddleware)
+		SetupNotificationRoutes(api, notificationHandler, jwtMiddleware)
+		SetupDispatchRoutes(api, dispatchHandler, jwtMiddleware)
+		SetupStreamRoutes(api, streamHandler, jwtMiddleware)
+
+		if container.OIDCUseCase != nil {
+			secureCookie := strings.HasPrefix(container.Config.OIDC.RedirectURL, "https://")
+			SetupOIDCRoutes(api, handler.NewOIDCHandler(container.OIDCUseCase, secureCookie))
+		}
 	}
 }
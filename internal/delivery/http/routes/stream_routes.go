@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Server-sent event streams. Seat availability is public like the event
+// itself; notifications are the signed in user's own.
+func SetupStreamRoutes(router *gin.RouterGroup, streamHandler *handler.StreamHandler, jwtMiddleware *middleware.JWTConfig) {
+	router.GET("/events/:id/availability/stream", streamHandler.AvailabilityStream)
+
+	notificationGroup := router.Group("/notifications")
+	notificationGroup.Use(jwtMiddleware.StreamAuth())
+	notificationGroup.Use(middleware.RequireUserSession())
+	{
+		notificationGroup.GET("/stream", streamHandler.NotificationStream)
+	}
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Event templates and cloning, admin only.
+func SetupTemplateRoutes(router *gin.RouterGroup, templateHandler *handler.TemplateHandler, jwtMiddleware *middleware.JWTConfig) {
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(jwtMiddleware.AuthMiddleware())
+	adminGroup.Use(middleware.RequirePermission(rbac.PermTemplatesWrite))
+	{
+		adminGroup.GET("/templates", templateHandler.ListTemplates)
+		adminGroup.GET("/templates/:id", templateHandler.GetTemplate)
+		adminGroup.POST("/templates", templateHandler.CreateTemplate)
+		adminGroup.PUT("/templates/:id", templateHandler.UpdateTemplate)
+		adminGroup.DELETE("/templates/:id", templateHandler.DeleteTemplate)
+		adminGroup.POST("/templates/:id/events", templateHandler.CreateEventFromTemplate)
+
+		adminGroup.POST("/events/:eventId/template", templateHandler.CreateTemplateFromEvent)
+		adminGroup.POST("/events/:eventId/clone", templateHandler.CloneEvent)
+	}
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Profiles are changed by users signed in as themselves; roles are
+// assigned under SetupRoleRoutes.
+func SetupUserRoutes(router *gin.RouterGroup, userHandler *handler.UserHandler, jwtMiddleware *middleware.JWTConfig) {
+	meGroup := router.Group("/users/me")
+	meGroup.Use(jwtMiddleware.AuthMiddleware())
+	meGroup.Use(middleware.RequireUserSession())
+	{
+		meGroup.GET("", userHandler.GetMe)
+		meGroup.PATCH("", userHandler.UpdateMe)
+	}
+
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(jwtMiddleware.AuthMiddleware())
+	adminGroup.Use(middleware.RequirePermission(rbac.PermUsersManage))
+	{
+		adminGroup.GET("/users", userHandler.ListUsers)
+		adminGroup.GET("/users/:id", userHandler.GetUser)
+		adminGroup.POST("/users/:id/disable", userHandler.DisableUser)
+		adminGroup.POST("/users/:id/enable", userHandler.EnableUser)
+	}
+}
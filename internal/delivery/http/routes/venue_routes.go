@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Venues and the events-near-me search. Reads are public, changes are admin
+// only.
+func SetupVenueRoutes(router *gin.RouterGroup, venueHandler *handler.VenueHandler, jwtMiddleware *middleware.JWTConfig) {
+	router.GET("/venues", venueHandler.ListVenues)
+	router.GET("/venues/:id", venueHandler.GetVenue)
+	router.GET("/events/nearby", venueHandler.FindNearbyEvents)
+
+	adminGroup := router.Group("/admin")
+	adminGroup.Use(jwtMiddleware.AuthMiddleware())
+	adminGroup.Use(middleware.RequirePermission(rbac.PermVenuesWrite))
+	{
+		adminGroup.POST("/venues", venueHandler.CreateVenue)
+		adminGroup.PUT("/venues/:id", venueHandler.UpdateVenue)
+		adminGroup.DELETE("/venues/:id", venueHandler.DeleteVenue)
+	}
+}
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"evently/internal/delivery/http/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Well-known URIs live at the root, outside /api.
+func SetupWellKnownRoutes(router *gin.Engine, jwksHandler *handler.JWKSHandler) {
+	router.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+}
@@ -2,16 +2,39 @@ package di
 
 import (
 	"context"
+	"fmt"
 
 	"evently/internal/config"
 	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/apikey"
+	"evently/internal/domain/audit"
+	"evently/internal/domain/auth"
 	"evently/internal/domain/booking"
+	"evently/internal/domain/calendar"
+	"evently/internal/domain/catalog"
+	"evently/internal/domain/channel"
+	"evently/internal/domain/dispatch"
 	"evently/internal/domain/events"
+	"evently/internal/domain/invitation"
+	"evently/internal/domain/mfa"
+	"evently/internal/domain/oidc"
+	"evently/internal/domain/organizer"
+	"evently/internal/domain/pass"
+	"evently/internal/domain/presale"
+	"evently/internal/domain/privacy"
+	"evently/internal/domain/rbac"
+	"evently/internal/domain/realtime"
+	"evently/internal/domain/series"
+	"evently/internal/domain/template"
+	"evently/internal/domain/venue"
 	"evently/internal/domain/waitlist"
 
 	"evently/internal/domain/model"
 	"evently/internal/domain/usecase"
+	channelImpl "evently/internal/usecase/channel"
 	ucImpl "evently/internal/usecase/impl"
+	oidcImpl "evently/internal/usecase/oidc"
+	realtimeImpl "evently/internal/usecase/realtime"
 	repoImpl "evently/internal/usecase/repository"
 
 	"github.com/gin-gonic/gin"
@@ -31,6 +54,34 @@ type Container struct {
 	BookingRepo      booking.BookingRepository
 	WaitlistRepo     waitlist.WaitlistRepository
 	NotificationRepo model.NotificationRepository
+	SeriesRepo       series.SeriesRepository
+	PassRepo         pass.PassRepository
+	CatalogRepo      catalog.CatalogRepository
+	VenueRepo        venue.VenueRepository
+	CalendarRepo     calendar.CalendarRepository
+	TemplateRepo     template.TemplateRepository
+	PresaleRepo      presale.PresaleRepository
+	OrganizerRepo    organizer.OrganizerRepository
+	RoleRepo         rbac.RoleRepository
+	InvitationRepo   invitation.InvitationRepository
+	RefreshTokenRepo auth.RefreshTokenRepository
+	AccountTokenRepo auth.AccountTokenRepository
+	LoginAttemptRepo auth.LoginAttemptRepository
+	AuditRepo        audit.AuditRepository
+	MFARepo          mfa.MFARepository
+	APIKeyRepo       apikey.APIKeyRepository
+	OIDCRepo         oidc.OIDCRepository
+	PrivacyRepo      privacy.PrivacyRepository
+	DeliveryRepo     dispatch.DeliveryRepository
+	PushRepo         dispatch.PushSubscriptionRepository
+
+	// Channels
+	EmailChannel   channel.Channel
+	SMSChannel     channel.Channel
+	WebPushChannel channel.Channel
+
+	// Streaming
+	Broker realtime.Broker
 
 	// Use Cases
 	AuthUseCase         usecase.AuthUseCase
@@ -38,6 +89,24 @@ type Container struct {
 	BookingUseCase      booking.BookingUsecase
 	WaitlistUseCase     waitlist.WaitlistUsecase
 	NotificationUseCase usecase.NotificationUsecase
+	SeriesUseCase       series.SeriesUsecase
+	PassUseCase         pass.PassUsecase
+	CatalogUseCase      catalog.CatalogUsecase
+	VenueUseCase        venue.VenueUsecase
+	CalendarUseCase     calendar.CalendarUsecase
+	TemplateUseCase     template.TemplateUsecase
+	PresaleUseCase      presale.PresaleUsecase
+	OrganizerUseCase    organizer.OrganizerUsecase
+	RoleUseCase         rbac.RoleUsecase
+	InvitationUseCase   invitation.InvitationUsecase
+	AccountUseCase      auth.AccountUsecase
+	AuditUseCase        audit.AuditUsecase
+	MFAUseCase          mfa.MFAUsecase
+	APIKeyUseCase       apikey.APIKeyUsecase
+	OIDCUseCase         oidc.OIDCUsecase
+	UserUseCase         usecase.UserUseCase
+	PrivacyUseCase      privacy.PrivacyUsecase
+	DispatchUseCase     dispatch.DispatchUsecase
 
 	// Middleware
 	JWTMiddleware *middleware.JWTConfig
@@ -50,6 +119,34 @@ func NewContainer(ctx context.Context) (*Container, error) {
 	// Load configuration
 	cfg := config.LoadConfig()
 
+	// Refuse to start without signing keys before touching the database
+	jwtMiddleware, err := middleware.NewJWTConfig(cfg.JWT, cfg.IsDevelopment())
+	if err != nil {
+		return nil, err
+	}
+
+	accessCodeKey, err := ucImpl.NewAccessCodeKey(cfg.Presale, cfg.IsDevelopment())
+	if err != nil {
+		return nil, err
+	}
+
+	emailChannel, err := channelImpl.NewEmailChannel(cfg.Mail)
+	if err != nil {
+		return nil, err
+	}
+	// Text messages stay off without SMS_CHANNEL, web push without a VAPID
+	// key
+	smsChannel, err := channelImpl.NewSMSChannel(cfg.SMS)
+	if err != nil {
+		return nil, err
+	}
+	var vapidPublicKey string
+	if cfg.WebPush.Enabled() {
+		if vapidPublicKey, err = channelImpl.VAPIDPublicKey(cfg.WebPush.VAPIDPrivateKey); err != nil {
+			return nil, err
+		}
+	}
+
 	// Initialize database connection
 	pool, err := config.NewPGXPool(ctx, cfg.DB)
 	if err != nil {
@@ -62,17 +159,88 @@ func NewContainer(ctx context.Context) (*Container, error) {
 	bookingRepo := repoImpl.NewBookingRepository(pool)
 	waitlistRepo := repoImpl.NewWaitlistRepository(pool)
 	notificationRepo := repoImpl.NewNotificationRepository(pool)
+	seriesRepo := repoImpl.NewSeriesRepository(pool)
+	passRepo := repoImpl.NewPassRepository(pool)
+	catalogRepo := repoImpl.NewCatalogRepository(pool)
+	venueRepo := repoImpl.NewVenueRepository(pool)
+	calendarRepo := repoImpl.NewCalendarRepository(pool)
+	templateRepo := repoImpl.NewTemplateRepository(pool)
+	presaleRepo := repoImpl.NewPresaleRepository(pool)
+	organizerRepo := repoImpl.NewOrganizerRepository(pool)
+	roleRepo := repoImpl.NewRoleRepository(pool)
+	invitationRepo := repoImpl.NewInvitationRepository(pool)
+	refreshTokenRepo := repoImpl.NewRefreshTokenRepository(pool)
+	accountTokenRepo := repoImpl.NewAccountTokenRepository(pool)
+	loginAttemptRepo := repoImpl.NewLoginAttemptRepository(pool)
+	auditRepo := repoImpl.NewAuditRepository(pool)
+	mfaRepo := repoImpl.NewMFARepository(pool)
+	apiKeyRepo := repoImpl.NewAPIKeyRepository(pool)
+	oidcRepo := repoImpl.NewOIDCRepository(pool)
+	privacyRepo := repoImpl.NewPrivacyRepository(pool)
+	deliveryRepo := repoImpl.NewDeliveryRepository(pool)
+	pushRepo := repoImpl.NewPushSubscriptionRepository(pool)
+
+	// Push endpoints on private addresses are only reachable in development
+	webPushChannel, err := channelImpl.NewWebPushChannel(cfg.WebPush, pushRepo, cfg.IsDevelopment())
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	// Pushes database changes to the event streams of this instance
+	broker := realtimeImpl.NewBroker(pool, notificationRepo)
 
 	// Initialize use cases
-	authUseCase := ucImpl.NewAuthUseCase(userRepo, cfg)
-	eventUseCase := ucImpl.NewEventUsecase(eventRepo)
+	authUseCase := ucImpl.NewAuthUseCase(userRepo, roleRepo, refreshTokenRepo, loginAttemptRepo, mfaRepo, auditRepo, jwtMiddleware, cfg)
 	notificationUseCase := ucImpl.NewNotificationUsecase(notificationRepo, eventRepo)
-	waitlistUseCase := ucImpl.NewWaitlistUsecase(waitlistRepo, eventRepo, notificationRepo)
-	bookingUseCase := ucImpl.NewBookingUsecase(bookingRepo, eventRepo, waitlistUseCase)
+	waitlistUseCase := ucImpl.NewWaitlistUsecase(waitlistRepo, eventRepo, presaleRepo, accessCodeKey, notificationRepo)
+	eventUseCase := ucImpl.NewEventUsecase(eventRepo, venueRepo, waitlistUseCase)
+	bookingUseCase := ucImpl.NewBookingUsecase(bookingRepo, eventRepo, passRepo, presaleRepo, accessCodeKey, waitlistUseCase)
+	seriesUseCase := ucImpl.NewSeriesUsecase(seriesRepo, venueRepo, eventUseCase, waitlistUseCase)
+	passUseCase := ucImpl.NewPassUsecase(passRepo, eventRepo)
+	catalogUseCase := ucImpl.NewCatalogUsecase(catalogRepo)
+	venueUseCase := ucImpl.NewVenueUsecase(venueRepo)
+	calendarUseCase := ucImpl.NewCalendarUsecase(calendarRepo, eventRepo, catalogRepo, venueRepo)
+	templateUseCase := ucImpl.NewTemplateUsecase(templateRepo, venueRepo, eventUseCase, passUseCase)
+	presaleUseCase := ucImpl.NewPresaleUsecase(presaleRepo, eventRepo, userRepo, accessCodeKey)
+	organizerUseCase := ucImpl.NewOrganizerUsecase(organizerRepo, eventRepo, userRepo, roleRepo)
+	roleUseCase := ucImpl.NewRoleUsecase(roleRepo, userRepo)
+	invitationUseCase := ucImpl.NewInvitationUsecase(invitationRepo, userRepo, roleRepo, jwtMiddleware)
+	auditUseCase := ucImpl.NewAuditUsecase(auditRepo)
+	accountUseCase := ucImpl.NewAccountUsecase(userRepo, accountTokenRepo, emailChannel, cfg.Auth.AppURL)
+	mfaUseCase := ucImpl.NewMFAUsecase(mfaRepo, userRepo, roleRepo, loginAttemptRepo, auditRepo, cfg)
+	apiKeyUseCase := ucImpl.NewAPIKeyUsecase(apiKeyRepo, userRepo, roleRepo, eventRepo, auditRepo)
+	userUseCase := ucImpl.NewUserUsecase(userRepo, refreshTokenRepo, loginAttemptRepo, auditRepo, authUseCase, accountUseCase)
+	privacyUseCase := ucImpl.NewPrivacyUsecase(privacyRepo, userRepo, bookingRepo, waitlistRepo, notificationRepo, pushRepo, loginAttemptRepo, auditRepo, cfg.Auth.DeletionGracePeriod)
+	dispatchUseCase := ucImpl.NewDispatchUsecase(deliveryRepo, pushRepo, notificationRepo, []channel.Channel{emailChannel, smsChannel, webPushChannel}, cfg.Delivery, vapidPublicKey)
 
-	jwtMiddleware := middleware.NewJWTConfig()
+	// Single sign-on stays off, and unrouted, without OIDC_ISSUER
+	var oidcUseCase oidc.OIDCUsecase
+	if cfg.OIDC.Enabled() {
+		provider, err := oidcImpl.NewProvider(cfg.OIDC)
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+		oidcUseCase, err = ucImpl.NewOIDCUsecase(oidcRepo, provider, userRepo, roleRepo, refreshTokenRepo, auditRepo, authUseCase, cfg.OIDC.GroupRoles)
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+	}
+
+	// Protected routes accept API keys as well as access tokens, and refuse
+	// accounts disabled after their token was issued
+	jwtMiddleware.UseAPIKeys(apiKeyUseCase)
+	jwtMiddleware.UseAccountStatus(userUseCase)
 
-	server := gin.Default()
+	server := gin.New()
+	server.Use(middleware.RequestLogger(), gin.Recovery())
+	// Client IPs feed login throttling, so only listed proxies are believed
+	if err := server.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
 
 	return &Container{
 		Config:              cfg,
@@ -82,11 +250,53 @@ func NewContainer(ctx context.Context) (*Container, error) {
 		BookingRepo:         bookingRepo,
 		WaitlistRepo:        waitlistRepo,
 		NotificationRepo:    notificationRepo,
+		SeriesRepo:          seriesRepo,
+		PassRepo:            passRepo,
+		CatalogRepo:         catalogRepo,
+		VenueRepo:           venueRepo,
+		CalendarRepo:        calendarRepo,
+		TemplateRepo:        templateRepo,
+		PresaleRepo:         presaleRepo,
+		OrganizerRepo:       organizerRepo,
+		RoleRepo:            roleRepo,
+		InvitationRepo:      invitationRepo,
+		RefreshTokenRepo:    refreshTokenRepo,
+		AccountTokenRepo:    accountTokenRepo,
+		LoginAttemptRepo:    loginAttemptRepo,
+		AuditRepo:           auditRepo,
+		MFARepo:             mfaRepo,
+		APIKeyRepo:          apiKeyRepo,
+		OIDCRepo:            oidcRepo,
+		PrivacyRepo:         privacyRepo,
+		DeliveryRepo:        deliveryRepo,
+		PushRepo:            pushRepo,
+		EmailChannel:        emailChannel,
+		SMSChannel:          smsChannel,
+		WebPushChannel:      webPushChannel,
+		Broker:              broker,
 		AuthUseCase:         authUseCase,
 		EventUseCase:        eventUseCase,
 		BookingUseCase:      bookingUseCase,
 		WaitlistUseCase:     waitlistUseCase,
 		NotificationUseCase: notificationUseCase,
+		SeriesUseCase:       seriesUseCase,
+		PassUseCase:         passUseCase,
+		CatalogUseCase:      catalogUseCase,
+		VenueUseCase:        venueUseCase,
+		CalendarUseCase:     calendarUseCase,
+		TemplateUseCase:     templateUseCase,
+		PresaleUseCase:      presaleUseCase,
+		OrganizerUseCase:    organizerUseCase,
+		RoleUseCase:         roleUseCase,
+		InvitationUseCase:   invitationUseCase,
+		AccountUseCase:      accountUseCase,
+		AuditUseCase:        auditUseCase,
+		MFAUseCase:          mfaUseCase,
+		APIKeyUseCase:       apiKeyUseCase,
+		OIDCUseCase:         oidcUseCase,
+		UserUseCase:         userUseCase,
+		PrivacyUseCase:      privacyUseCase,
+		DispatchUseCase:     dispatchUseCase,
 		JWTMiddleware:       jwtMiddleware,
 		Server:              server,
 	}, nil
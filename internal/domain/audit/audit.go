@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Actions recorded in the audit log.
+const (
+	ActionLoginLocked   = "login.locked"
+	ActionLoginUnlocked = "login.unlocked"
+	ActionLoginIPLocked = "login.ip_locked"
+
+	ActionMFAEnabled                  = "mfa.enabled"
+	ActionMFADisabled                 = "mfa.disabled"
+	ActionMFARecoveryCodeUsed         = "mfa.recovery_code_used"
+	ActionMFARecoveryCodesRegenerated = "mfa.recovery_codes_regenerated"
+
+	ActionAPIKeyCreated = "api_key.created"
+	ActionAPIKeyRevoked = "api_key.revoked"
+
+	ActionSSOLinked      = "sso.linked"
+	ActionSSOProvisioned = "sso.provisioned"
+	ActionSSORoleSynced  = "sso.role_synced"
+
+	ActionUserDisabled             = "user.disabled"
+	ActionUserEnabled              = "user.enabled"
+	ActionUserPasswordChanged      = "user.password_changed"
+	ActionUserEmailChangeRequested = "user.email_change_requested"
+	ActionUserDeletionRequested    = "user.deletion_requested"
+	ActionUserDeletionCancelled    = "user.deletion_cancelled"
+	ActionUserAnonymized           = "user.anonymized"
+)
+
+// Entry records a security relevant action. ActorID is empty for actions
+// the system took on its own, such as locking an account. APIKeyID is set
+// when the actor used an API key.
+type Entry struct {
+	ID         string         `json:"id" db:"id"`
+	ActorID    *string        `json:"actor_id,omitempty" db:"actor_id"`
+	APIKeyID   *string        `json:"api_key_id,omitempty" db:"api_key_id"`
+	Action     string         `json:"action" db:"action"`
+	TargetType string         `json:"target_type" db:"target_type"`
+	TargetID   string         `json:"target_id" db:"target_id"`
+	IP         string         `json:"ip" db:"ip"`
+	Metadata   map[string]any `json:"metadata" db:"metadata"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+}
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *Entry) error
+	// List returns the newest entries first, filtered by action when it is
+	// set.
+	List(ctx context.Context, action string, limit, offset int) ([]*Entry, error)
+}
+
+type AuditUsecase interface {
+	ListEntries(ctx context.Context, action string, limit, offset int) ([]*Entry, error)
+}
@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrInvalidRefreshToken is returned for unknown, expired or revoked
+// refresh tokens.
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+// ErrRefreshTokenReused is returned when a refresh token that was already
+// exchanged is presented again. The token may have been stolen, so its
+// whole family is revoked.
+var ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+
+// TokenPair is returned on login and refresh. The access token is kept
+// under "token" for existing clients.
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	// ExpiresIn is the lifetime of the access token in seconds
+	ExpiresIn int `json:"expires_in"`
+}
+
+// Lifetimes of the step tokens handed out between the password and the
+// second factor.
+const (
+	MFAChallengeTTL = 5 * time.Minute
+	MFAEnrollTTL    = 15 * time.Minute
+)
+
+// ErrInvalidMFAToken is returned for expired or forged step tokens.
+var ErrInvalidMFAToken = errors.New("invalid or expired mfa token")
+
+// ErrInvalidMFACode is returned for wrong, reused or unknown two-factor and
+// recovery codes.
+var ErrInvalidMFACode = errors.New("invalid two-factor code")
+
+// MFAEnrollmentRequiredError is returned instead of a session while the
+// user's role requires two-factor authentication and they have not set it
+// up. MFAToken only opens the setup endpoints.
+type MFAEnrollmentRequiredError struct {
+	MFAToken string
+}
+
+func (e *MFAEnrollmentRequiredError) Error() string {
+	return "two-factor authentication must be set up before logging in"
+}
+
+// LoginResult is either a session, or a step token when the password was
+// right but a second step is needed: a two-factor code, or enrollment when
+// the user's role requires two-factor authentication.
+type LoginResult struct {
+	Tokens                *TokenPair
+	MFAToken              string
+	MFAEnrollmentRequired bool
+}
+
+// RefreshToken is a stored refresh token. Only the hash of the token is
+// kept. Tokens issued by refreshing the same login share a family.
+type RefreshToken struct {
+	ID         string     `json:"id" db:"id"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	FamilyID   string     `json:"family_id" db:"family_id"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UsedAt     *time.Time `json:"used_at,omitempty" db:"used_at"`
+	ReplacedBy *string    `json:"replaced_by,omitempty" db:"replaced_by"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	// Rotate marks the token used and stores its replacement in one
+	// transaction. It returns ErrRefreshTokenReused when the token was
+	// already used or revoked in the meantime.
+	Rotate(ctx context.Context, usedID string, next *RefreshToken, at time.Time) error
+	RevokeFamily(ctx context.Context, familyID string, at time.Time) error
+	// RevokeAllForUser revokes every active token of the user and returns
+	// how many were revoked.
+	RevokeAllForUser(ctx context.Context, userID string, at time.Time) (int, error)
+}
+
+// ErrInvalidAccountToken is returned for unknown, used or expired password
+// reset and email verification tokens.
+var ErrInvalidAccountToken = errors.New("invalid or expired token")
+
+// TokenPurpose says what an account token may be used for.
+type TokenPurpose string
+
+const (
+	PurposePasswordReset     TokenPurpose = "password_reset"
+	PurposeEmailVerification TokenPurpose = "email_verification"
+	PurposeEmailChange       TokenPurpose = "email_change"
+)
+
+// Lifetimes of account tokens.
+const (
+	PasswordResetTTL     = time.Hour
+	EmailVerificationTTL = 48 * time.Hour
+)
+
+// AccountToken is a single-use token sent by email. Only its hash is
+// stored.
+type AccountToken struct {
+	ID        string       `json:"id" db:"id"`
+	UserID    string       `json:"user_id" db:"user_id"`
+	Purpose   TokenPurpose `json:"purpose" db:"purpose"`
+	TokenHash string       `json:"-" db:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time   `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+}
+
+type AccountTokenRepository interface {
+	// Create stores the token and discards unused tokens of the same
+	// purpose for the user, so only the latest email works.
+	Create(ctx context.Context, token *AccountToken) error
+	// Consume marks an unused, unexpired token used and returns it, or
+	// returns ErrInvalidAccountToken.
+	Consume(ctx context.Context, tokenHash string, purpose TokenPurpose, at time.Time) (*AccountToken, error)
+	// ResetPassword consumes a password reset token, sets the password of
+	// its user, revokes their sessions and marks their email verified in
+	// one transaction. Unusable tokens give ErrInvalidAccountToken.
+	ResetPassword(ctx context.Context, tokenHash, hashedPassword string, at time.Time) error
+}
+
+// AccountUsecase recovers accounts and verifies email addresses. Requests
+// by email succeed whether or not the address is registered, so they cannot
+// be used to find out who has an account.
+type AccountUsecase interface {
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword sets a new password and signs the user out everywhere.
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	RequestEmailVerification(ctx context.Context, email string) error
+	VerifyEmail(ctx context.Context, token string) error
+	IsEmailVerified(ctx context.Context, userID string) (bool, error)
+	// RequestEmailChange sends a confirmation link to newEmail, which
+	// replaces the user's email once ConfirmEmailChange redeems it. The
+	// current address is told about the request.
+	RequestEmailChange(ctx context.Context, userID, newEmail string) error
+	ConfirmEmailChange(ctx context.Context, token string) error
+}
+
+// ErrAccountDisabled is returned when a disabled user tries to sign in.
+var ErrAccountDisabled = errors.New("account is disabled")
+
+// ErrInvalidCredentials is the only error a failed login reports, so the
+// response does not tell whether the email has an account.
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+// Login throttling. Failures count within LoginFailureWindow. After
+// LoginDelayAfter failures for an email each further attempt must wait
+// twice as long as the previous one, up to LoginMaxDelay, and after
+// LockoutThreshold the email is locked for LockoutDuration. Client IPs get
+// the same treatment with higher limits, since one IP may try many emails.
+const (
+	LoginFailureWindow = 15 * time.Minute
+	LoginDelayAfter    = 3
+	LoginMaxDelay      = 30 * time.Second
+	LockoutThreshold   = 10
+	LockoutDuration    = 15 * time.Minute
+	IPDelayAfter       = 20
+	IPLockoutThreshold = 100
+)
+
+// ThrottledError is returned when a login is attempted too soon after
+// failed ones.
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return "too many failed login attempts, try again later"
+}
+
+// FailureStats summarizes recent failed logins for an email and an IP.
+type FailureStats struct {
+	EmailFailures    int
+	LastEmailFailure *time.Time
+	IPFailures       int
+	LastIPFailure    *time.Time
+	// LockedUntil is set while the email is locked
+	LockedUntil *time.Time
+}
+
+// ThrottlePolicy returns how long a caller with the given recent failures
+// has to wait before the next attempt, or zero when it may try now.
+type ThrottlePolicy func(stats *FailureStats, now time.Time) time.Duration
+
+// LoginAttempt is an attempt to prove who one is. It counts as a failure
+// from the start, so concurrent guesses cannot slip past the limits, and is
+// forgotten when it succeeds.
+type LoginAttempt struct {
+	ID string
+	// EmailFailures and IPFailures include this attempt.
+	EmailFailures int
+	IPFailures    int
+}
+
+type LoginAttemptRepository interface {
+	// BeginAttempt counts the failures since the given time and, unless
+	// throttle makes the caller wait, records a new attempt. Attempts for
+	// the same email or ip are serialized, so the check and the increment
+	// are atomic. email is normalized. The wait is returned when the caller
+	// is throttled, with a nil attempt.
+	BeginAttempt(ctx context.Context, email, ip string, since, now time.Time, throttle ThrottlePolicy) (*LoginAttempt, time.Duration, error)
+	// ForgetAttempt removes an attempt that succeeded.
+	ForgetAttempt(ctx context.Context, id string) error
+	// Lock locks the email until the given time.
+	Lock(ctx context.Context, email string, until time.Time) error
+	// ClearFailures forgets the failures of the email after a successful
+	// login.
+	ClearFailures(ctx context.Context, email string) error
+	// Unlock lifts a lock and forgets the failures of the email.
+	Unlock(ctx context.Context, email string) error
+	// DeleteExpired removes failures recorded before the given time and
+	// lockouts that ended, and returns how many rows were removed.
+	DeleteExpired(ctx context.Context, before, now time.Time) (int, error)
+}
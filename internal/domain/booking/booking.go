@@ -2,9 +2,21 @@ package booking
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrSessionSoldOut is returned when a pass cannot be booked because one of
+// its sessions has no seats left.
+var ErrSessionSoldOut = errors.New("pass sold out")
+
+// ErrNotConfirmed is returned when checking in a booking that is not
+// confirmed.
+var ErrNotConfirmed = errors.New("booking is not confirmed")
+
+// ErrAlreadyCheckedIn is returned when a booking is checked in twice.
+var ErrAlreadyCheckedIn = errors.New("booking is already checked in")
+
 type BookingStatus string
 
 const (
@@ -17,18 +29,31 @@ type Booking struct {
 	ID          string        `json:"id" db:"id"`
 	UserID      string        `json:"user_id" db:"user_id"`
 	EventID     string        `json:"event_id" db:"event_id"`
+	PassID      *string       `json:"pass_id,omitempty" db:"pass_id"`
 	Quantity    int           `json:"quantity" db:"quantity"`
 	TotalAmount float64       `json:"total_amount" db:"total_amount"`
 	Status      BookingStatus `json:"status" db:"status"`
 	BookingTime time.Time     `json:"booking_time" db:"booking_time"`
 	CancelledAt *time.Time    `json:"cancelled_at,omitempty" db:"cancelled_at"`
+	CheckedInAt *time.Time    `json:"checked_in_at,omitempty" db:"checked_in_at"`
 	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time     `json:"updated_at" db:"updated_at"`
+	// AccessCode is only read from booking requests during a presale
+	AccessCode string `json:"access_code,omitempty" db:"-"`
 }
 
 type BookingRepository interface {
 	Create(booking *Booking) error
+	// CreateWithSessionSeats inserts a pass booking and takes Quantity seats
+	// from every session in one transaction.
+	CreateWithSessionSeats(ctx context.Context, booking *Booking, sessionIDs []string) error
+	// CancelWithSessionSeats cancels a pass booking and returns its seats to
+	// every session in one transaction.
+	CancelWithSessionSeats(ctx context.Context, booking *Booking, sessionIDs []string) error
 	Update(booking *Booking) error
+	// CheckIn sets CheckedInAt of a confirmed booking that is not checked in
+	// yet, and returns ErrAlreadyCheckedIn otherwise.
+	CheckIn(ctx context.Context, id string, at time.Time) error
 	GetByID(id string) (*Booking, error)
 	GetByUserID(userID string, limit, offset int) ([]*Booking, error)
 	GetByEventID(eventID string, limit, offset int) ([]*Booking, error)
@@ -52,6 +77,8 @@ type BookingUsecase interface {
 	CreateBooking(ctx context.Context, booking *Booking) error
 	CancelBooking(ctx context.Context, bookingID, userID string) error
 	GetBooking(ctx context.Context, bookingID string) (*Booking, error)
+	// CheckIn admits the holder of a confirmed booking of the event.
+	CheckIn(ctx context.Context, eventID, bookingID string) (*Booking, error)
 	GetUserBookings(ctx context.Context, userID string, limit, offset int) ([]*Booking, error)
 	GetEventBookings(ctx context.Context, eventID string, limit, offset int) ([]*Booking, error)
 	GetBookingAnalytics(ctx context.Context, eventID string) (*BookingAnalytics, error)
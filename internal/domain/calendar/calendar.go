@@ -0,0 +1,72 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"evently/internal/domain/booking"
+	"evently/internal/domain/events"
+)
+
+// DefaultDuration is used as the length of calendar entries, since events
+// only have a start time.
+const DefaultDuration = 2 * time.Hour
+
+// MaxFeedEvents caps the number of entries in a single feed.
+const MaxFeedEvents = 500
+
+type Status string
+
+const (
+	StatusConfirmed Status = "CONFIRMED"
+	StatusTentative Status = "TENTATIVE"
+	StatusCancelled Status = "CANCELLED"
+)
+
+// Calendar is a VCALENDAR object with its entries.
+type Calendar struct {
+	Name    string
+	Entries []*Entry
+}
+
+// Entry is a single VEVENT. UID must stay the same for the lifetime of the
+// booking or event so that calendar apps replace the entry on refresh, and
+// Sequence must grow whenever the entry changes.
+type Entry struct {
+	UID          string
+	Sequence     int
+	Status       Status
+	Summary      string
+	Description  string
+	Location     string
+	Start        time.Time
+	Timezone     string
+	Duration     time.Duration
+	LastModified time.Time
+}
+
+// BookingEntry is a booking together with the event it is for.
+type BookingEntry struct {
+	Booking *booking.Booking
+	Event   *events.Event
+}
+
+type CalendarRepository interface {
+	// SetFeedToken stores the hash of a user's feed token, replacing any
+	// previous one.
+	SetFeedToken(ctx context.Context, userID, tokenHash string) error
+	GetUserIDByFeedToken(ctx context.Context, tokenHash string) (string, error)
+	// ListUserBookings returns the user's confirmed and cancelled bookings
+	// for events that have not ended long ago, with their events.
+	ListUserBookings(ctx context.Context, userID string, since time.Time, limit int) ([]*BookingEntry, error)
+}
+
+type CalendarUsecase interface {
+	BookingCalendar(ctx context.Context, b *booking.Booking) (*Calendar, error)
+	// CreateFeedToken issues a new secret feed token for the user. Any
+	// previously issued token stops working.
+	CreateFeedToken(ctx context.Context, userID string) (string, error)
+	UserFeed(ctx context.Context, token string) (*Calendar, error)
+	CategoryFeed(ctx context.Context, category string) (*Calendar, error)
+	VenueFeed(ctx context.Context, venueID string) (*Calendar, error)
+}
@@ -0,0 +1,281 @@
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	productID = "-//Evently//Evently Calendar//EN"
+
+	localFormat = "20060102T150405"
+	utcFormat   = "20060102T150405Z"
+
+	// Lines longer than this many octets are folded (RFC 5545 section 3.1)
+	maxLineOctets = 75
+)
+
+// Encode writes the calendar as an RFC 5545 iCalendar stream. A VTIMEZONE
+// is generated for every zone used by an entry, covering the years the
+// entries fall in.
+func Encode(w io.Writer, cal *Calendar) error {
+	e := &encoder{}
+	now := time.Now().UTC()
+
+	e.line("BEGIN:VCALENDAR")
+	e.line("VERSION:2.0")
+	e.line("PRODID:" + productID)
+	e.line("CALSCALE:GREGORIAN")
+	e.line("METHOD:PUBLISH")
+	if cal.Name != "" {
+		e.line("X-WR-CALNAME:" + escapeText(cal.Name))
+	}
+
+	for _, zone := range usedZones(cal.Entries) {
+		e.timezone(zone.loc, zone.from, zone.to)
+	}
+
+	for _, entry := range cal.Entries {
+		e.event(entry, now)
+	}
+
+	e.line("END:VCALENDAR")
+
+	_, err := w.Write(e.buf.Bytes())
+	return err
+}
+
+type encoder struct {
+	buf bytes.Buffer
+}
+
+// line writes a content line, folding it at 75 octets without splitting
+// multi-byte characters.
+func (e *encoder) line(s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		e.buf.WriteString(s[:cut])
+		e.buf.WriteString("\r\n ")
+		s = s[cut:]
+		// The leading space of a continuation line counts towards its length
+		limit = maxLineOctets - 1
+	}
+	e.buf.WriteString(s)
+	e.buf.WriteString("\r\n")
+}
+
+func (e *encoder) event(entry *Entry, now time.Time) {
+	duration := entry.duration()
+
+	e.line("BEGIN:VEVENT")
+	e.line("UID:" + entry.UID)
+	e.line("DTSTAMP:" + now.Format(utcFormat))
+	e.line(dateTimeProperty("DTSTART", entry.Start, entry.Timezone))
+	e.line(dateTimeProperty("DTEND", entry.Start.Add(duration), entry.Timezone))
+	e.line(fmt.Sprintf("SEQUENCE:%d", entry.Sequence))
+	if entry.Status != "" {
+		e.line("STATUS:" + string(entry.Status))
+	}
+	e.line("SUMMARY:" + escapeText(entry.Summary))
+	if entry.Description != "" {
+		e.line("DESCRIPTION:" + escapeText(entry.Description))
+	}
+	if entry.Location != "" {
+		e.line("LOCATION:" + escapeText(entry.Location))
+	}
+	if !entry.LastModified.IsZero() {
+		e.line("LAST-MODIFIED:" + entry.LastModified.UTC().Format(utcFormat))
+	}
+	e.line("END:VEVENT")
+}
+
+// dateTimeProperty formats t as local time with a TZID parameter, or as UTC
+// when the entry has no zone or is in UTC.
+func dateTimeProperty(name string, t time.Time, zone string) string {
+	loc := loadZone(zone)
+	if loc == time.UTC {
+		return name + ":" + t.UTC().Format(utcFormat)
+	}
+	return fmt.Sprintf("%s;TZID=%s:%s", name, loc.String(), t.In(loc).Format(localFormat))
+}
+
+func (entry *Entry) duration() time.Duration {
+	if entry.Duration <= 0 {
+		return DefaultDuration
+	}
+	return entry.Duration
+}
+
+func loadZone(name string) *time.Location {
+	if name == "" || name == "UTC" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+type zoneRange struct {
+	loc      *time.Location
+	from, to time.Time
+}
+
+// usedZones returns the non-UTC zones used by the entries together with the
+// time range their entries span, sorted by zone name.
+func usedZones(entries []*Entry) []*zoneRange {
+	byName := make(map[string]*zoneRange)
+	for _, entry := range entries {
+		loc := loadZone(entry.Timezone)
+		if loc == time.UTC {
+			continue
+		}
+		end := entry.Start.Add(entry.duration())
+
+		z, ok := byName[loc.String()]
+		if !ok {
+			byName[loc.String()] = &zoneRange{loc: loc, from: entry.Start, to: end}
+			continue
+		}
+		if entry.Start.Before(z.from) {
+			z.from = entry.Start
+		}
+		if end.After(z.to) {
+			z.to = end
+		}
+	}
+
+	zones := make([]*zoneRange, 0, len(byName))
+	for _, z := range byName {
+		zones = append(zones, z)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].loc.String() < zones[j].loc.String() })
+
+	return zones
+}
+
+// timezone writes a VTIMEZONE built from the zone's actual transitions
+// between the start of from's year and the end of to's year. Listing every
+// transition instead of RRULEs keeps historical rule changes correct.
+func (e *encoder) timezone(loc *time.Location, from, to time.Time) {
+	start := time.Date(from.In(loc).Year(), time.January, 1, 0, 0, 0, 0, loc)
+	end := time.Date(to.In(loc).Year()+1, time.January, 1, 0, 0, 0, 0, loc)
+
+	e.line("BEGIN:VTIMEZONE")
+	e.line("TZID:" + loc.String())
+
+	// The observance in effect at the start of the range
+	name, offset := start.Zone()
+	e.observance(start.IsDST(), start, offset, offset, name)
+
+	for _, tr := range transitions(loc, start, end) {
+		onset := tr.at.In(time.FixedZone("", tr.offsetFrom))
+		e.observance(tr.dst, onset, tr.offsetFrom, tr.offsetTo, tr.name)
+	}
+
+	e.line("END:VTIMEZONE")
+}
+
+// observance writes a STANDARD or DAYLIGHT component. onset is the instant
+// it starts, expressed in the offset in effect before it.
+func (e *encoder) observance(dst bool, onset time.Time, offsetFrom, offsetTo int, name string) {
+	kind := "STANDARD"
+	if dst {
+		kind = "DAYLIGHT"
+	}
+
+	e.line("BEGIN:" + kind)
+	e.line("DTSTART:" + onset.Format(localFormat))
+	e.line("TZOFFSETFROM:" + formatOffset(offsetFrom))
+	e.line("TZOFFSETTO:" + formatOffset(offsetTo))
+	if name != "" && !strings.HasPrefix(name, "+") && !strings.HasPrefix(name, "-") {
+		e.line("TZNAME:" + name)
+	}
+	e.line("END:" + kind)
+}
+
+type transition struct {
+	at         time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string
+	dst        bool
+}
+
+// transitions finds the instants in [start, end) at which the zone's UTC
+// offset or DST flag changes. Go does not expose the zone's transition table,
+// so days are scanned and each change is narrowed down to the second.
+func transitions(loc *time.Location, start, end time.Time) []transition {
+	var result []transition
+
+	prev := start
+	_, prevOffset := prev.In(loc).Zone()
+	prevDST := prev.In(loc).IsDST()
+
+	for t := start.Add(24 * time.Hour); !prev.After(end); t = t.Add(24 * time.Hour) {
+		_, offset := t.In(loc).Zone()
+		dst := t.In(loc).IsDST()
+		if offset != prevOffset || dst != prevDST {
+			lo, hi := prev, t
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				_, midOffset := mid.In(loc).Zone()
+				if midOffset == prevOffset && mid.In(loc).IsDST() == prevDST {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+
+			// Transitions fall on whole seconds and hi is less than a second
+			// past the transition
+			name, _ := hi.In(loc).Zone()
+			result = append(result, transition{
+				at:         hi.UTC().Truncate(time.Second),
+				offsetFrom: prevOffset,
+				offsetTo:   offset,
+				name:       name,
+				dst:        dst,
+			})
+		}
+
+		prev, prevOffset, prevDST = t, offset, dst
+	}
+
+	return result
+}
+
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	hours, minutes := seconds/3600, (seconds%3600)/60
+	if rest := seconds % 60; rest != 0 {
+		return fmt.Sprintf("%s%02d%02d%02d", sign, hours, minutes, rest)
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, hours, minutes)
+}
+
+// escapeText escapes a TEXT value (RFC 5545 section 3.3.11).
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
@@ -0,0 +1,198 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "Jazz night", want: "Jazz night"},
+		{in: "Rock, pop; more", want: `Rock\, pop\; more`},
+		{in: `C:\music`, want: `C:\\music`},
+		{in: "one\r\ntwo\nthree\rfour", want: `one\ntwo\nthree\nfour`},
+	}
+
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatOffset(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{seconds: 0, want: "+0000"},
+		{seconds: 3600, want: "+0100"},
+		{seconds: 5*3600 + 30*60, want: "+0530"},
+		{seconds: -(3*3600 + 30*60), want: "-0330"},
+		// Historical offsets such as Amsterdam's +00:19:32
+		{seconds: 19*60 + 32, want: "+001932"},
+	}
+
+	for _, tt := range tests {
+		if got := formatOffset(tt.seconds); got != tt.want {
+			t.Errorf("formatOffset(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestLineFolding(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "short", in: "SUMMARY:Jazz night"},
+		{name: "exactly 75 octets", in: "SUMMARY:" + strings.Repeat("a", 67)},
+		{name: "long ascii", in: "DESCRIPTION:" + strings.Repeat("abcdefghij", 20)},
+		{name: "multi-byte", in: "LOCATION:" + strings.Repeat("Zürich Hallenstadion ", 8)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &encoder{}
+			e.line(tt.in)
+			out := e.buf.String()
+
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("line does not end in CRLF: %q", out)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			for i, line := range lines {
+				if len(line) > maxLineOctets {
+					t.Errorf("line %d is %d octets long", i, len(line))
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d does not start with a space", i)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a character", i)
+				}
+			}
+
+			// Unfolding gives back the original line
+			unfolded := strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", "")
+			if unfolded != tt.in {
+				t.Errorf("unfolded line = %q, want %q", unfolded, tt.in)
+			}
+		})
+	}
+}
+
+func TestDateTimeProperty(t *testing.T) {
+	start := time.Date(2027, 7, 1, 17, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		zone string
+		want string
+	}{
+		{zone: "", want: "DTSTART:20270701T173000Z"},
+		{zone: "UTC", want: "DTSTART:20270701T173000Z"},
+		{zone: "Not/AZone", want: "DTSTART:20270701T173000Z"},
+		{zone: "Europe/Berlin", want: "DTSTART;TZID=Europe/Berlin:20270701T193000"},
+		{zone: "America/New_York", want: "DTSTART;TZID=America/New_York:20270701T133000"},
+	}
+
+	for _, tt := range tests {
+		if tt.zone != "" && tt.zone != "UTC" && tt.zone != "Not/AZone" {
+			if _, err := time.LoadLocation(tt.zone); err != nil {
+				t.Skipf("time zone data unavailable: %v", err)
+			}
+		}
+		if got := dateTimeProperty("DTSTART", start, tt.zone); got != tt.want {
+			t.Errorf("zone %q: got %q, want %q", tt.zone, got, tt.want)
+		}
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	start := time.Date(2027, 1, 1, 0, 0, 0, 0, berlin)
+	end := time.Date(2028, 1, 1, 0, 0, 0, 0, berlin)
+	got := transitions(berlin, start, end)
+
+	want := []transition{
+		{at: time.Date(2027, 3, 28, 1, 0, 0, 0, time.UTC), offsetFrom: 3600, offsetTo: 7200, name: "CEST", dst: true},
+		{at: time.Date(2027, 10, 31, 1, 0, 0, 0, time.UTC), offsetFrom: 7200, offsetTo: 3600, name: "CET", dst: false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d transitions %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if !got[i].at.Equal(want[i].at) || got[i].offsetFrom != want[i].offsetFrom ||
+			got[i].offsetTo != want[i].offsetTo || got[i].name != want[i].name || got[i].dst != want[i].dst {
+			t.Errorf("transition %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	cal := &Calendar{
+		Name: "Evently",
+		Entries: []*Entry{
+			{
+				UID:      "booking-1@evently",
+				Sequence: 2,
+				Status:   StatusCancelled,
+				Summary:  "Jazz, live",
+				Location: "Blue Note",
+				Start:    time.Date(2027, 7, 1, 17, 30, 0, 0, time.UTC),
+				Timezone: "Europe/Berlin",
+			},
+			{
+				UID:      "event-2@evently",
+				Summary:  "Morning run",
+				Start:    time.Date(2027, 7, 2, 6, 0, 0, 0, time.UTC),
+				Duration: time.Hour,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, cal); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Evently\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n",
+		"UID:booking-1@evently\r\n",
+		"DTSTART;TZID=Europe/Berlin:20270701T193000\r\n",
+		"DTEND;TZID=Europe/Berlin:20270701T213000\r\n",
+		"SEQUENCE:2\r\n",
+		"STATUS:CANCELLED\r\n",
+		`SUMMARY:Jazz\, live` + "\r\n",
+		"DTSTART:20270702T060000Z\r\n",
+		"DTEND:20270702T070000Z\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "BEGIN:VTIMEZONE") != 1 {
+		t.Errorf("want one VTIMEZONE, for the only zone used")
+	}
+	if strings.Count(out, "BEGIN:VEVENT") != 2 {
+		t.Errorf("want two VEVENTs")
+	}
+}
@@ -0,0 +1,119 @@
+package catalog
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	"evently/internal/domain/events"
+)
+
+// Category is a node in the category tree. Top-level categories have no
+// ParentID.
+type Category struct {
+	ID          string    `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Slug        string    `json:"slug" db:"slug"`
+	Description string    `json:"description" db:"description"`
+	ParentID    *string   `json:"parent_id,omitempty" db:"parent_id"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type Tag struct {
+	ID         string    `json:"id" db:"id"`
+	Name       string    `json:"name" db:"name"`
+	Slug       string    `json:"slug" db:"slug"`
+	EventCount int       `json:"event_count" db:"event_count"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// Collection is an ordered, curated list of events. EventIDs is the input
+// order; Events is filled when a collection is read publicly.
+type Collection struct {
+	ID          string          `json:"id" db:"id"`
+	Title       string          `json:"title" db:"title"`
+	Slug        string          `json:"slug" db:"slug"`
+	Description string          `json:"description" db:"description"`
+	EventIDs    []string        `json:"event_ids" db:"-"`
+	Events      []*events.Event `json:"events,omitempty" db:"-"`
+	CreatedBy   string          `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// CategoryAnalytics aggregates bookings over a category and all of its
+// subcategories.
+type CategoryAnalytics struct {
+	CategoryID      string  `json:"category_id" db:"category_id"`
+	CategoryName    string  `json:"category_name" db:"category_name"`
+	EventCount      int     `json:"event_count" db:"event_count"`
+	TotalBookings   int     `json:"total_bookings" db:"total_bookings"`
+	TotalRevenue    float64 `json:"total_revenue" db:"total_revenue"`
+	CapacityUsed    int     `json:"capacity_used" db:"capacity_used"`
+	CapacityTotal   int     `json:"capacity_total" db:"capacity_total"`
+	UtilizationRate float64 `json:"utilization_rate"`
+}
+
+type CatalogRepository interface {
+	CreateCategory(ctx context.Context, category *Category) error
+	UpdateCategory(ctx context.Context, category *Category) error
+	DeleteCategory(ctx context.Context, id string) error
+	GetCategory(ctx context.Context, idOrSlug string) (*Category, error)
+	ListCategories(ctx context.Context) ([]*Category, error)
+	// IsDescendant reports whether candidateID is categoryID itself or one of
+	// its subcategories.
+	IsDescendant(ctx context.Context, categoryID, candidateID string) (bool, error)
+	GetCategoryAnalytics(ctx context.Context, limit int) ([]*CategoryAnalytics, error)
+
+	CreateTag(ctx context.Context, tag *Tag) error
+	UpdateTag(ctx context.Context, tag *Tag) error
+	DeleteTag(ctx context.Context, id string) error
+	ListTags(ctx context.Context) ([]*Tag, error)
+
+	CreateCollection(ctx context.Context, collection *Collection) error
+	UpdateCollection(ctx context.Context, collection *Collection) error
+	DeleteCollection(ctx context.Context, id string) error
+	GetCollection(ctx context.Context, idOrSlug string) (*Collection, error)
+	ListCollections(ctx context.Context, limit, offset int) ([]*Collection, error)
+	GetCollectionEvents(ctx context.Context, collectionID string) ([]*events.Event, error)
+}
+
+type CatalogUsecase interface {
+	CreateCategory(ctx context.Context, category *Category) error
+	UpdateCategory(ctx context.Context, category *Category) error
+	DeleteCategory(ctx context.Context, id string) error
+	ListCategories(ctx context.Context) ([]*Category, error)
+	GetCategoryAnalytics(ctx context.Context, limit int) ([]*CategoryAnalytics, error)
+
+	CreateTag(ctx context.Context, tag *Tag) error
+	UpdateTag(ctx context.Context, tag *Tag) error
+	DeleteTag(ctx context.Context, id string) error
+	ListTags(ctx context.Context) ([]*Tag, error)
+
+	CreateCollection(ctx context.Context, collection *Collection) error
+	UpdateCollection(ctx context.Context, collection *Collection) error
+	DeleteCollection(ctx context.Context, id string) error
+	GetCollection(ctx context.Context, slug string) (*Collection, error)
+	ListCollections(ctx context.Context, limit, offset int) ([]*Collection, error)
+}
+
+// Slugify lowercases s and joins its letters and digits with single dashes,
+// e.g. "Tech Meetups!" becomes "tech-meetups".
+func Slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
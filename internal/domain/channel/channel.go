@@ -0,0 +1,31 @@
+package channel
+
+import (
+	"context"
+	"errors"
+)
+
+// Names of the channels notifications can be delivered through.
+const (
+	Email   = "email"
+	SMS     = "sms"
+	WebPush = "web_push"
+)
+
+// ErrUndeliverable marks failures that sending again cannot fix, such as an
+// address the provider rejects. Other errors are worth retrying.
+var ErrUndeliverable = errors.New("undeliverable")
+
+// Message is something to deliver to a person outside the app. To is an
+// address in the channel's own format, such as an email address.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Channel delivers messages, e.g. by email.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, msg *Message) error
+}
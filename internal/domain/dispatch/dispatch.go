@@ -0,0 +1,111 @@
+package dispatch
+
+import (
+	"context"
+	"time"
+)
+
+// Delivery statuses. A delivery waits as pending until it is due, is
+// sending while a worker holds it, and ends up sent or, once retries are
+// exhausted or the channel rejected it for good, dead.
+const (
+	StatusPending = "pending"
+	StatusSending = "sending"
+	StatusSent    = "sent"
+	StatusDead    = "dead"
+)
+
+// Delivery is a notification on its way to one recipient through one
+// channel. Recipient is an email address, a phone number or the ID of a
+// push subscription.
+type Delivery struct {
+	ID             string     `json:"id"`
+	NotificationID string     `json:"notification_id"`
+	UserID         string     `json:"user_id"`
+	Channel        string     `json:"channel"`
+	Recipient      string     `json:"recipient"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastError      *string    `json:"last_error,omitempty"`
+	SentAt         *time.Time `json:"sent_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// DeliveryFilter selects deliveries for the admin listing.
+type DeliveryFilter struct {
+	Status         string
+	Channel        string
+	NotificationID string
+	Limit          int
+	Offset         int
+}
+
+// PushSubscription is a browser the user allowed to show notifications,
+// as handed out by the browser's Push API.
+type PushSubscription struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Endpoint  string    `json:"endpoint"`
+	P256dh    string    `json:"-"`
+	Auth      string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PushSubscriptionRequest is the JSON form of a browser PushSubscription.
+type PushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+type DeliveryRepository interface {
+	// Plan creates the deliveries of up to limit notifications that have
+	// not been planned yet, one for each of channels the user can be
+	// reached on, and returns how many notifications it planned. Email
+	// goes to verified addresses only and nothing goes to disabled users.
+	Plan(ctx context.Context, channels []string, now time.Time, limit int) (int, error)
+	// Claim marks up to limit due deliveries as sending until lockedUntil
+	// and counts the attempt. Deliveries whose worker went away before
+	// lockedUntil become due again.
+	Claim(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*Delivery, error)
+	MarkSent(ctx context.Context, id string, at time.Time) error
+	// MarkFailed records a failed attempt. The delivery is tried again at
+	// retryAt, or is dead when retryAt is nil.
+	MarkFailed(ctx context.Context, id, lastError string, retryAt *time.Time, at time.Time) error
+	// Requeue makes a dead delivery due at once with a fresh set of
+	// attempts.
+	Requeue(ctx context.Context, id string, at time.Time) (*Delivery, error)
+	// List returns the newest deliveries first and the number of matches.
+	List(ctx context.Context, filter DeliveryFilter) ([]*Delivery, int, error)
+}
+
+type PushSubscriptionRepository interface {
+	// Save stores the subscription, moving it over when the browser was
+	// subscribed by another user before.
+	Save(ctx context.Context, subscription *PushSubscription) (*PushSubscription, error)
+	GetByID(ctx context.Context, id string) (*PushSubscription, error)
+	ListByUser(ctx context.Context, userID string) ([]*PushSubscription, error)
+	// Delete removes the subscription; userID limits it to the owner's
+	// subscriptions unless it is empty.
+	Delete(ctx context.Context, id, userID string) error
+}
+
+type DispatchUsecase interface {
+	// Dispatch plans the deliveries of new notifications and sends the
+	// ones that are due, returning how many were sent.
+	Dispatch(ctx context.Context) (int, error)
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]*Delivery, int, error)
+	// RetryDelivery sends a dead delivery again.
+	RetryDelivery(ctx context.Context, id string) (*Delivery, error)
+
+	// VAPIDPublicKey is the key browsers subscribe with, or empty when web
+	// push is off.
+	VAPIDPublicKey() string
+	SubscribePush(ctx context.Context, userID string, req *PushSubscriptionRequest) (*PushSubscription, error)
+	ListPushSubscriptions(ctx context.Context, userID string) ([]*PushSubscription, error)
+	UnsubscribePush(ctx context.Context, userID, id string) error
+}
@@ -2,21 +2,57 @@ package events
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrCapacityConflict is returned when a capacity change would drop below the
+// seats that are already sold or held for waitlisted users.
+var ErrCapacityConflict = errors.New("capacity conflict")
+
 type Event struct {
-	ID             string    `json:"id" db:"id"`
-	Name           string    `json:"name" db:"name"`
-	Description    string    `json:"description" db:"description"`
-	Venue          string    `json:"venue" db:"venue"`
-	EventTime      time.Time `json:"event_time" db:"event_time"`
-	TotalCapacity  int       `json:"total_capacity" db:"total_capacity"`
-	AvailableSeats int       `json:"available_seats" db:"available_seats"`
-	Price          float64   `json:"price" db:"price"`
-	CreatedBy      string    `json:"created_by" db:"created_by"`
-	CreatedAt      time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+	ID             string     `json:"id" db:"id"`
+	Name           string     `json:"name" db:"name"`
+	Description    string     `json:"description" db:"description"`
+	Venue          string     `json:"venue" db:"venue"`
+	VenueID        *string    `json:"venue_id,omitempty" db:"venue_id"`
+	EventTime      time.Time  `json:"event_time" db:"event_time"`
+	Timezone       string     `json:"timezone" db:"timezone"`
+	LocalEventTime string     `json:"local_event_time,omitempty" db:"-"`
+	TotalCapacity  int        `json:"total_capacity" db:"total_capacity"`
+	AvailableSeats int        `json:"available_seats" db:"available_seats"`
+	Price          float64    `json:"price" db:"price"`
+	OnSaleAt       *time.Time `json:"on_sale_at" db:"on_sale_at"`
+	OffSaleAt      *time.Time `json:"off_sale_at" db:"off_sale_at"`
+	CreatedBy      string     `json:"created_by" db:"created_by"`
+	SeriesID       *string    `json:"series_id,omitempty" db:"series_id"`
+	ParentEventID  *string    `json:"parent_event_id,omitempty" db:"parent_event_id"`
+	CategoryID     *string    `json:"category_id,omitempty" db:"category_id"`
+	Tags           []string   `json:"tags" db:"-"`
+	Sequence       int        `json:"sequence" db:"sequence"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Location returns the time zone the event takes place in, falling back to
+// UTC for unknown zones.
+func (e *Event) Location() *time.Location {
+	loc, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Localize fills LocalEventTime with the event time as seen at the event.
+func (e *Event) Localize() {
+	e.LocalEventTime = e.EventTime.In(e.Location()).Format(time.RFC3339)
+}
+
+// HasStarted reports whether the event has started at now. Event times are
+// absolute instants, so the result does not depend on the server time zone.
+func (e *Event) HasStarted(now time.Time) bool {
+	return !e.EventTime.After(now)
 }
 
 type EventUsecase interface {
@@ -24,20 +60,45 @@ type EventUsecase interface {
 	UpdateEvent(ctx context.Context, event *Event) error
 	DeleteEvent(ctx context.Context, eventID string) error
 	GetEvent(ctx context.Context, eventID string) (*Event, error)
-	ListUpcomingEvents(ctx context.Context, limit, offset int) ([]*Event, error)
+	ListUpcomingEvents(ctx context.Context, filter EventFilter, limit, offset int) ([]*Event, error)
 	ListAllEvents(ctx context.Context, limit, offset int) ([]*Event, error)
+	// ListManagedEvents returns the events the user created or collaborates
+	// on, including their sessions.
+	ListManagedEvents(ctx context.Context, userID string, limit, offset int) ([]*Event, error)
 	GetMostPopularEvents(ctx context.Context, limit int) ([]*EventAnalytics, error)
+	GetMostPopularManagedEvents(ctx context.Context, userID string, limit int) ([]*EventAnalytics, error)
+	CreateSession(ctx context.Context, parentEventID string, session *Event) error
+	ListSessions(ctx context.Context, parentEventID string) ([]*Event, error)
+	GetSessionUtilization(ctx context.Context, parentEventID string) ([]*SessionUtilization, error)
+	SearchEvents(ctx context.Context, params *SearchParams) (*SearchResult, error)
 }
 
 type EventRepository interface {
 	Create(event *Event) error
 	Update(event *Event) error
+	// UpdateWithCapacity updates the event while holding a lock on its row and
+	// recomputes available seats from the new capacity. It returns the change
+	// in available seats.
+	UpdateWithCapacity(ctx context.Context, event *Event) (int, error)
 	Delete(id string) error
 	GetByID(id string) (*Event, error)
-	ListUpcoming(limit, offset int) ([]*Event, error)
+	ListUpcoming(filter EventFilter, limit, offset int) ([]*Event, error)
 	ListAll(limit, offset int) ([]*Event, error)
+	ListManaged(ctx context.Context, userID string, limit, offset int) ([]*Event, error)
 	UpdateAvailableSeats(eventID string, quantity int) error
 	GetMostPopularEvents(ctx context.Context, limit int) ([]*EventAnalytics, error)
+	GetMostPopularManagedEvents(ctx context.Context, userID string, limit int) ([]*EventAnalytics, error)
+	ListSessions(ctx context.Context, parentEventID string) ([]*Event, error)
+	GetSessionUtilization(ctx context.Context, parentEventID string) ([]*SessionUtilization, error)
+	Search(ctx context.Context, params *SearchParams) (*SearchResult, error)
+}
+
+// EventFilter narrows event listings. Category matches a category ID or slug
+// and includes its subcategories; every tag in Tags must be present.
+type EventFilter struct {
+	VenueID  string
+	Category string
+	Tags     []string
 }
 
 type EventAnalytics struct {
@@ -49,3 +110,73 @@ type EventAnalytics struct {
 	CapacityTotal   int     `json:"capacity_total" db:"capacity_total"`
 	UtilizationRate float64 `json:"utilization_rate"`
 }
+
+// SessionUtilization reports seat usage of one session of a multi-session
+// event, split between direct bookings and passes.
+type SessionUtilization struct {
+	SessionID       string    `json:"session_id" db:"session_id"`
+	SessionName     string    `json:"session_name" db:"session_name"`
+	EventTime       time.Time `json:"event_time" db:"event_time"`
+	CapacityTotal   int       `json:"capacity_total" db:"capacity_total"`
+	AvailableSeats  int       `json:"available_seats" db:"available_seats"`
+	DirectSeats     int       `json:"direct_seats" db:"direct_seats"`
+	PassSeats       int       `json:"pass_seats" db:"pass_seats"`
+	UtilizationRate float64   `json:"utilization_rate"`
+}
+
+// Search models
+
+type SearchSort string
+
+const (
+	SearchSortRelevance  SearchSort = "relevance"
+	SearchSortDate       SearchSort = "date"
+	SearchSortDateDesc   SearchSort = "-date"
+	SearchSortPrice      SearchSort = "price"
+	SearchSortPriceDesc  SearchSort = "-price"
+	SearchSortPopularity SearchSort = "popularity"
+)
+
+type Availability string
+
+const (
+	AvailabilityHasSeats Availability = "available"
+	AvailabilitySoldOut  Availability = "sold_out"
+)
+
+// SearchParams filters GET /api/events/search. Zero values mean "no filter".
+type SearchParams struct {
+	Query        string
+	From         *time.Time
+	To           *time.Time
+	MinPrice     *float64
+	MaxPrice     *float64
+	Venue        string
+	Category     string
+	Tags         []string
+	Availability Availability
+	Sort         SearchSort
+	Limit        int
+	Offset       int
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// SearchFacets are computed over every event matching the filters, not only
+// the returned page.
+type SearchFacets struct {
+	Venues       []FacetCount `json:"venues"`
+	Categories   []FacetCount `json:"categories"`
+	Tags         []FacetCount `json:"tags"`
+	Availability []FacetCount `json:"availability"`
+	PriceRanges  []FacetCount `json:"price_ranges"`
+}
+
+type SearchResult struct {
+	Events []*Event      `json:"events"`
+	Total  int           `json:"total"`
+	Facets *SearchFacets `json:"facets"`
+}
@@ -0,0 +1,74 @@
+package invitation
+
+import (
+	"context"
+	"time"
+
+	"evently/internal/domain/model"
+)
+
+// DefaultTTL is how long an invitation stays valid unless the admin picks
+// another duration.
+const DefaultTTL = 72 * time.Hour
+
+// MaxTTL caps the lifetime of an invitation.
+const MaxTTL = 30 * 24 * time.Hour
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusAccepted Status = "accepted"
+	StatusRevoked  Status = "revoked"
+	// StatusExpired is never stored; pending invitations past their expiry
+	// are reported with it.
+	StatusExpired Status = "expired"
+)
+
+type Invitation struct {
+	ID         string     `json:"id" db:"id"`
+	Email      string     `json:"email" db:"email"`
+	Role       string     `json:"role" db:"role"`
+	InvitedBy  *string    `json:"invited_by,omitempty" db:"invited_by"`
+	Status     Status     `json:"status" db:"status"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty" db:"accepted_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	// Token is only returned when the invitation is created
+	Token string `json:"token,omitempty" db:"-"`
+}
+
+type CreateRequest struct {
+	Email string `json:"email" binding:"required"`
+	Role  string `json:"role" binding:"required"`
+	// ExpiresInHours defaults to DefaultTTL
+	ExpiresInHours int `json:"expires_in_hours"`
+}
+
+type AcceptRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type InvitationRepository interface {
+	// Create stores the invitation and revokes any other pending invitation
+	// for the same email.
+	Create(ctx context.Context, inv *Invitation) error
+	GetByID(ctx context.Context, id string) (*Invitation, error)
+	// List filters by status when it is set; pending and expired are told
+	// apart using now.
+	List(ctx context.Context, status Status, now time.Time, limit, offset int) ([]*Invitation, error)
+	Revoke(ctx context.Context, id string, at time.Time) error
+	// Accept marks a pending invitation accepted and creates the user in one
+	// transaction.
+	Accept(ctx context.Context, id string, user *model.User, at time.Time) error
+}
+
+type InvitationUsecase interface {
+	CreateInvitation(ctx context.Context, req *CreateRequest, invitedBy string) (*Invitation, error)
+	ListInvitations(ctx context.Context, status Status, limit, offset int) ([]*Invitation, error)
+	RevokeInvitation(ctx context.Context, id string) error
+	AcceptInvitation(ctx context.Context, req *AcceptRequest) (*model.User, error)
+}
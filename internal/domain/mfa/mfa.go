@@ -0,0 +1,66 @@
+package mfa
+
+import (
+	"context"
+	"time"
+)
+
+// RecoveryCodeCount is how many recovery codes a user gets at a time.
+const RecoveryCodeCount = 10
+
+// Enrollment is a user's TOTP secret. It only protects logins once
+// EnabledAt is set.
+type Enrollment struct {
+	UserID       string     `json:"user_id" db:"user_id"`
+	Secret       string     `json:"-" db:"secret"`
+	EnabledAt    *time.Time `json:"enabled_at,omitempty" db:"enabled_at"`
+	LastUsedStep int64      `json:"-" db:"last_used_step"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Status is what a user sees about their own two-factor setup.
+type Status struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	Required               bool       `json:"required"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+// Setup is returned when enrolling; the URI is meant for a QR code.
+type Setup struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type MFARepository interface {
+	Get(ctx context.Context, userID string) (*Enrollment, error)
+	// SavePending stores a new secret unless two-factor is already enabled.
+	SavePending(ctx context.Context, enrollment *Enrollment) error
+	// Enable turns the pending secret on, records the step of the code that
+	// confirmed it and replaces the recovery codes.
+	Enable(ctx context.Context, userID string, step int64, at time.Time, codeHashes []string) error
+	// UseStep records a code's time step. It reports false when that step
+	// or a later one was already used.
+	UseStep(ctx context.Context, userID string, step int64) (bool, error)
+	// UseRecoveryCode marks an unused code used and reports whether there
+	// was one.
+	UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+	CountRecoveryCodes(ctx context.Context, userID string) (int, error)
+	// Delete removes the secret and recovery codes.
+	Delete(ctx context.Context, userID string) error
+}
+
+type MFAUsecase interface {
+	GetStatus(ctx context.Context, userID string) (*Status, error)
+	// Setup creates a new secret to add to an authenticator app.
+	Setup(ctx context.Context, userID string) (*Setup, error)
+	// Enable confirms the secret with a current code and returns the
+	// recovery codes, which are shown only this once.
+	Enable(ctx context.Context, userID, code string) ([]string, error)
+	// Disable and RegenerateRecoveryCodes ask for the password and a
+	// current code or recovery code again.
+	// Failed attempts count towards login throttling for the user and ip.
+	Disable(ctx context.Context, userID, password, code, ip string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID, password, code, ip string) ([]string, error)
+}
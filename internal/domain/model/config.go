@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type DBConfig struct {
 	URL           string `json:"url"`
 	Host          string `json:"host"`
@@ -12,10 +14,130 @@ type DBConfig struct {
 }
 
 type JWTConfig struct {
-	SecretKey string `yaml:"secret_key"`
+	// KeysDir holds the PEM signing keys. It may only be empty in
+	// development, where a throwaway key is generated.
+	KeysDir  string `yaml:"keys_dir"`
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// AccessTTL is the lifetime of access tokens; RefreshTTL that of
+	// refresh tokens.
+	AccessTTL  time.Duration `yaml:"access_ttl"`
+	RefreshTTL time.Duration `yaml:"refresh_ttl"`
+	// KeyRetention is how long a replaced key keeps verifying tokens.
+	KeyRetention time.Duration `yaml:"key_retention"`
+	// KeysReload is how often KeysDir is reread for new keys.
+	KeysReload time.Duration `yaml:"keys_reload"`
+}
+
+type AuthConfig struct {
+	// RequireVerifiedEmail blocks bookings until the user verified their
+	// email address.
+	RequireVerifiedEmail bool `yaml:"require_verified_email"`
+	// RequireMFAForPrivileged makes users whose role can do more than book
+	// tickets enroll in two-factor authentication before they can log in.
+	RequireMFAForPrivileged bool `yaml:"require_mfa_for_privileged"`
+	// AppURL is the frontend that links in account emails point to.
+	AppURL string `yaml:"app_url"`
+	// DeletionGracePeriod is how long a requested account deletion can
+	// still be cancelled before the account is anonymised.
+	DeletionGracePeriod time.Duration `yaml:"deletion_grace_period"`
+}
+
+// OIDCConfig sets up single sign-on with an OpenID Connect provider. It is
+// off while Issuer is empty.
+type OIDCConfig struct {
+	Issuer       string   `yaml:"issuer"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	RedirectURL  string   `yaml:"redirect_url"`
+	Scopes       []string `yaml:"scopes"`
+	// GroupsClaim names the ID token claim listing the user's groups.
+	GroupsClaim string `yaml:"groups_claim"`
+	// GroupRoles maps provider groups to Evently roles as "group=role"
+	// entries; the first entry whose group the user is in wins. When it is
+	// set, the role of every SSO user follows their groups.
+	GroupRoles []string `yaml:"group_roles"`
+}
+
+func (c OIDCConfig) Enabled() bool {
+	return c.Issuer != ""
+}
+
+type MailConfig struct {
+	// Channel is "smtp", or "file" to write messages to Dir.
+	Channel      string `yaml:"channel"`
+	Dir          string `yaml:"dir"`
+	From         string `yaml:"from"`
+	SMTPHost     string `yaml:"smtp_host"`
+	SMTPPort     string `yaml:"smtp_port"`
+	SMTPUsername string `yaml:"smtp_username"`
+	SMTPPassword string `yaml:"smtp_password"`
+}
+
+type SMSConfig struct {
+	// Channel is "twilio", "file" to write messages to Dir, or empty to
+	// send no text messages.
+	Channel string `yaml:"channel"`
+	Dir     string `yaml:"dir"`
+	// From is the sending phone number.
+	From             string `yaml:"from"`
+	TwilioAccountSID string `yaml:"twilio_account_sid"`
+	TwilioAuthToken  string `yaml:"twilio_auth_token"`
+	// TwilioURL is the API base URL, replaceable for testing.
+	TwilioURL string `yaml:"twilio_url"`
+}
+
+type WebPushConfig struct {
+	// VAPIDPrivateKey identifies the server to push services: a P-256
+	// private key as base64url. Web push stays off without it.
+	VAPIDPrivateKey string `yaml:"vapid_private_key"`
+	// Subject is a mailto: or https: URL push services can reach the
+	// operator at.
+	Subject string `yaml:"subject"`
+}
+
+func (c WebPushConfig) Enabled() bool {
+	return c.VAPIDPrivateKey != ""
+}
+
+type PresaleConfig struct {
+	// CodeSecret keys the HMAC under which access codes are stored. It may
+	// only be empty in development.
+	CodeSecret string `yaml:"code_secret"`
+}
+
+type DeliveryConfig struct {
+	// MaxAttempts is how often a notification is tried on a channel before
+	// its delivery is dead.
+	MaxAttempts int `yaml:"max_attempts"`
+	// RetryBackoff is the wait before the first retry; it doubles with
+	// every further attempt.
+	RetryBackoff time.Duration `yaml:"retry_backoff"`
+	// PollInterval is how often due deliveries are looked for.
+	PollInterval time.Duration `yaml:"poll_interval"`
 }
 
 type Config struct {
-	DB  DBConfig  `yaml:"db"`
-	JWT JWTConfig `yaml:"jwt"`
+	// Env is the deployment environment, "production" unless set. Only
+	// "development" relaxes checks meant for production.
+	Env string `yaml:"env"`
+	// PublicURL is where clients reach the API, for the links it hands out
+	// such as calendar feed URLs.
+	PublicURL string `yaml:"public_url"`
+	// TrustedProxies may set X-Forwarded-For; the client IP of requests from
+	// anywhere else is the peer address.
+	TrustedProxies []string       `yaml:"trusted_proxies"`
+	DB             DBConfig       `yaml:"db"`
+	JWT            JWTConfig      `yaml:"jwt"`
+	Auth           AuthConfig     `yaml:"auth"`
+	OIDC           OIDCConfig     `yaml:"oidc"`
+	Mail           MailConfig     `yaml:"mail"`
+	SMS            SMSConfig      `yaml:"sms"`
+	WebPush        WebPushConfig  `yaml:"web_push"`
+	Presale        PresaleConfig  `yaml:"presale"`
+	Delivery       DeliveryConfig `yaml:"delivery"`
+}
+
+func (c *Config) IsDevelopment() bool {
+	return c.Env == "development"
 }
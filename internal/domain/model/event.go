@@ -23,10 +23,37 @@ type Notification struct {
 	UpdatedAt time.Time        `json:"updated_at" db:"updated_at"`
 }
 
+// NotificationCursor is the position after the last notification of a
+// page, which lists newest first.
+type NotificationCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// NotificationFilter selects a page of a user's notifications. Cursor is
+// the NextCursor of the previous page and empty for the first.
+type NotificationFilter struct {
+	UnreadOnly bool
+	Cursor     string
+	Limit      int
+}
+
+type NotificationPage struct {
+	Notifications []*Notification `json:"notifications"`
+	// NextCursor is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
 type NotificationRepository interface {
 	Create(notification *Notification) error
+	GetByID(id string) (*Notification, error)
 	GetByUserID(userID string, limit, offset int) ([]*Notification, error)
-	MarkAsRead(id string) error
+	// List returns up to limit of the user's notifications, newest first,
+	// starting after the cursor when it is set.
+	List(userID string, unreadOnly bool, after *NotificationCursor, limit int) ([]*Notification, error)
+	// MarkAsRead and Delete only touch notifications of userID.
+	MarkAsRead(id, userID string) error
 	MarkAllAsRead(userID string) error
+	Delete(id, userID string) error
 	GetUnreadCount(userID string) (int, error)
 }
@@ -1,27 +1,112 @@
 package model
 
 import (
+	"context"
 	"time"
+
+	"evently/internal/domain/audit"
+)
+
+const (
+	RoleUser      = "user"
+	RoleOrganizer = "organizer"
+	RoleAdmin     = "admin"
 )
 
 type User struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	Password  string    `json:"-"`    // hashed
-	Role      string    `json:"role"` // "user" or "admin"
-	CreatedAt time.Time `json:"created_at"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"-"`    // hashed
+	Role     string `json:"role"` // "user", "organizer" or "admin"
+	// EmailVerifiedAt is set once the user proved they own the address
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// PendingEmail replaces Email once the user confirms it
+	PendingEmail *string `json:"pending_email,omitempty"`
+	// Phone receives text message notifications, in E.164 format
+	Phone *string `json:"phone,omitempty"`
+	// DisabledAt is set while an admin has disabled the account
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+	// DeletionScheduledAt is when a requested deletion takes effect
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+	// AnonymizedAt is set once the account was deleted
+	AnonymizedAt *time.Time `json:"anonymized_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// UserFilter selects users for the admin listing. Query matches name or
+// email; Status is "active" or "disabled".
+type UserFilter struct {
+	Query  string
+	Role   string
+	Status string
+	Limit  int
+	Offset int
+}
+
+// ActivitySummary counts a user's bookings and waitlist entries.
+type ActivitySummary struct {
+	Bookings struct {
+		Confirmed int `json:"confirmed"`
+		Pending   int `json:"pending"`
+		Cancelled int `json:"cancelled"`
+		// Upcoming counts confirmed bookings for events still ahead
+		Upcoming int `json:"upcoming"`
+		Tickets  int `json:"tickets"`
+	} `json:"bookings"`
+	Waitlist struct {
+		Active   int `json:"active"`
+		Notified int `json:"notified"`
+	} `json:"waitlist"`
 }
 
 type UserRepository interface {
 	Create(user *User) error
 	GetByID(id string) (*User, error)
 	GetByEmail(email string) (*User, error)
+	CountByRole(role string) (int, error)
+	UpdatePassword(id, hashedPassword string) error
+	// ChangePassword sets the password, revokes every session of the user
+	// and writes entry to the audit log in one transaction.
+	ChangePassword(ctx context.Context, id, hashedPassword string, at time.Time, entry *audit.Entry) error
+	MarkEmailVerified(id string, at time.Time) error
+	UpdateName(id, name string) error
+	// UpdatePhone sets the user's phone number, or clears it when phone is
+	// nil.
+	UpdatePhone(id string, phone *string) error
+	// SetPendingEmail stores the address the user wants to switch to, or
+	// clears it when email is nil.
+	SetPendingEmail(id string, email *string) error
+	// ConfirmEmailChange makes the pending email the user's email.
+	ConfirmEmailChange(id string, at time.Time) error
+	// SetDisabled disables the account, or enables it when at is nil.
+	SetDisabled(id string, at *time.Time) error
+	// List returns the newest users first and the number of matches.
+	List(filter UserFilter) ([]*User, int, error)
+	GetActivitySummary(userID string) (*ActivitySummary, error)
+}
+
+// UpdateProfileRequest changes the signed in user's account. Only fields
+// that are set change; email and password also need CurrentPassword. An
+// empty phone removes the number.
+type UpdateProfileRequest struct {
+	Name            *string `json:"name"`
+	Email           *string `json:"email"`
+	Phone           *string `json:"phone"`
+	Password        *string `json:"password"`
+	CurrentPassword string  `json:"current_password"`
+}
+
+// Profile is what GET /users/me returns.
+type Profile struct {
+	*User
+	Summary *ActivitySummary `json:"summary"`
 }
 
+// RegisterRequest is the body of public registration, which always creates
+// regular users. Other roles are granted by invitation.
 type RegisterRequest struct {
 	Name     string `json:"name"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
-	Role     string `json:"role"`
 }
@@ -0,0 +1,82 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"evently/internal/domain/auth"
+)
+
+// LoginStateTTL is how long a user has at the identity provider before the
+// callback is refused.
+const LoginStateTTL = 10 * time.Minute
+
+// ErrInvalidState is returned for callbacks whose state is unknown, expired,
+// already used or was not issued to the calling browser.
+var ErrInvalidState = errors.New("invalid or expired login state")
+
+// ErrLoginRejected is returned when the provider's answer cannot be
+// trusted or does not identify a usable account.
+var ErrLoginRejected = errors.New("single sign-on login rejected")
+
+// LoginState is a login waiting for the provider's callback. The state
+// itself is only stored hashed.
+type LoginState struct {
+	StateHash    string    `db:"state_hash"`
+	Nonce        string    `db:"nonce"`
+	CodeVerifier string    `db:"code_verifier"`
+	ExpiresAt    time.Time `db:"expires_at"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// Identity links an account at the provider to an Evently user.
+type Identity struct {
+	ID          string     `json:"id" db:"id"`
+	UserID      string     `json:"user_id" db:"user_id"`
+	Issuer      string     `json:"issuer" db:"issuer"`
+	Subject     string     `json:"subject" db:"subject"`
+	Email       string     `json:"email" db:"email"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
+}
+
+// Claims are the parts of a validated ID token Evently uses.
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Groups        []string
+}
+
+// Provider talks to the identity provider.
+type Provider interface {
+	// AuthCodeURL returns where to send the browser to log in.
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems the code and returns the claims of the ID token once
+	// its signature, issuer, audience, expiry and nonce check out.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Claims, error)
+}
+
+type OIDCRepository interface {
+	SaveState(ctx context.Context, state *LoginState) error
+	// ConsumeState deletes and returns an unexpired state, or returns
+	// ErrInvalidState.
+	ConsumeState(ctx context.Context, stateHash string, now time.Time) (*LoginState, error)
+	GetIdentity(ctx context.Context, issuer, subject string) (*Identity, error)
+	CreateIdentity(ctx context.Context, identity *Identity) error
+	TouchIdentity(ctx context.Context, id, email string, at time.Time) error
+}
+
+type OIDCUsecase interface {
+	// StartLogin returns the provider URL to redirect the browser to and
+	// the state the browser must keep until the callback.
+	StartLogin(ctx context.Context) (authURL, state string, err error)
+	// CompleteLogin handles the provider's callback and signs the user in,
+	// linking or creating their account first when needed. browserState is
+	// the state kept by the browser, so a callback started elsewhere is
+	// refused.
+	CompleteLogin(ctx context.Context, state, browserState, code, ip string) (*auth.TokenPair, error)
+}
@@ -0,0 +1,35 @@
+package organizer
+
+import (
+	"context"
+	"time"
+)
+
+// Collaborator is a user with events:write who was given access to manage
+// someone else's event. Access to an event includes its sessions.
+type Collaborator struct {
+	EventID   string    `json:"event_id" db:"event_id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	Name      string    `json:"name" db:"name"`
+	Email     string    `json:"email" db:"email"`
+	AddedBy   *string   `json:"added_by,omitempty" db:"added_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type OrganizerRepository interface {
+	// CanManageEvent reports whether the user created the event, or the
+	// parent event of a session, or collaborates on it.
+	CanManageEvent(ctx context.Context, eventID, userID string) (bool, error)
+	AddCollaborator(ctx context.Context, collaborator *Collaborator) error
+	RemoveCollaborator(ctx context.Context, eventID, userID string) error
+	ListCollaborators(ctx context.Context, eventID string) ([]*Collaborator, error)
+}
+
+type OrganizerUsecase interface {
+	CanManageEvent(ctx context.Context, eventID, userID string) (bool, error)
+	// AddCollaborator gives the user with the given email access to the
+	// event. Their role must grant events:write.
+	AddCollaborator(ctx context.Context, eventID, email, addedBy string) (*Collaborator, error)
+	RemoveCollaborator(ctx context.Context, eventID, userID string) error
+	ListCollaborators(ctx context.Context, eventID string) ([]*Collaborator, error)
+}
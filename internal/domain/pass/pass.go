@@ -0,0 +1,42 @@
+package pass
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrPassInUse is returned when a pass that still has confirmed bookings is
+// deleted.
+var ErrPassInUse = errors.New("pass has confirmed bookings")
+
+// Pass is a product sold on a multi-session event. Booking a pass consumes
+// one seat per booked ticket in every included session.
+type Pass struct {
+	ID          string     `json:"id" db:"id"`
+	EventID     string     `json:"event_id" db:"event_id"`
+	Name        string     `json:"name" db:"name"`
+	Description string     `json:"description" db:"description"`
+	Price       float64    `json:"price" db:"price"`
+	SessionIDs  []string   `json:"session_ids" db:"-"`
+	OnSaleAt    *time.Time `json:"on_sale_at" db:"on_sale_at"`
+	OffSaleAt   *time.Time `json:"off_sale_at" db:"off_sale_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+type PassRepository interface {
+	Create(ctx context.Context, pass *Pass) error
+	GetByID(ctx context.Context, id string) (*Pass, error)
+	ListByEvent(ctx context.Context, eventID string) ([]*Pass, error)
+	// Delete removes a pass of the event unless it has confirmed bookings.
+	// Cancelled bookings are kept without the link to the pass.
+	Delete(ctx context.Context, eventID, id string) error
+}
+
+type PassUsecase interface {
+	CreatePass(ctx context.Context, pass *Pass) error
+	GetPass(ctx context.Context, passID string) (*Pass, error)
+	ListEventPasses(ctx context.Context, eventID string) ([]*Pass, error)
+	DeletePass(ctx context.Context, eventID, passID string) error
+}
@@ -0,0 +1,79 @@
+package presale
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type Kind string
+
+const (
+	// KindAccessCode admits anyone who knows the shared access code.
+	KindAccessCode Kind = "access_code"
+	// KindUserList admits the listed users.
+	KindUserList Kind = "user_list"
+	// KindPreviousAttendees admits users with a confirmed booking for one of
+	// the source events.
+	KindPreviousAttendees Kind = "previous_attendees"
+)
+
+// Presale is a window before the general on-sale time in which selected
+// buyers may book.
+type Presale struct {
+	ID             string    `json:"id" db:"id"`
+	EventID        string    `json:"event_id" db:"event_id"`
+	Name           string    `json:"name" db:"name"`
+	Kind           Kind      `json:"kind" db:"kind"`
+	StartsAt       time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt         time.Time `json:"ends_at" db:"ends_at"`
+	AccessCode     string    `json:"access_code,omitempty" db:"-"`
+	UserIDs        []string  `json:"user_ids,omitempty" db:"-"`
+	SourceEventIDs []string  `json:"source_event_ids,omitempty" db:"source_event_ids"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// Window is the public view of a presale, without codes or user lists.
+type Window struct {
+	Name     string    `json:"name"`
+	Kind     Kind      `json:"kind"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// SalesClosedError is returned when tickets cannot be bought right now.
+// OpensAt is set when sales open later.
+type SalesClosedError struct {
+	Reason  string
+	OpensAt *time.Time
+}
+
+func (e *SalesClosedError) Error() string {
+	if e.OpensAt != nil {
+		return fmt.Sprintf("%s; sales open at %s", e.Reason, e.OpensAt.Format(time.RFC3339))
+	}
+	return e.Reason
+}
+
+// Schedule is the public sale schedule of an event.
+type Schedule struct {
+	OnSaleAt  *time.Time `json:"on_sale_at"`
+	OffSaleAt *time.Time `json:"off_sale_at"`
+	Presales  []*Window  `json:"presales"`
+}
+
+type PresaleRepository interface {
+	Create(ctx context.Context, presale *Presale, accessCodeHash *string) error
+	Delete(ctx context.Context, eventID, presaleID string) error
+	ListByEvent(ctx context.Context, eventID string) ([]*Presale, error)
+	// HasAccess reports whether the user or the access code is admitted by
+	// any presale of the event whose window contains at.
+	HasAccess(ctx context.Context, eventID, userID, accessCodeHash string, at time.Time) (bool, error)
+}
+
+type PresaleUsecase interface {
+	CreatePresale(ctx context.Context, presale *Presale) error
+	DeletePresale(ctx context.Context, eventID, presaleID string) error
+	ListPresales(ctx context.Context, eventID string) ([]*Presale, error)
+	GetSchedule(ctx context.Context, eventID string) (*Schedule, error)
+}
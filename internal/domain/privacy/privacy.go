@@ -0,0 +1,47 @@
+package privacy
+
+import (
+	"context"
+	"time"
+
+	"evently/internal/domain/booking"
+	"evently/internal/domain/dispatch"
+	"evently/internal/domain/model"
+	"evently/internal/domain/waitlist"
+)
+
+// Export is everything Evently stores about a user, as handed out for a
+// data subject access request.
+type Export struct {
+	ExportedAt        time.Time                    `json:"exported_at"`
+	Profile           *model.User                  `json:"profile"`
+	Bookings          []*booking.Booking           `json:"bookings"`
+	Waitlist          []*waitlist.Waitlist         `json:"waitlist"`
+	Notifications     []*model.Notification        `json:"notifications"`
+	PushSubscriptions []*dispatch.PushSubscription `json:"push_subscriptions"`
+}
+
+type PrivacyRepository interface {
+	// ScheduleDeletion sets when the account is anonymised, or cancels the
+	// deletion when at is nil.
+	ScheduleDeletion(ctx context.Context, userID string, at *time.Time) error
+	// ListDueForDeletion returns up to limit users whose deletion is due.
+	ListDueForDeletion(ctx context.Context, now time.Time, limit int) ([]string, error)
+	// Anonymize replaces the user's personal data in one transaction. The
+	// user row and their bookings stay for accounting; waitlist entries,
+	// notifications, credentials and sessions are removed.
+	Anonymize(ctx context.Context, userID string, at time.Time) error
+}
+
+type PrivacyUsecase interface {
+	ExportData(ctx context.Context, userID string) (*Export, error)
+	// RequestDeletion schedules the user's own account for deletion after
+	// the grace period. It asks for the password again.
+	RequestDeletion(ctx context.Context, userID, password, ip string) (*model.User, error)
+	CancelDeletion(ctx context.Context, actorID, userID, ip string) error
+	// DeleteNow anonymises an account at once, skipping the grace period.
+	DeleteNow(ctx context.Context, actorID, userID, ip string) error
+	// PurgeDue anonymises the accounts whose grace period ended and returns
+	// how many there were.
+	PurgeDue(ctx context.Context) (int, error)
+}
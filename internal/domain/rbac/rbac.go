@@ -0,0 +1,86 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Permissions checked by the API. Each one is also a row in the permissions
+// table, which the admin role always holds in full.
+const (
+	PermEventsWrite         = "events:write"
+	PermEventsManageAll     = "events:manage_all"
+	PermBookingsCreate      = "bookings:create"
+	PermBookingsReadAll     = "bookings:read_all"
+	PermAnalyticsRead       = "analytics:read"
+	PermCatalogWrite        = "catalog:write"
+	PermVenuesWrite         = "venues:write"
+	PermSeriesWrite         = "series:write"
+	PermTemplatesWrite      = "templates:write"
+	PermRolesManage         = "roles:manage"
+	PermUsersManage         = "users:manage"
+	PermAPIKeysManage       = "api_keys:manage"
+	PermNotificationsManage = "notifications:manage"
+	PermCheckinScan         = "checkin:scan"
+)
+
+// ErrRoleInUse is returned when deleting a role that is still assigned.
+var ErrRoleInUse = errors.New("role is assigned to users")
+
+type Permission struct {
+	Name        string `json:"name" db:"name"`
+	Description string `json:"description" db:"description"`
+}
+
+type Role struct {
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	Permissions []string  `json:"permissions" db:"-"`
+	IsSystem    bool      `json:"is_system" db:"is_system"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// HasPermission reports whether the role grants permission.
+func (r *Role) HasPermission(permission string) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPrivileged reports whether the role can do more than book tickets.
+func (r *Role) IsPrivileged() bool {
+	for _, p := range r.Permissions {
+		if p != PermBookingsCreate {
+			return true
+		}
+	}
+	return false
+}
+
+type RoleRepository interface {
+	ListPermissions(ctx context.Context) ([]*Permission, error)
+	ListRoles(ctx context.Context) ([]*Role, error)
+	GetRole(ctx context.Context, name string) (*Role, error)
+	// CreateRole and UpdateRole store the role with exactly its Permissions.
+	CreateRole(ctx context.Context, role *Role) error
+	UpdateRole(ctx context.Context, role *Role) error
+	DeleteRole(ctx context.Context, name string) error
+	SetUserRole(ctx context.Context, userID, role string) error
+}
+
+type RoleUsecase interface {
+	ListPermissions(ctx context.Context) ([]*Permission, error)
+	ListRoles(ctx context.Context) ([]*Role, error)
+	GetRole(ctx context.Context, name string) (*Role, error)
+	CreateRole(ctx context.Context, role *Role) error
+	UpdateRole(ctx context.Context, role *Role) error
+	DeleteRole(ctx context.Context, name string) error
+	// AssignRole changes the role of a user. Nobody can change their own
+	// role, so the last admin cannot lock everyone out by accident.
+	AssignRole(ctx context.Context, actorID, userID, role string) error
+}
@@ -0,0 +1,34 @@
+package realtime
+
+import (
+	"context"
+
+	"evently/internal/domain/model"
+)
+
+// Postgres channels the database triggers notify on.
+const (
+	NotificationsChannel = "evently_notifications"
+	AvailabilityChannel  = "evently_availability"
+)
+
+// Availability is the seat count of an event after a change.
+type Availability struct {
+	EventID        string `json:"event_id"`
+	AvailableSeats int    `json:"available_seats"`
+	TotalCapacity  int    `json:"total_capacity"`
+}
+
+// Broker fans database changes out to the streams open on this instance.
+// A subscription's channel is closed when the subscriber cancels it, when
+// the subscriber falls too far behind, and when the broker closes, so
+// streams end and clients reconnect.
+type Broker interface {
+	SubscribeNotifications(userID string) (<-chan *model.Notification, func())
+	SubscribeAvailability(eventID string) (<-chan *Availability, func())
+	// Run listens for changes until ctx is done, reconnecting when the
+	// database connection drops.
+	Run(ctx context.Context)
+	// Close ends every subscription and refuses new ones.
+	Close()
+}
@@ -0,0 +1,253 @@
+package series
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxOccurrences caps how many events a single series may generate.
+const MaxOccurrences = 366
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+)
+
+// RecurrenceRule is the subset of an RFC 5545 RRULE that Evently supports:
+// FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, COUNT, UNTIL, BYDAY for weekly
+// rules and BYMONTHDAY for monthly rules.
+type RecurrenceRule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByDay      []time.Weekday
+	ByMonthDay []int
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// ParseRRule parses a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10".
+// A leading "RRULE:" is accepted. Either COUNT or UNTIL is required so that a
+// series always has a finite number of occurrences. An UNTIL without a "Z"
+// suffix is local time in loc, the series' time zone.
+func ParseRRule(value string, loc *time.Location) (*RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("recurrence rule is required")
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq := Frequency(strings.ToUpper(val))
+			if freq != FrequencyDaily && freq != FrequencyWeekly && freq != FrequencyMonthly {
+				return nil, fmt.Errorf("unsupported FREQ %q: must be DAILY, WEEKLY or MONTHLY", val)
+			}
+			rule.Freq = freq
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("INTERVAL must be a positive integer")
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("COUNT must be a positive integer")
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(val, loc)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, code := range strings.Split(strings.ToUpper(val), ",") {
+				day, ok := weekdayCodes[code]
+				if !ok {
+					return nil, fmt.Errorf("unsupported BYDAY value %q", code)
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(v)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY value %q", v)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+	if rule.Count == 0 && rule.Until == nil {
+		return nil, fmt.Errorf("either COUNT or UNTIL is required")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("COUNT and UNTIL cannot be combined")
+	}
+	if rule.Count > MaxOccurrences {
+		return nil, fmt.Errorf("COUNT cannot exceed %d", MaxOccurrences)
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != FrequencyWeekly {
+		return nil, fmt.Errorf("BYDAY is only supported with FREQ=WEEKLY")
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Freq != FrequencyMonthly {
+		return nil, fmt.Errorf("BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+
+	return rule, nil
+}
+
+func parseUntil(val string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", val); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", val, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102", val, loc); err == nil {
+		// A date-only UNTIL includes the whole day. The end is computed on
+		// the wall clock so that days of a DST change are handled too.
+		return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, loc), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL value %q", val)
+}
+
+// Occurrences expands the rule starting at start. Candidates are generated in
+// start's location, so the wall-clock time is kept across DST changes. As in
+// RFC 5545, COUNT is applied before exceptions are removed. Start itself is
+// only included when it matches the rule.
+func (r *RecurrenceRule) Occurrences(start time.Time, exceptions []time.Time) ([]time.Time, error) {
+	var result []time.Time
+	generated := 0
+
+	// Guards against rules that never produce a candidate, e.g. BYMONTHDAY=30
+	// with an interval that only ever lands on February.
+	maxPeriods := MaxOccurrences * 31
+
+	for period := 0; period < maxPeriods; period++ {
+		for _, candidate := range r.candidates(start, period) {
+			if candidate.Before(start) {
+				continue
+			}
+			if r.Until != nil && candidate.After(*r.Until) {
+				return result, nil
+			}
+
+			generated++
+			if !isException(candidate, exceptions) {
+				result = append(result, candidate)
+			}
+
+			if r.Count > 0 && generated >= r.Count {
+				return result, nil
+			}
+			if generated > MaxOccurrences {
+				return nil, fmt.Errorf("recurrence rule generates more than %d occurrences", MaxOccurrences)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// candidates returns the sorted instances that fall in the n-th period
+// (day, week or month) after start.
+func (r *RecurrenceRule) candidates(start time.Time, n int) []time.Time {
+	hour, min, sec := start.Clock()
+	loc := start.Location()
+	step := n * r.Interval
+
+	switch r.Freq {
+	case FrequencyDaily:
+		return []time.Time{start.AddDate(0, 0, step)}
+
+	case FrequencyWeekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		// Weeks start on Monday, the RFC 5545 default for WKST
+		offset := (int(start.Weekday()) + 6) % 7
+		weekStart := time.Date(start.Year(), start.Month(), start.Day()-offset+7*step, hour, min, sec, 0, loc)
+
+		var out []time.Time
+		for _, day := range days {
+			out = append(out, weekStart.AddDate(0, 0, (int(day)+6)%7))
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+		return dedupe(out)
+
+	case FrequencyMonthly:
+		monthStart := time.Date(start.Year(), start.Month()+time.Month(step), 1, hour, min, sec, 0, loc)
+		daysInMonth := monthStart.AddDate(0, 1, -1).Day()
+
+		monthDays := r.ByMonthDay
+		if len(monthDays) == 0 {
+			monthDays = []int{start.Day()}
+		}
+
+		var out []time.Time
+		for _, day := range monthDays {
+			if day < 0 {
+				day = daysInMonth + day + 1
+			}
+			// Days that do not exist in this month are skipped, as in RFC 5545
+			if day < 1 || day > daysInMonth {
+				continue
+			}
+			out = append(out, time.Date(monthStart.Year(), monthStart.Month(), day, hour, min, sec, 0, loc))
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+		return dedupe(out)
+	}
+
+	return nil
+}
+
+func dedupe(sorted []time.Time) []time.Time {
+	out := sorted[:0]
+	for i, t := range sorted {
+		if i == 0 || !t.Equal(sorted[i-1]) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func isException(t time.Time, exceptions []time.Time) bool {
+	for _, ex := range exceptions {
+		if t.Equal(ex) {
+			return true
+		}
+	}
+	return false
+}
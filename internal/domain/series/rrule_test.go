@@ -0,0 +1,199 @@
+package series
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{name: "weekly with count", value: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10"},
+		{name: "rrule prefix", value: "RRULE:FREQ=DAILY;COUNT=3"},
+		{name: "lower case", value: "freq=monthly;bymonthday=-1;until=20270101"},
+		{name: "empty", value: " ", wantErr: "recurrence rule is required"},
+		{name: "missing value", value: "FREQ=DAILY;COUNT=", wantErr: "invalid rule part"},
+		{name: "yearly", value: "FREQ=YEARLY;COUNT=2", wantErr: "unsupported FREQ"},
+		{name: "zero interval", value: "FREQ=DAILY;INTERVAL=0;COUNT=2", wantErr: "INTERVAL must be a positive integer"},
+		{name: "negative count", value: "FREQ=DAILY;COUNT=-1", wantErr: "COUNT must be a positive integer"},
+		{name: "bad until", value: "FREQ=DAILY;UNTIL=tomorrow", wantErr: "invalid UNTIL value"},
+		{name: "bad weekday", value: "FREQ=WEEKLY;BYDAY=XX;COUNT=2", wantErr: "unsupported BYDAY value"},
+		{name: "month day zero", value: "FREQ=MONTHLY;BYMONTHDAY=0;COUNT=2", wantErr: "invalid BYMONTHDAY value"},
+		{name: "month day out of range", value: "FREQ=MONTHLY;BYMONTHDAY=32;COUNT=2", wantErr: "invalid BYMONTHDAY value"},
+		{name: "unknown part", value: "FREQ=DAILY;BYHOUR=9;COUNT=2", wantErr: "unsupported rule part"},
+		{name: "no freq", value: "COUNT=2", wantErr: "FREQ is required"},
+		{name: "unbounded", value: "FREQ=DAILY", wantErr: "either COUNT or UNTIL is required"},
+		{name: "count and until", value: "FREQ=DAILY;COUNT=2;UNTIL=20270101", wantErr: "COUNT and UNTIL cannot be combined"},
+		{name: "too many", value: "FREQ=DAILY;COUNT=367", wantErr: "COUNT cannot exceed"},
+		{name: "byday not weekly", value: "FREQ=DAILY;BYDAY=MO;COUNT=2", wantErr: "BYDAY is only supported with FREQ=WEEKLY"},
+		{name: "bymonthday not monthly", value: "FREQ=WEEKLY;BYMONTHDAY=1;COUNT=2", wantErr: "BYMONTHDAY is only supported with FREQ=MONTHLY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRRule(tt.value, time.UTC)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ParseRRule(%q) failed: %v", tt.value, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ParseRRule(%q) error = %v, want %q", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRRuleUntil(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		value string
+		loc   *time.Location
+		want  time.Time
+	}{
+		{value: "20270315T100000Z", loc: time.UTC, want: time.Date(2027, 3, 15, 10, 0, 0, 0, time.UTC)},
+		{value: "20270315T100000", loc: time.UTC, want: time.Date(2027, 3, 15, 10, 0, 0, 0, time.UTC)},
+		// A date includes the whole day
+		{value: "20270315", loc: time.UTC, want: time.Date(2027, 3, 15, 23, 59, 59, 0, time.UTC)},
+		// Only a Z suffix is UTC, anything else is local time of the series
+		{value: "20270315T100000Z", loc: berlin, want: time.Date(2027, 3, 15, 10, 0, 0, 0, time.UTC)},
+		{value: "20270315T100000", loc: berlin, want: time.Date(2027, 3, 15, 9, 0, 0, 0, time.UTC)},
+		{value: "20270315", loc: berlin, want: time.Date(2027, 3, 15, 22, 59, 59, 0, time.UTC)},
+		{value: "20270328", loc: berlin, want: time.Date(2027, 3, 28, 21, 59, 59, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseRRule("FREQ=DAILY;UNTIL="+tt.value, tt.loc)
+		if err != nil {
+			t.Fatalf("ParseRRule failed: %v", err)
+		}
+		if !rule.Until.Equal(tt.want) {
+			t.Errorf("UNTIL=%s in %s = %s, want %s", tt.value, tt.loc, rule.Until, tt.want)
+		}
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	// Monday 5 April 2027, 19:00
+	start := time.Date(2027, 4, 5, 19, 0, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2027, month, d, 19, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		rule       string
+		start      time.Time
+		exceptions []time.Time
+		want       []time.Time
+	}{
+		{
+			name:  "daily",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: start,
+			want:  []time.Time{day(4, 5), day(4, 6), day(4, 7)},
+		},
+		{
+			name:  "every other day until",
+			rule:  "FREQ=DAILY;INTERVAL=2;UNTIL=20270410",
+			start: start,
+			want:  []time.Time{day(4, 5), day(4, 7), day(4, 9)},
+		},
+		{
+			name:  "weekly by day",
+			rule:  "FREQ=WEEKLY;BYDAY=WE,MO;COUNT=4",
+			start: start,
+			want:  []time.Time{day(4, 5), day(4, 7), day(4, 12), day(4, 14)},
+		},
+		{
+			name:  "start not matching the rule is skipped",
+			rule:  "FREQ=WEEKLY;BYDAY=TU;COUNT=2",
+			start: start,
+			want:  []time.Time{day(4, 6), day(4, 13)},
+		},
+		{
+			name:  "biweekly",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			start: start,
+			want:  []time.Time{day(4, 5), day(4, 19), day(5, 3)},
+		},
+		{
+			name:  "last day of the month",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			start: start,
+			want:  []time.Time{day(4, 30), day(5, 31), day(6, 30)},
+		},
+		{
+			name:  "missing month days are skipped",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=2",
+			start: start,
+			want:  []time.Time{day(5, 31), day(7, 31)},
+		},
+		{
+			name:       "count applies before exceptions",
+			rule:       "FREQ=DAILY;COUNT=3",
+			start:      start,
+			exceptions: []time.Time{day(4, 6)},
+			want:       []time.Time{day(4, 5), day(4, 7)},
+		},
+		{
+			name:  "until is local to the series",
+			rule:  "FREQ=DAILY;UNTIL=20270323",
+			start: time.Date(2027, 3, 23, 0, 30, 0, 0, berlin),
+			want:  []time.Time{time.Date(2027, 3, 22, 23, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "wall clock kept across DST",
+			rule:  "FREQ=WEEKLY;COUNT=2",
+			start: time.Date(2027, 3, 22, 19, 0, 0, 0, berlin),
+			want: []time.Time{
+				time.Date(2027, 3, 22, 18, 0, 0, 0, time.UTC),
+				time.Date(2027, 3, 29, 17, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule, tt.start.Location())
+			if err != nil {
+				t.Fatalf("ParseRRule(%q) failed: %v", tt.rule, err)
+			}
+			got, err := rule.Occurrences(tt.start, tt.exceptions)
+			if err != nil {
+				t.Fatalf("Occurrences failed: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %v", len(got), got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOccurrencesLimit(t *testing.T) {
+	rule, err := ParseRRule("FREQ=DAILY;UNTIL=20400101", time.UTC)
+	if err != nil {
+		t.Fatalf("ParseRRule failed: %v", err)
+	}
+	if _, err := rule.Occurrences(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), nil); err == nil {
+		t.Fatal("expected an error for a rule generating more than MaxOccurrences")
+	}
+}
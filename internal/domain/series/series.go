@@ -0,0 +1,70 @@
+package series
+
+import (
+	"context"
+	"time"
+
+	"evently/internal/domain/events"
+)
+
+// Series is the template from which recurring occurrences are generated.
+// Each occurrence is stored as a regular event linked through SeriesID, so
+// bookings and waitlists stay per occurrence.
+type Series struct {
+	ID            string      `json:"id" db:"id"`
+	Name          string      `json:"name" db:"name"`
+	Description   string      `json:"description" db:"description"`
+	Venue         string      `json:"venue" db:"venue"`
+	VenueID       *string     `json:"venue_id,omitempty" db:"venue_id"`
+	StartTime     time.Time   `json:"start_time" db:"start_time"`
+	Timezone      string      `json:"timezone" db:"timezone"`
+	RRule         string      `json:"rrule" db:"rrule"`
+	Exceptions    []time.Time `json:"exceptions" db:"exceptions"`
+	TotalCapacity int         `json:"total_capacity" db:"total_capacity"`
+	Price         float64     `json:"price" db:"price"`
+	CreatedBy     string      `json:"created_by" db:"created_by"`
+	CreatedAt     time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// EditScope selects which occurrences a bulk edit applies to.
+type EditScope string
+
+const (
+	EditScopeThis      EditScope = "this"
+	EditScopeFollowing EditScope = "following"
+	EditScopeAll       EditScope = "all"
+)
+
+// OccurrenceUpdate holds the fields of a bulk edit. Nil fields are left
+// unchanged. A new EventTime is applied to the selected occurrence and the
+// same shift of local date and time is applied to the other occurrences in
+// scope.
+type OccurrenceUpdate struct {
+	Name          *string    `json:"name"`
+	Description   *string    `json:"description"`
+	Venue         *string    `json:"venue"`
+	EventTime     *time.Time `json:"event_time"`
+	TotalCapacity *int       `json:"total_capacity"`
+	Price         *float64   `json:"price"`
+}
+
+type SeriesRepository interface {
+	CreateWithOccurrences(ctx context.Context, series *Series, occurrences []*events.Event) error
+	// UpdateWithOccurrences updates the occurrences, and the series unless
+	// it is nil, in one transaction. Capacities are checked against the
+	// locked rows as by EventRepository.UpdateWithCapacity; it returns the
+	// change in available seats of each occurrence.
+	UpdateWithOccurrences(ctx context.Context, series *Series, occurrences []*events.Event) ([]int, error)
+	GetByID(ctx context.Context, id string) (*Series, error)
+	List(ctx context.Context, limit, offset int) ([]*Series, error)
+	GetOccurrences(ctx context.Context, seriesID string, from time.Time, limit, offset int) ([]*events.Event, error)
+}
+
+type SeriesUsecase interface {
+	CreateSeries(ctx context.Context, series *Series) ([]*events.Event, error)
+	GetSeries(ctx context.Context, seriesID string) (*Series, error)
+	ListSeries(ctx context.Context, limit, offset int) ([]*Series, error)
+	ListUpcomingOccurrences(ctx context.Context, seriesID string, limit, offset int) ([]*events.Event, error)
+	UpdateOccurrences(ctx context.Context, seriesID, eventID string, scope EditScope, update *OccurrenceUpdate) ([]*events.Event, error)
+}
@@ -0,0 +1,80 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/pass"
+)
+
+// ErrDuplicateName is returned when another template already has the name.
+var ErrDuplicateName = errors.New("template name is already in use")
+
+// Template is a named, reusable event configuration. The configuration is
+// stored as a document so new event settings can be added to it without a
+// schema change.
+type Template struct {
+	ID          string      `json:"id" db:"id"`
+	Name        string      `json:"name" db:"name"`
+	Description string      `json:"description" db:"description"`
+	Config      EventConfig `json:"config" db:"config"`
+	CreatedBy   string      `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// EventConfig holds everything about an event except its date and its
+// bookings.
+type EventConfig struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Venue         string   `json:"venue,omitempty"`
+	VenueID       *string  `json:"venue_id,omitempty"`
+	Timezone      string   `json:"timezone,omitempty"`
+	TotalCapacity int      `json:"total_capacity"`
+	Price         float64  `json:"price"`
+	CategoryID    *string  `json:"category_id,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// Overrides replace template settings when creating an event. Nil fields
+// keep the template's value.
+type Overrides struct {
+	EventTime     time.Time `json:"event_time" binding:"required"`
+	Name          *string   `json:"name"`
+	Description   *string   `json:"description"`
+	Venue         *string   `json:"venue"`
+	VenueID       *string   `json:"venue_id"`
+	Timezone      *string   `json:"timezone"`
+	TotalCapacity *int      `json:"total_capacity"`
+	Price         *float64  `json:"price"`
+	CategoryID    *string   `json:"category_id"`
+	Tags          *[]string `json:"tags"`
+}
+
+type TemplateRepository interface {
+	Create(ctx context.Context, template *Template) error
+	Update(ctx context.Context, template *Template) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*Template, error)
+	List(ctx context.Context, limit, offset int) ([]*Template, error)
+	// CreateClone stores a cloned event with its sessions and passes in one
+	// transaction.
+	CreateClone(ctx context.Context, event *events.Event, sessions []*events.Event, passes []*pass.Pass) error
+}
+
+type TemplateUsecase interface {
+	CreateTemplate(ctx context.Context, template *Template) error
+	// CreateTemplateFromEvent saves the configuration of an existing event.
+	CreateTemplateFromEvent(ctx context.Context, eventID string, template *Template) error
+	UpdateTemplate(ctx context.Context, template *Template) error
+	DeleteTemplate(ctx context.Context, templateID string) error
+	GetTemplate(ctx context.Context, templateID string) (*Template, error)
+	ListTemplates(ctx context.Context, limit, offset int) ([]*Template, error)
+	CreateEventFromTemplate(ctx context.Context, templateID string, overrides *Overrides, createdBy string) (*events.Event, error)
+	// CloneEvent copies an event, its sessions and its passes to a new date.
+	// Bookings, waitlist entries and notifications are never copied.
+	CloneEvent(ctx context.Context, eventID string, eventTime time.Time, createdBy string) (*events.Event, error)
+}
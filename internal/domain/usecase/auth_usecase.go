@@ -1,8 +1,38 @@
 package usecase
 
-import "evently/internal/domain/model"
+import (
+	"context"
+
+	"evently/internal/domain/auth"
+	"evently/internal/domain/model"
+)
 
 type AuthUseCase interface {
 	Register(user *model.RegisterRequest) error
-	Login(email, password string) (string, error)
+	// BootstrapAdmin creates the first admin account. It fails once any
+	// admin exists.
+	BootstrapAdmin(user *model.RegisterRequest) error
+	// Login returns auth.ErrInvalidCredentials for any wrong email or
+	// password, and *auth.ThrottledError after too many failures from the
+	// email or ip.
+	Login(ctx context.Context, email, password, ip string) (*auth.LoginResult, error)
+	// VerifyMFA completes a login that needed a second factor. code is a
+	// TOTP code or a recovery code.
+	VerifyMFA(ctx context.Context, mfaToken, code, ip string) (*auth.TokenPair, error)
+	// StartSession signs in a user who was authenticated elsewhere, such as
+	// by single sign-on.
+	StartSession(ctx context.Context, user *model.User) (*auth.TokenPair, error)
+	// PurgeLoginFailures forgets failed logins and lockouts that no longer
+	// count and returns how many were removed.
+	PurgeLoginFailures(ctx context.Context) (int, error)
+	// UnlockAccount lifts a login lockout of the user.
+	UnlockAccount(ctx context.Context, actorID, userID, ip string) error
+	// Refresh exchanges a refresh token for a new pair. The presented token
+	// is used up; presenting it again revokes the session.
+	Refresh(ctx context.Context, refreshToken string) (*auth.TokenPair, error)
+	// Logout revokes the session the refresh token belongs to.
+	Logout(ctx context.Context, refreshToken string) error
+	// RevokeSessions revokes every session of the user and returns how many
+	// were active.
+	RevokeSessions(ctx context.Context, userID string) (int, error)
 }
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+
+	"evently/internal/domain/auth"
+	"evently/internal/domain/model"
+)
+
+type UserUseCase interface {
+	// GetProfile returns the user with a summary of their bookings and
+	// waitlist entries.
+	GetProfile(ctx context.Context, userID string) (*model.Profile, error)
+	// UpdateProfile applies the set fields of req. A new email only takes
+	// effect once confirmed. A new password signs the user out everywhere
+	// and the returned pair starts the only remaining session.
+	UpdateProfile(ctx context.Context, userID string, req *model.UpdateProfileRequest, ip string) (*model.User, *auth.TokenPair, error)
+	ListUsers(ctx context.Context, filter model.UserFilter) ([]*model.User, int, error)
+	// DisableUser blocks the account at once: it cannot log in and its
+	// tokens and API keys stop working. Admins cannot disable themselves.
+	DisableUser(ctx context.Context, actorID, userID, ip string) error
+	EnableUser(ctx context.Context, actorID, userID, ip string) error
+	IsDisabled(ctx context.Context, userID string) (bool, error)
+}
@@ -8,8 +8,12 @@ import (
 type NotificationUsecase interface {
 	CreateNotification(ctx context.Context, notification *model.Notification) error
 	GetUserNotifications(ctx context.Context, userID string, limit, offset int) ([]*model.Notification, error)
-	MarkNotificationAsRead(ctx context.Context, notificationID string) error
+	ListNotifications(ctx context.Context, userID string, filter model.NotificationFilter) (*model.NotificationPage, error)
+	// MarkNotificationAsRead and DeleteNotification report other users'
+	// notifications as not found.
+	MarkNotificationAsRead(ctx context.Context, userID, notificationID string) error
 	MarkAllNotificationsAsRead(ctx context.Context, userID string) error
+	DeleteNotification(ctx context.Context, userID, notificationID string) error
 	GetUnreadNotificationCount(ctx context.Context, userID string) (int, error)
 	SendWaitlistNotification(ctx context.Context, userID, eventID string, quantity int) error
 }
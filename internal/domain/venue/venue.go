@@ -0,0 +1,57 @@
+package venue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"evently/internal/domain/events"
+)
+
+// ErrCapacityExceeded is returned when an event asks for more seats than its
+// venue holds.
+var ErrCapacityExceeded = errors.New("venue capacity exceeded")
+
+type Venue struct {
+	ID                   string    `json:"id" db:"id"`
+	Name                 string    `json:"name" db:"name"`
+	Address              string    `json:"address" db:"address"`
+	Timezone             string    `json:"timezone" db:"timezone"`
+	Latitude             *float64  `json:"latitude,omitempty" db:"latitude"`
+	Longitude            *float64  `json:"longitude,omitempty" db:"longitude"`
+	MaxCapacity          int       `json:"max_capacity" db:"max_capacity"`
+	WheelchairAccessible bool      `json:"wheelchair_accessible" db:"wheelchair_accessible"`
+	AccessibilityNotes   string    `json:"accessibility_notes" db:"accessibility_notes"`
+	CreatedAt            time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NearbyEvent is an upcoming event together with its distance from the
+// search point.
+type NearbyEvent struct {
+	*events.Event
+	DistanceKM float64 `json:"distance_km"`
+}
+
+type VenueRepository interface {
+	Create(ctx context.Context, venue *Venue) error
+	Update(ctx context.Context, venue *Venue) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*Venue, error)
+	// GetByName matches names case-insensitively and ignoring repeated spaces.
+	GetByName(ctx context.Context, name string) (*Venue, error)
+	List(ctx context.Context, search string, limit, offset int) ([]*Venue, error)
+	// MaxUpcomingCapacity returns the largest capacity of an upcoming event
+	// held at the venue, or 0 if there is none.
+	MaxUpcomingCapacity(ctx context.Context, venueID string) (int, error)
+	FindNearbyEvents(ctx context.Context, lat, lng, radiusKM float64, limit, offset int) ([]*NearbyEvent, error)
+}
+
+type VenueUsecase interface {
+	CreateVenue(ctx context.Context, venue *Venue) error
+	UpdateVenue(ctx context.Context, venue *Venue) error
+	DeleteVenue(ctx context.Context, venueID string) error
+	GetVenue(ctx context.Context, venueID string) (*Venue, error)
+	ListVenues(ctx context.Context, search string, limit, offset int) ([]*Venue, error)
+	FindNearbyEvents(ctx context.Context, lat, lng, radiusKM float64, limit, offset int) ([]*NearbyEvent, error)
+}
@@ -44,7 +44,9 @@ type WaitlistRepository interface {
 }
 
 type WaitlistUsecase interface {
-	JoinWaitlist(ctx context.Context, userID, eventID string, quantity int) error
+	// JoinWaitlist enforces the event's sale window; accessCode admits the
+	// user during a presale.
+	JoinWaitlist(ctx context.Context, userID, eventID string, quantity int, accessCode string) error
 	LeaveWaitlist(ctx context.Context, userID, eventID string) error
 	GetUserWaitlist(ctx context.Context, userID string, limit, offset int) ([]*Waitlist, error)
 	GetEventWaitlist(ctx context.Context, eventID string, limit, offset int) ([]*Waitlist, error)
@@ -0,0 +1,160 @@
+package channel
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"evently/internal/domain/channel"
+	"evently/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// smtpTimeout bounds a send whose context has no deadline.
+const smtpTimeout = 30 * time.Second
+
+// NewEmailChannel returns the email channel picked by cfg.Channel: "smtp"
+// sends through a mail server, "file" writes each message to cfg.Dir for
+// local development.
+func NewEmailChannel(cfg model.MailConfig) (channel.Channel, error) {
+	switch cfg.Channel {
+	case "smtp":
+		if cfg.SMTPHost == "" {
+			return nil, fmt.Errorf("SMTP_HOST is required for the smtp mail channel")
+		}
+		return &smtpEmailChannel{cfg: cfg}, nil
+	case "file", "":
+		if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create mail directory: %w", err)
+		}
+		return &fileEmailChannel{dir: cfg.Dir, from: cfg.From}, nil
+	default:
+		return nil, fmt.Errorf("unknown mail channel %q", cfg.Channel)
+	}
+}
+
+// formatEmail renders a plain text message with the headers mail servers
+// expect.
+func formatEmail(from string, msg *channel.Message, now time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// checkHeaders rejects values that would inject extra headers.
+func checkHeaders(msg *channel.Message) error {
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return fmt.Errorf("email headers must not contain line breaks")
+	}
+	return nil
+}
+
+type fileEmailChannel struct {
+	dir  string
+	from string
+}
+
+func (c *fileEmailChannel) Name() string {
+	return channel.Email
+}
+
+func (c *fileEmailChannel) Send(ctx context.Context, msg *channel.Message) error {
+	if err := checkHeaders(msg); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405"), uuid.New().String()[:8])
+
+	return os.WriteFile(filepath.Join(c.dir, name), formatEmail(c.from, msg, now), 0o600)
+}
+
+type smtpEmailChannel struct {
+	cfg model.MailConfig
+}
+
+func (c *smtpEmailChannel) Name() string {
+	return channel.Email
+}
+
+func (c *smtpEmailChannel) Send(ctx context.Context, msg *channel.Message) error {
+	if err := checkHeaders(msg); err != nil {
+		return err
+	}
+
+	// The envelope sender is the bare address of the From header
+	sender, err := mail.ParseAddress(c.cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid MAIL_FROM: %w", err)
+	}
+
+	dialer := net.Dialer{Timeout: smtpTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(c.cfg.SMTPHost, c.cfg.SMTPPort))
+	if err != nil {
+		return err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, c.cfg.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	// The same steps as smtp.SendMail, which cannot be given a deadline
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: c.cfg.SMTPHost}); err != nil {
+			return err
+		}
+	}
+	if c.cfg.SMTPUsername != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.cfg.SMTPUsername, c.cfg.SMTPPassword, c.cfg.SMTPHost)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(sender.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		// 5xx replies reject the address for good
+		var reply *textproto.Error
+		if errors.As(err, &reply) && reply.Code >= 500 {
+			return fmt.Errorf("%w: %v", channel.ErrUndeliverable, err)
+		}
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(formatEmail(c.cfg.From, msg, time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,208 @@
+package channel
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"evently/internal/domain/channel"
+	"evently/internal/domain/model"
+)
+
+// fakeSMTPServer accepts mail on a loopback port. rcptReplies answers RCPT
+// TO for specific recipients, everyone else is accepted. A silent server
+// never greets, like one that accepted the connection and hung.
+type fakeSMTPServer struct {
+	listener    net.Listener
+	rcptReplies map[string]string
+	silent      bool
+
+	mu       sync.Mutex
+	sender   string
+	messages []string
+}
+
+func newFakeSMTPServer(t *testing.T, rcptReplies map[string]string, silent bool) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := &fakeSMTPServer{listener: listener, rcptReplies: rcptReplies, silent: silent}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+
+	return server
+}
+
+func (s *fakeSMTPServer) config() model.MailConfig {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return model.MailConfig{Channel: "smtp", From: "Evently <noreply@example.com>", SMTPHost: host, SMTPPort: port}
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	if s.silent {
+		bufio.NewReader(conn).ReadString('\n')
+		return
+	}
+
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP fake")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			text.PrintfLine("250-localhost")
+			text.PrintfLine("250 8BITMIME")
+		case "MAIL":
+			s.mu.Lock()
+			s.sender = smtpPath(arg)
+			s.mu.Unlock()
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			if reply, ok := s.rcptReplies[smtpPath(arg)]; ok {
+				text.PrintfLine("%s", reply)
+			} else {
+				text.PrintfLine("250 OK")
+			}
+		case "DATA":
+			text.PrintfLine("354 Go ahead")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, string(data))
+			s.mu.Unlock()
+			text.PrintfLine("250 Queued")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+// smtpPath returns the address of a "FROM:<address> PARAMS" argument.
+func smtpPath(arg string) string {
+	_, path, _ := strings.Cut(arg, "<")
+	address, _, _ := strings.Cut(path, ">")
+	return address
+}
+
+func TestSMTPSend(t *testing.T) {
+	server := newFakeSMTPServer(t, nil, false)
+	ch, err := NewEmailChannel(server.config())
+	if err != nil {
+		t.Fatalf("NewEmailChannel failed: %v", err)
+	}
+
+	err = ch.Send(context.Background(), &channel.Message{To: "guest@example.com", Subject: "Your booking", Body: "See you\nthere"})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.sender != "noreply@example.com" {
+		t.Errorf("envelope sender = %q, want the bare From address", server.sender)
+	}
+	if len(server.messages) != 1 {
+		t.Fatalf("server received %d messages", len(server.messages))
+	}
+	message := server.messages[0]
+	for _, want := range []string{"From: Evently <noreply@example.com>\n", "To: guest@example.com\n", "Subject: Your booking\n", "\nSee you\nthere"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("message lacks %q:\n%s", want, message)
+		}
+	}
+}
+
+func TestSMTPSendRejected(t *testing.T) {
+	server := newFakeSMTPServer(t, map[string]string{
+		"gone@example.com": "550 5.1.1 No such user",
+		"busy@example.com": "451 4.3.0 Try again later",
+	}, false)
+	ch, err := NewEmailChannel(server.config())
+	if err != nil {
+		t.Fatalf("NewEmailChannel failed: %v", err)
+	}
+
+	tests := []struct {
+		to                string
+		wantUndeliverable bool
+	}{
+		{to: "gone@example.com", wantUndeliverable: true},
+		{to: "busy@example.com", wantUndeliverable: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.to, func(t *testing.T) {
+			err := ch.Send(context.Background(), &channel.Message{To: tt.to, Subject: "Hi", Body: "Hello"})
+			if err == nil {
+				t.Fatal("Send succeeded, want an error")
+			}
+			if errors.Is(err, channel.ErrUndeliverable) != tt.wantUndeliverable {
+				t.Errorf("Send error = %v, undeliverable %v, want %v", err, errors.Is(err, channel.ErrUndeliverable), tt.wantUndeliverable)
+			}
+		})
+	}
+}
+
+func TestSMTPSendHeaderInjection(t *testing.T) {
+	server := newFakeSMTPServer(t, nil, false)
+	ch, err := NewEmailChannel(server.config())
+	if err != nil {
+		t.Fatalf("NewEmailChannel failed: %v", err)
+	}
+
+	err = ch.Send(context.Background(), &channel.Message{To: "guest@example.com", Subject: "Hi\r\nBcc: everyone@example.com", Body: "Hello"})
+	if err == nil || !strings.Contains(err.Error(), "line breaks") {
+		t.Fatalf("Send error = %v, want a header error", err)
+	}
+	if len(server.messages) != 0 {
+		t.Errorf("server received %d messages", len(server.messages))
+	}
+}
+
+func TestSMTPSendDeadline(t *testing.T) {
+	server := newFakeSMTPServer(t, nil, true)
+	ch, err := NewEmailChannel(server.config())
+	if err != nil {
+		t.Fatalf("NewEmailChannel failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = ch.Send(ctx, &channel.Message{To: "guest@example.com", Subject: "Hi", Body: "Hello"})
+	if err == nil {
+		t.Fatal("Send succeeded against a silent server")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Send took %s, the context deadline was not applied", elapsed)
+	}
+}
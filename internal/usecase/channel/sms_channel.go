@@ -0,0 +1,123 @@
+package channel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"evently/internal/domain/channel"
+	"evently/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// maxSMSLength is the longest text, in characters, a message is cut to.
+// Providers split it into as many segments as needed.
+const maxSMSLength = 640
+
+// NewSMSChannel returns the text message channel picked by cfg.Channel:
+// "twilio" sends through Twilio's API, "file" writes each message to cfg.Dir
+// for local development. It returns nil when text messages are off.
+func NewSMSChannel(cfg model.SMSConfig) (channel.Channel, error) {
+	switch cfg.Channel {
+	case "":
+		return nil, nil
+	case "twilio":
+		if cfg.TwilioAccountSID == "" || cfg.TwilioAuthToken == "" || cfg.From == "" {
+			return nil, fmt.Errorf("TWILIO_ACCOUNT_SID, TWILIO_AUTH_TOKEN and SMS_FROM are required for the twilio sms channel")
+		}
+		return &twilioSMSChannel{cfg: cfg, client: &http.Client{Timeout: 30 * time.Second}}, nil
+	case "file":
+		if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create sms directory: %w", err)
+		}
+		return &fileSMSChannel{dir: cfg.Dir}, nil
+	default:
+		return nil, fmt.Errorf("unknown sms channel %q", cfg.Channel)
+	}
+}
+
+// smsText puts the subject in front of the body, as texts have no
+// subject line.
+func smsText(msg *channel.Message) string {
+	text := []rune(msg.Subject + "\n" + msg.Body)
+	if len(text) > maxSMSLength {
+		text = append(text[:maxSMSLength-1], '…')
+	}
+	return string(text)
+}
+
+// providerError describes a failed request to a delivery provider. Refusals
+// of the request itself are undeliverable; rate limits, outages and auth
+// problems are retried.
+func providerError(provider string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err := fmt.Errorf("%s responded %s: %s", provider, resp.Status, strings.TrimSpace(string(body)))
+
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusGone,
+		http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		return fmt.Errorf("%w: %v", channel.ErrUndeliverable, err)
+	default:
+		return err
+	}
+}
+
+type fileSMSChannel struct {
+	dir string
+}
+
+func (c *fileSMSChannel) Name() string {
+	return channel.SMS
+}
+
+func (c *fileSMSChannel) Send(ctx context.Context, msg *channel.Message) error {
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.txt", now.UTC().Format("20060102T150405"), uuid.New().String()[:8])
+	content := fmt.Sprintf("To: %s\nDate: %s\n\n%s\n", msg.To, now.Format(time.RFC1123Z), smsText(msg))
+
+	return os.WriteFile(filepath.Join(c.dir, name), []byte(content), 0o600)
+}
+
+type twilioSMSChannel struct {
+	cfg    model.SMSConfig
+	client *http.Client
+}
+
+func (c *twilioSMSChannel) Name() string {
+	return channel.SMS
+}
+
+func (c *twilioSMSChannel) Send(ctx context.Context, msg *channel.Message) error {
+	form := url.Values{
+		"From": {c.cfg.From},
+		"To":   {msg.To},
+		"Body": {smsText(msg)},
+	}
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json",
+		strings.TrimSuffix(c.cfg.TwilioURL, "/"), url.PathEscape(c.cfg.TwilioAccountSID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(c.cfg.TwilioAccountSID, c.cfg.TwilioAuthToken)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return providerError("twilio", resp)
+	}
+	return nil
+}
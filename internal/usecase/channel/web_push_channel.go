@@ -0,0 +1,315 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"evently/internal/domain/channel"
+	"evently/internal/domain/dispatch"
+	"evently/internal/domain/model"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// pushRecordSize is the record size of the encrypted payload, which
+	// always fits in one record.
+	pushRecordSize = 4096
+	// maxPushPayload is the most plaintext push services accept: 4096
+	// bytes less the header, the padding delimiter and the GCM tag.
+	maxPushPayload = 4096 - 86 - 1 - 16
+	// pushTTL is how long push services hold a message for an offline
+	// browser.
+	pushTTL = 24 * time.Hour
+)
+
+// NewWebPushChannel returns the web push channel, or nil when no VAPID key
+// is configured. Its recipients are push subscription IDs. Endpoints come
+// from browsers, so unless allowPrivate is set it refuses to connect to
+// loopback and private addresses.
+func NewWebPushChannel(cfg model.WebPushConfig, subscriptions dispatch.PushSubscriptionRepository, allowPrivate bool) (channel.Channel, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	if !strings.HasPrefix(cfg.Subject, "mailto:") && !strings.HasPrefix(cfg.Subject, "https://") {
+		return nil, fmt.Errorf("VAPID_SUBJECT must be a mailto: or https: URL for web push")
+	}
+
+	key, publicKey, err := parseVAPIDKey(cfg.VAPIDPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !allowPrivate {
+		dialer.Control = refusePrivateAddress
+	}
+
+	return &webPushChannel{
+		subject:       cfg.Subject,
+		key:           key,
+		publicKey:     publicKey,
+		subscriptions: subscriptions,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+			// A proxy would hide the address the dialer checks
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}, nil
+}
+
+// VAPIDPublicKey returns the public key of a VAPID private key, as browsers
+// expect it for PushManager.subscribe.
+func VAPIDPublicKey(privateKey string) (string, error) {
+	_, publicKey, err := parseVAPIDKey(privateKey)
+	return publicKey, err
+}
+
+// parseVAPIDKey reads a raw base64url P-256 private key, the format web
+// push libraries generate.
+func parseVAPIDKey(encoded string) (*ecdsa.PrivateKey, string, error) {
+	raw, err := decodeBase64URL(encoded)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid VAPID_PRIVATE_KEY: %w", err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid VAPID_PRIVATE_KEY: %w", err)
+	}
+
+	// Uncompressed point: 0x04, X, Y
+	public := key.PublicKey().Bytes()
+	signingKey := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(public[1:33]),
+			Y:     new(big.Int).SetBytes(public[33:]),
+		},
+		D: new(big.Int).SetBytes(raw),
+	}
+
+	return signingKey, base64.RawURLEncoding.EncodeToString(public), nil
+}
+
+// decodeBase64URL accepts base64url with or without padding, as browsers
+// and libraries differ.
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// refusePrivateAddress stops connections to anything but public unicast
+// addresses.
+func refusePrivateAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	addr := addrPort.Addr().Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return fmt.Errorf("%w: push endpoint resolves to non-public address %s", channel.ErrUndeliverable, addr)
+	}
+	return nil
+}
+
+type webPushChannel struct {
+	subject       string
+	key           *ecdsa.PrivateKey
+	publicKey     string
+	subscriptions dispatch.PushSubscriptionRepository
+	client        *http.Client
+}
+
+func (c *webPushChannel) Name() string {
+	return channel.WebPush
+}
+
+func (c *webPushChannel) Send(ctx context.Context, msg *channel.Message) error {
+	subscription, err := c.subscriptions.GetByID(ctx, msg.To)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return fmt.Errorf("%w: %v", channel.ErrUndeliverable, err)
+		}
+		return err
+	}
+
+	payload, err := pushPayload(msg)
+	if err != nil {
+		return err
+	}
+	body, err := encryptPushPayload(subscription, payload)
+	if err != nil {
+		return err
+	}
+
+	authorization, err := c.authorization(subscription.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", channel.ErrUndeliverable, err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", fmt.Sprint(int(pushTTL.Seconds())))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode/100 == 2:
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		// The browser unsubscribed or the subscription expired
+		if err := c.subscriptions.Delete(ctx, subscription.ID, ""); err != nil && !strings.HasSuffix(err.Error(), "not found") {
+			return err
+		}
+		return providerError("push service", resp)
+	default:
+		return providerError("push service", resp)
+	}
+}
+
+// authorization signs the VAPID token for the push service at endpoint
+// (RFC 8292).
+func (c *webPushChannel) authorization(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid push endpoint: %v", channel.ErrUndeliverable, err)
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": c.subject,
+	}).SignedString(c.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+
+	return fmt.Sprintf("vapid t=%s, k=%s", token, c.publicKey), nil
+}
+
+// pushPayload is the JSON the service worker receives, with the body cut
+// short when it would not fit.
+func pushPayload(msg *channel.Message) ([]byte, error) {
+	body := []rune(msg.Body)
+	for {
+		payload, err := json.Marshal(map[string]string{
+			"title": msg.Subject,
+			"body":  string(body),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(payload) <= maxPushPayload {
+			return payload, nil
+		}
+		if len(body) == 0 {
+			return nil, fmt.Errorf("%w: push title is too long", channel.ErrUndeliverable)
+		}
+		body = body[:len(body)*3/4]
+	}
+}
+
+// encryptPushPayload encrypts payload for the subscription's browser as a
+// single aes128gcm record (RFC 8291).
+func encryptPushPayload(subscription *dispatch.PushSubscription, payload []byte) ([]byte, error) {
+	// A fresh key pair and salt per message, both travel in the header
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	return sealPushPayload(subscription, payload, asPrivate, salt)
+}
+
+// sealPushPayload is encryptPushPayload with the application server's key
+// pair and the salt given.
+func sealPushPayload(subscription *dispatch.PushSubscription, payload []byte, asPrivate *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	uaPublicBytes, err := decodeBase64URL(subscription.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid p256dh key: %v", channel.ErrUndeliverable, err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid p256dh key: %v", channel.ErrUndeliverable, err)
+	}
+	authSecret, err := decodeBase64URL(subscription.Auth)
+	if err != nil || len(authSecret) == 0 {
+		return nil, fmt.Errorf("%w: invalid auth secret", channel.ErrUndeliverable)
+	}
+
+	asPublic := asPrivate.PublicKey().Bytes()
+	sharedSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", channel.ErrUndeliverable, err)
+	}
+
+	keyInfo := "WebPush: info\x00" + string(uaPublicBytes) + string(asPublic)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	cek, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Header: salt, record size, key ID length and the key ID
+	header := make([]byte, 0, 86)
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, pushRecordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	// 0x02 marks the last record, without padding
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
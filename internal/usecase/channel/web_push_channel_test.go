@@ -0,0 +1,85 @@
+package channel
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"evently/internal/domain/channel"
+	"evently/internal/domain/dispatch"
+)
+
+// TestSealPushPayload checks the example of RFC 8291 Appendix A.
+func TestSealPushPayload(t *testing.T) {
+	decode := func(s string) []byte {
+		t.Helper()
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			t.Fatalf("invalid test vector %q: %v", s, err)
+		}
+		return b
+	}
+
+	asPrivate, err := ecdh.P256().NewPrivateKey(decode("yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+	if err != nil {
+		t.Fatalf("invalid application server key: %v", err)
+	}
+	subscription := &dispatch.PushSubscription{
+		P256dh: "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:   "BTBZMqHH6r4Tts7J_aSIgg",
+	}
+
+	body, err := sealPushPayload(subscription, []byte("When I grow up, I want to be a watermelon"), asPrivate, decode("DGv6ra1nlYgDCS1FRnbzlw"))
+	if err != nil {
+		t.Fatalf("sealPushPayload failed: %v", err)
+	}
+
+	want := "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+	if got := base64.RawURLEncoding.EncodeToString(body); got != want {
+		t.Errorf("body = %s\nwant   %s", got, want)
+	}
+}
+
+func TestEncryptPushPayloadInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription dispatch.PushSubscription
+	}{
+		{name: "p256dh not base64", subscription: dispatch.PushSubscription{P256dh: "not base64!", Auth: "BTBZMqHH6r4Tts7J_aSIgg"}},
+		{name: "p256dh not a point", subscription: dispatch.PushSubscription{P256dh: "BCVx", Auth: "BTBZMqHH6r4Tts7J_aSIgg"}},
+		{
+			name:         "no auth secret",
+			subscription: dispatch.PushSubscription{P256dh: "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := encryptPushPayload(&tt.subscription, []byte("hello")); !errors.Is(err, channel.ErrUndeliverable) {
+				t.Errorf("encryptPushPayload error = %v, want ErrUndeliverable", err)
+			}
+		})
+	}
+}
+
+func TestPushPayload(t *testing.T) {
+	payload, err := pushPayload(&channel.Message{Subject: "Title", Body: strings.Repeat("ü", 5000)})
+	if err != nil {
+		t.Fatalf("pushPayload failed: %v", err)
+	}
+	if len(payload) > maxPushPayload {
+		t.Errorf("payload is %d bytes, more than %d", len(payload), maxPushPayload)
+	}
+	var decoded map[string]string
+	if err := json.Unmarshal(payload, &decoded); err != nil || decoded["title"] != "Title" || decoded["body"] == "" {
+		t.Errorf("payload = %s", payload)
+	}
+
+	_, err = pushPayload(&channel.Message{Subject: strings.Repeat("x", maxPushPayload)})
+	if !errors.Is(err, channel.ErrUndeliverable) {
+		t.Errorf("pushPayload error = %v, want ErrUndeliverable for a title that cannot fit", err)
+	}
+}
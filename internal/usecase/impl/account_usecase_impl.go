@@ -0,0 +1,176 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"evently/internal/domain/auth"
+	"evently/internal/domain/channel"
+	"evently/internal/domain/model"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// minPasswordLength applies to passwords set through a reset.
+const minPasswordLength = 8
+
+type accountUsecaseImpl struct {
+	userRepo         model.UserRepository
+	accountTokenRepo auth.AccountTokenRepository
+	email            channel.Channel
+	appURL           string
+}
+
+func NewAccountUsecase(userRepo model.UserRepository, accountTokenRepo auth.AccountTokenRepository, email channel.Channel, appURL string) auth.AccountUsecase {
+	return &accountUsecaseImpl{
+		userRepo:         userRepo,
+		accountTokenRepo: accountTokenRepo,
+		email:            email,
+		appURL:           strings.TrimRight(appURL, "/"),
+	}
+}
+
+func (u *accountUsecaseImpl) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := u.userRepo.GetByEmail(strings.TrimSpace(email))
+	if err != nil {
+		return nil
+	}
+
+	token, err := u.issueToken(ctx, user.ID, auth.PurposePasswordReset, auth.PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	return u.send(ctx, user.Email, "Reset your Evently password", fmt.Sprintf(
+		"Hi %s,\n\nSomeone asked to reset the password of your Evently account. "+
+			"If it was you, open the link below within an hour:\n\n%s\n\n"+
+			"Otherwise you can ignore this email; your password stays the same.\n",
+		user.Name, u.link("/reset-password", token)))
+}
+
+func (u *accountUsecaseImpl) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if len(newPassword) < minPasswordLength {
+		return fmt.Errorf("validation failed: password must be at least %d characters", minPasswordLength)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// Either the token is used up and the password changed, or neither
+	return u.accountTokenRepo.ResetPassword(ctx, hashToken(token), string(hashedPassword), time.Now())
+}
+
+func (u *accountUsecaseImpl) RequestEmailVerification(ctx context.Context, email string) error {
+	user, err := u.userRepo.GetByEmail(strings.TrimSpace(email))
+	if err != nil || user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	token, err := u.issueToken(ctx, user.ID, auth.PurposeEmailVerification, auth.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return u.send(ctx, user.Email, "Verify your email address", fmt.Sprintf(
+		"Hi %s,\n\nPlease confirm that this is your email address by opening the link below "+
+			"within 48 hours:\n\n%s\n",
+		user.Name, u.link("/verify-email", token)))
+}
+
+func (u *accountUsecaseImpl) VerifyEmail(ctx context.Context, token string) error {
+	now := time.Now()
+	consumed, err := u.accountTokenRepo.Consume(ctx, hashToken(token), auth.PurposeEmailVerification, now)
+	if err != nil {
+		return err
+	}
+
+	return u.userRepo.MarkEmailVerified(consumed.UserID, now)
+}
+
+func (u *accountUsecaseImpl) IsEmailVerified(ctx context.Context, userID string) (bool, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return false, fmt.Errorf("user not found: %w", err)
+	}
+
+	return user.EmailVerifiedAt != nil, nil
+}
+
+func (u *accountUsecaseImpl) RequestEmailChange(ctx context.Context, userID, newEmail string) error {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+
+	if err := u.userRepo.SetPendingEmail(user.ID, &newEmail); err != nil {
+		return fmt.Errorf("failed to store new email: %w", err)
+	}
+
+	token, err := u.issueToken(ctx, user.ID, auth.PurposeEmailChange, auth.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	err = u.send(ctx, newEmail, "Confirm your new email address", fmt.Sprintf(
+		"Hi %s,\n\nPlease confirm that you want to use this address for your Evently account "+
+			"by opening the link below within 48 hours:\n\n%s\n",
+		user.Name, u.link("/confirm-email", token)))
+	if err != nil {
+		return err
+	}
+
+	return u.send(ctx, user.Email, "Your Evently email is being changed", fmt.Sprintf(
+		"Hi %s,\n\nSomeone asked to change the email of your Evently account to %s. "+
+			"It changes once the new address is confirmed. If this was not you, reset your password.\n",
+		user.Name, newEmail))
+}
+
+func (u *accountUsecaseImpl) ConfirmEmailChange(ctx context.Context, token string) error {
+	now := time.Now()
+	consumed, err := u.accountTokenRepo.Consume(ctx, hashToken(token), auth.PurposeEmailChange, now)
+	if err != nil {
+		return err
+	}
+
+	return u.userRepo.ConfirmEmailChange(consumed.UserID, now)
+}
+
+func (u *accountUsecaseImpl) issueToken(ctx context.Context, userID string, purpose auth.TokenPurpose, ttl time.Duration) (string, error) {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	now := time.Now()
+	err = u.accountTokenRepo.Create(ctx, &auth.AccountToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (u *accountUsecaseImpl) link(path, token string) string {
+	return u.appURL + path + "?token=" + url.QueryEscape(token)
+}
+
+func (u *accountUsecaseImpl) send(ctx context.Context, to, subject, body string) error {
+	err := u.email.Send(ctx, &channel.Message{To: to, Subject: subject, Body: body})
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package impl
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"evently/internal/domain/apikey"
+	"evently/internal/domain/audit"
+	"evently/internal/domain/events"
+	"evently/internal/domain/model"
+	"evently/internal/domain/rbac"
+
+	"github.com/google/uuid"
+)
+
+type apiKeyUsecaseImpl struct {
+	apiKeyRepo apikey.APIKeyRepository
+	userRepo   model.UserRepository
+	roleRepo   rbac.RoleRepository
+	eventRepo  events.EventRepository
+	auditRepo  audit.AuditRepository
+}
+
+func NewAPIKeyUsecase(apiKeyRepo apikey.APIKeyRepository, userRepo model.UserRepository, roleRepo rbac.RoleRepository, eventRepo events.EventRepository, auditRepo audit.AuditRepository) apikey.APIKeyUsecase {
+	return &apiKeyUsecaseImpl{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		roleRepo:   roleRepo,
+		eventRepo:  eventRepo,
+		auditRepo:  auditRepo,
+	}
+}
+
+func (u *apiKeyUsecaseImpl) CreateKey(ctx context.Context, actorID string, actorPermissions []string, req *apikey.CreateAPIKeyRequest, ip string) (*apikey.APIKey, string, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return nil, "", fmt.Errorf("validation failed: name must be 1-100 characters")
+	}
+	if len(req.Scopes) == 0 {
+		return nil, "", fmt.Errorf("validation failed: at least one scope is required")
+	}
+
+	// A key can never do more than the admin who created it
+	held := make(map[string]bool, len(actorPermissions))
+	for _, p := range actorPermissions {
+		held[p] = true
+	}
+	for _, scope := range req.Scopes {
+		if !held[scope] {
+			return nil, "", fmt.Errorf("validation failed: scope %q is not a permission you hold", scope)
+		}
+	}
+
+	for _, eventID := range req.EventIDs {
+		if _, err := u.eventRepo.GetByID(eventID); err != nil {
+			return nil, "", fmt.Errorf("validation failed: event %s not found", eventID)
+		}
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, "", fmt.Errorf("validation failed: expires_at must be in the future")
+	}
+
+	prefix, err := newAPIKeyPrefix()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	secret, err := newOpaqueToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	plaintext := apikey.KeyPrefix + prefix + "_" + secret
+
+	eventIDs := req.EventIDs
+	if eventIDs == nil {
+		eventIDs = []string{}
+	}
+
+	key := &apikey.APIKey{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hashToken(plaintext),
+		Scopes:    req.Scopes,
+		EventIDs:  eventIDs,
+		CreatedBy: actorID,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: now,
+	}
+	if err := u.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	err = u.audit(ctx, actorID, audit.ActionAPIKeyCreated, key, ip, map[string]any{
+		"name":      key.Name,
+		"scopes":    key.Scopes,
+		"event_ids": key.EventIDs,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, plaintext, nil
+}
+
+func (u *apiKeyUsecaseImpl) ListKeys(ctx context.Context, limit, offset int) ([]*apikey.APIKey, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.apiKeyRepo.List(ctx, limit, offset)
+}
+
+func (u *apiKeyUsecaseImpl) RevokeKey(ctx context.Context, actorID, id, ip string) error {
+	key, err := u.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := u.apiKeyRepo.Revoke(ctx, id, time.Now()); err != nil {
+		return err
+	}
+
+	return u.audit(ctx, actorID, audit.ActionAPIKeyRevoked, key, ip, map[string]any{"name": key.Name})
+}
+
+func (u *apiKeyUsecaseImpl) Authenticate(ctx context.Context, plaintext string) (*apikey.Principal, error) {
+	rest, ok := strings.CutPrefix(plaintext, apikey.KeyPrefix)
+	if !ok {
+		return nil, apikey.ErrInvalidAPIKey
+	}
+	prefix, _, ok := strings.Cut(rest, "_")
+	if !ok {
+		return nil, apikey.ErrInvalidAPIKey
+	}
+
+	key, err := u.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(hashToken(plaintext))) != 1 {
+		return nil, apikey.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && !key.ExpiresAt.After(now)) {
+		return nil, apikey.ErrInvalidAPIKey
+	}
+
+	// The creator's current role bounds the key, so demoting the admin
+	// narrows their keys too
+	user, err := u.userRepo.GetByID(key.CreatedBy)
+	if err != nil || user.DisabledAt != nil {
+		return nil, apikey.ErrInvalidAPIKey
+	}
+	role, err := u.roleRepo.GetRole(ctx, user.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load role %s: %w", user.Role, err)
+	}
+
+	permissions := []string{}
+	for _, scope := range key.Scopes {
+		if role.HasPermission(scope) {
+			permissions = append(permissions, scope)
+		}
+	}
+
+	if err := u.apiKeyRepo.TouchLastUsed(ctx, key.ID, now); err != nil {
+		return nil, fmt.Errorf("failed to record api key use: %w", err)
+	}
+
+	return &apikey.Principal{
+		Key:         key,
+		UserID:      user.ID,
+		Role:        user.Role,
+		Permissions: permissions,
+	}, nil
+}
+
+func (u *apiKeyUsecaseImpl) audit(ctx context.Context, actorID, action string, key *apikey.APIKey, ip string, metadata map[string]any) error {
+	err := u.auditRepo.Create(ctx, &audit.Entry{
+		ID:         uuid.New().String(),
+		ActorID:    &actorID,
+		Action:     action,
+		TargetType: "api_key",
+		TargetID:   key.ID,
+		IP:         ip,
+		Metadata:   metadata,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// newAPIKeyPrefix returns the public part of a key, which looks it up.
+func newAPIKeyPrefix() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,28 @@
+package impl
+
+import (
+	"context"
+
+	"evently/internal/domain/audit"
+)
+
+type auditUsecaseImpl struct {
+	auditRepo audit.AuditRepository
+}
+
+func NewAuditUsecase(auditRepo audit.AuditRepository) audit.AuditUsecase {
+	return &auditUsecaseImpl{
+		auditRepo: auditRepo,
+	}
+}
+
+func (u *auditUsecaseImpl) ListEntries(ctx context.Context, action string, limit, offset int) ([]*audit.Entry, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.auditRepo.List(ctx, action, limit, offset)
+}
@@ -1,12 +1,24 @@
 package impl
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"sync"
 	"time"
 
 	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/audit"
+	"evently/internal/domain/auth"
+	"evently/internal/domain/mfa"
 	"evently/internal/domain/model"
+	"evently/internal/domain/rbac"
 	"evently/internal/domain/usecase"
 
 	"github.com/google/uuid"
@@ -14,25 +26,53 @@ import (
 )
 
 type authUsecaseImpl struct {
-	userRepo model.UserRepository
-	config   *model.Config
+	userRepo         model.UserRepository
+	roleRepo         rbac.RoleRepository
+	refreshTokenRepo auth.RefreshTokenRepository
+	loginAttemptRepo auth.LoginAttemptRepository
+	mfaRepo          mfa.MFARepository
+	auditRepo        audit.AuditRepository
+	tokens           *middleware.JWTConfig
+	config           *model.Config
 }
 
-func NewAuthUseCase(userRepo model.UserRepository, config *model.Config) usecase.AuthUseCase {
+func NewAuthUseCase(userRepo model.UserRepository, roleRepo rbac.RoleRepository, refreshTokenRepo auth.RefreshTokenRepository, loginAttemptRepo auth.LoginAttemptRepository, mfaRepo mfa.MFARepository, auditRepo audit.AuditRepository, tokens *middleware.JWTConfig, config *model.Config) usecase.AuthUseCase {
 	return &authUsecaseImpl{
-		userRepo: userRepo,
-		config:   config,
+		userRepo:         userRepo,
+		roleRepo:         roleRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		mfaRepo:          mfaRepo,
+		auditRepo:        auditRepo,
+		tokens:           tokens,
+		config:           config,
 	}
 }
 
 func (u *authUsecaseImpl) Register(req *model.RegisterRequest) error {
+	return u.createUser(req, model.RoleUser)
+}
+
+func (u *authUsecaseImpl) BootstrapAdmin(req *model.RegisterRequest) error {
+	admins, err := u.userRepo.CountByRole(model.RoleAdmin)
+	if err != nil {
+		return fmt.Errorf("failed to count admins: %w", err)
+	}
+	if admins > 0 {
+		return fmt.Errorf("an admin already exists, invite further admins instead")
+	}
+
+	return u.createUser(req, model.RoleAdmin)
+}
+
+func (u *authUsecaseImpl) createUser(req *model.RegisterRequest, role string) error {
 	// Check if user already exists
 
 	newUser := model.User{
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: req.Password,
-		Role:     req.Role,
+		Role:     role,
 	}
 
 	existingUser, _ := u.userRepo.GetByEmail(newUser.Email)
@@ -51,11 +91,6 @@ func (u *authUsecaseImpl) Register(req *model.RegisterRequest) error {
 	newUser.Password = string(hashedPassword)
 	newUser.CreatedAt = time.Now()
 
-	// Set default role if not specified
-	if newUser.Role == "" {
-		newUser.Role = "user"
-	}
-
 	// Validate user data
 	if err := u.validateUser(&newUser); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
@@ -64,26 +99,404 @@ func (u *authUsecaseImpl) Register(req *model.RegisterRequest) error {
 	return u.userRepo.Create(&newUser)
 }
 
-func (u *authUsecaseImpl) Login(email, password string) (string, error) {
-	// Get user by email
+func (u *authUsecaseImpl) Login(ctx context.Context, email, password, ip string) (*auth.LoginResult, error) {
+	now := time.Now()
+	key := strings.ToLower(strings.TrimSpace(email))
+
+	attempt, err := beginLoginAttempt(ctx, u.loginAttemptRepo, key, ip, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unknown emails still pay for a bcrypt comparison so response times
+	// do not reveal which emails have accounts
 	user, err := u.userRepo.GetByEmail(email)
+	hash := dummyPasswordHash()
+	if err == nil {
+		hash = []byte(user.Password)
+	}
+
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil || user == nil {
+		if err := u.loginFailed(ctx, key, ip, attempt, now); err != nil {
+			return nil, err
+		}
+		return nil, auth.ErrInvalidCredentials
+	}
+	forgetLoginAttempt(ctx, u.loginAttemptRepo, attempt)
+
+	if user.DisabledAt != nil {
+		return nil, auth.ErrAccountDisabled
+	}
+
+	// Failures are only forgotten once the second factor passed too
+	enrollment, err := u.mfaRepo.Get(ctx, user.ID)
+	if err == nil && enrollment.EnabledAt != nil {
+		token, err := u.tokens.GenerateStepToken(user.ID, middleware.MFAChallengePurpose, auth.MFAChallengeTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate mfa token: %w", err)
+		}
+		return &auth.LoginResult{MFAToken: token}, nil
+	}
+
+	tokens, err := u.completeLogin(ctx, user, key)
+	var enroll *auth.MFAEnrollmentRequiredError
+	if errors.As(err, &enroll) {
+		return &auth.LoginResult{MFAToken: enroll.MFAToken, MFAEnrollmentRequired: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.LoginResult{Tokens: tokens}, nil
+}
+
+func (u *authUsecaseImpl) VerifyMFA(ctx context.Context, mfaToken, code, ip string) (*auth.TokenPair, error) {
+	userID, err := u.tokens.ParseStepToken(mfaToken, middleware.MFAChallengePurpose)
+	if err != nil {
+		return nil, auth.ErrInvalidMFAToken
+	}
+
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, auth.ErrInvalidMFAToken
+	}
+
+	// Codes are guessed against the same limits as passwords
+	now := time.Now()
+	key := strings.ToLower(strings.TrimSpace(user.Email))
+	enrollment, err := u.mfaRepo.Get(ctx, user.ID)
+	if err != nil || enrollment.EnabledAt == nil {
+		return nil, auth.ErrInvalidMFAToken
+	}
+
+	attempt, err := beginLoginAttempt(ctx, u.loginAttemptRepo, key, ip, now)
+	if err != nil {
+		return nil, err
+	}
+
+	method, ok, err := checkSecondFactor(ctx, u.mfaRepo, enrollment, code, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check two-factor code: %w", err)
+	}
+	if !ok {
+		if err := u.loginFailed(ctx, key, ip, attempt, now); err != nil {
+			return nil, err
+		}
+		return nil, auth.ErrInvalidMFACode
+	}
+
+	if method == "recovery_code" {
+		err := u.auditRepo.Create(ctx, &audit.Entry{
+			ID:         uuid.New().String(),
+			ActorID:    &user.ID,
+			Action:     audit.ActionMFARecoveryCodeUsed,
+			TargetType: "user",
+			TargetID:   user.ID,
+			IP:         ip,
+			CreatedAt:  now,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to write audit log: %w", err)
+		}
+	}
+
+	return u.completeLogin(ctx, user, key)
+}
+
+// completeLogin forgets the failed attempts and starts a session.
+func (u *authUsecaseImpl) completeLogin(ctx context.Context, user *model.User, key string) (*auth.TokenPair, error) {
+	if err := u.loginAttemptRepo.ClearFailures(ctx, key); err != nil {
+		log.Printf("failed to clear login failures: %v", err)
+	}
+
+	// Each login starts a new family of refresh tokens
+	return u.issueTokens(ctx, user, uuid.New().String(), "")
+}
+
+func (u *authUsecaseImpl) StartSession(ctx context.Context, user *model.User) (*auth.TokenPair, error) {
+	return u.issueTokens(ctx, user, uuid.New().String(), "")
+}
+
+// loginFailed locks the email once the failed attempt brings it to the
+// threshold, and records when the ip reaches its own.
+func (u *authUsecaseImpl) loginFailed(ctx context.Context, email, ip string, attempt *auth.LoginAttempt, now time.Time) error {
+	lockedUntil := now.Add(auth.LockoutDuration)
+
+	if attempt.EmailFailures >= auth.LockoutThreshold {
+		if err := u.loginAttemptRepo.Lock(ctx, email, lockedUntil); err != nil {
+			return fmt.Errorf("failed to lock account: %w", err)
+		}
+
+		err := u.auditRepo.Create(ctx, &audit.Entry{
+			ID:         uuid.New().String(),
+			Action:     audit.ActionLoginLocked,
+			TargetType: "email",
+			TargetID:   email,
+			IP:         ip,
+			Metadata: map[string]any{
+				"failures":     attempt.EmailFailures,
+				"locked_until": lockedUntil,
+			},
+			CreatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write audit log: %w", err)
+		}
+	}
+
+	// The ip stays locked while its failures are at the threshold; further
+	// attempts are refused before they count, so this is written once
+	if attempt.IPFailures == auth.IPLockoutThreshold {
+		err := u.auditRepo.Create(ctx, &audit.Entry{
+			ID:         uuid.New().String(),
+			Action:     audit.ActionLoginIPLocked,
+			TargetType: "ip",
+			TargetID:   ip,
+			IP:         ip,
+			Metadata: map[string]any{
+				"failures":     attempt.IPFailures,
+				"locked_until": lockedUntil,
+			},
+			CreatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write audit log: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (u *authUsecaseImpl) PurgeLoginFailures(ctx context.Context) (int, error) {
+	now := time.Now()
+	return u.loginAttemptRepo.DeleteExpired(ctx, now.Add(-auth.LoginFailureWindow), now)
+}
+
+func (u *authUsecaseImpl) UnlockAccount(ctx context.Context, actorID, userID, ip string) error {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+
+	key := strings.ToLower(strings.TrimSpace(user.Email))
+	if err := u.loginAttemptRepo.Unlock(ctx, key); err != nil {
+		return fmt.Errorf("failed to unlock account: %w", err)
+	}
+
+	return u.auditRepo.Create(ctx, &audit.Entry{
+		ID:         uuid.New().String(),
+		ActorID:    &actorID,
+		Action:     audit.ActionLoginUnlocked,
+		TargetType: "user",
+		TargetID:   user.ID,
+		IP:         ip,
+		CreatedAt:  time.Now(),
+	})
+}
+
+// beginLoginAttempt starts an attempt to prove who the email belongs to,
+// or returns a *auth.ThrottledError when the caller has to wait. The attempt
+// counts as failed until forgetLoginAttempt.
+func beginLoginAttempt(ctx context.Context, loginAttemptRepo auth.LoginAttemptRepository, email, ip string, now time.Time) (*auth.LoginAttempt, error) {
+	attempt, wait, err := loginAttemptRepo.BeginAttempt(ctx, email, ip, now.Add(-auth.LoginFailureWindow), now, loginRetryAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check login attempts: %w", err)
+	}
+	if attempt == nil {
+		return nil, &auth.ThrottledError{RetryAfter: wait}
+	}
+	return attempt, nil
+}
+
+// forgetLoginAttempt stops counting an attempt that succeeded.
+func forgetLoginAttempt(ctx context.Context, loginAttemptRepo auth.LoginAttemptRepository, attempt *auth.LoginAttempt) {
+	if err := loginAttemptRepo.ForgetAttempt(ctx, attempt.ID); err != nil {
+		log.Printf("failed to forget login attempt: %v", err)
+	}
+}
+
+// loginRetryAfter returns how long the caller has to wait before the next
+// attempt, or zero when it may try now.
+func loginRetryAfter(stats *auth.FailureStats, now time.Time) time.Duration {
+	var wait time.Duration
+	later := func(until time.Time) {
+		if d := until.Sub(now); d > wait {
+			wait = d
+		}
+	}
+
+	if stats.LockedUntil != nil {
+		later(*stats.LockedUntil)
+	}
+	if stats.IPFailures >= auth.IPLockoutThreshold && stats.LastIPFailure != nil {
+		later(stats.LastIPFailure.Add(auth.LockoutDuration))
+	}
+	if stats.LastEmailFailure != nil {
+		later(stats.LastEmailFailure.Add(progressiveDelay(stats.EmailFailures, auth.LoginDelayAfter)))
+	}
+	if stats.LastIPFailure != nil {
+		later(stats.LastIPFailure.Add(progressiveDelay(stats.IPFailures, auth.IPDelayAfter)))
+	}
+
+	return wait
+}
+
+// progressiveDelay doubles from one second for every failure past the free
+// ones, up to LoginMaxDelay.
+func progressiveDelay(failures, free int) time.Duration {
+	if failures < free {
+		return 0
+	}
+
+	delay := time.Second
+	for i := free; i < failures && delay < auth.LoginMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, auth.LoginMaxDelay)
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// dummyPasswordHash is compared against when the email is unknown.
+func dummyPasswordHash() []byte {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a real password"), bcrypt.DefaultCost)
+	})
+	return dummyHash
+}
+
+func (u *authUsecaseImpl) Refresh(ctx context.Context, refreshToken string) (*auth.TokenPair, error) {
+	now := time.Now()
+	stored, err := u.refreshTokenRepo.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return nil, auth.ErrInvalidRefreshToken
+	}
+
+	if stored.UsedAt != nil && stored.RevokedAt == nil {
+		// A replaced token came back, so either the client or an attacker
+		// holds a copy. End the session for both.
+		if err := u.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID, now); err != nil {
+			return nil, fmt.Errorf("failed to revoke session: %w", err)
+		}
+		return nil, auth.ErrRefreshTokenReused
+	}
+	if stored.RevokedAt != nil || !stored.ExpiresAt.After(now) {
+		return nil, auth.ErrInvalidRefreshToken
+	}
+
+	user, err := u.userRepo.GetByID(stored.UserID)
+	if err != nil {
+		return nil, auth.ErrInvalidRefreshToken
+	}
+
+	pair, err := u.issueTokens(ctx, user, stored.FamilyID, stored.ID)
+	if errors.Is(err, auth.ErrRefreshTokenReused) {
+		if err := u.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID, now); err != nil {
+			return nil, fmt.Errorf("failed to revoke session: %w", err)
+		}
+	}
+
+	return pair, err
+}
+
+func (u *authUsecaseImpl) Logout(ctx context.Context, refreshToken string) error {
+	stored, err := u.refreshTokenRepo.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return auth.ErrInvalidRefreshToken
+	}
+
+	return u.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID, time.Now())
+}
+
+func (u *authUsecaseImpl) RevokeSessions(ctx context.Context, userID string) (int, error) {
+	if _, err := u.userRepo.GetByID(userID); err != nil {
+		return 0, fmt.Errorf("user not found")
+	}
+
+	return u.refreshTokenRepo.RevokeAllForUser(ctx, userID, time.Now())
+}
+
+// issueTokens signs an access token carrying the permissions of the user's
+// role and stores a new refresh token in the family. When replacing is set
+// that token is used up in the same step.
+func (u *authUsecaseImpl) issueTokens(ctx context.Context, user *model.User, familyID, replacing string) (*auth.TokenPair, error) {
+	// Covers refreshes and every way of logging in
+	if user.DisabledAt != nil {
+		return nil, auth.ErrAccountDisabled
+	}
+
+	role, err := u.roleRepo.GetRole(ctx, user.Role)
 	if err != nil {
-		return "", fmt.Errorf("email doesn't exist in database please register")
+		return nil, fmt.Errorf("failed to load role %s: %w", user.Role, err)
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		log.Printf("Password comparison failed: %v", err)
-		return "", fmt.Errorf("invalid password")
+	// Also covers users promoted after they logged in, and single sign-on
+	if u.config.Auth.RequireMFAForPrivileged && role.IsPrivileged() {
+		enrollment, err := u.mfaRepo.Get(ctx, user.ID)
+		if err != nil || enrollment.EnabledAt == nil {
+			token, err := u.tokens.GenerateStepToken(user.ID, middleware.MFAEnrollPurpose, auth.MFAEnrollTTL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate mfa token: %w", err)
+			}
+			return nil, &auth.MFAEnrollmentRequiredError{MFAToken: token}
+		}
+	}
+
+	accessToken, err := u.tokens.GenerateToken(user.ID, user.Role, role.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	// Generate JWT token
-	jwtConfig := middleware.NewJWTConfig()
-	token, err := jwtConfig.GenerateToken(user.ID, user.Role)
+	refreshToken, err := newOpaqueToken()
 	if err != nil {
-		return "", fmt.Errorf("failed to generate token: %w", err)
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	now := time.Now()
+	stored := &auth.RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: now.Add(u.tokens.RefreshTTL),
+		CreatedAt: now,
+	}
+
+	if replacing == "" {
+		err = u.refreshTokenRepo.Create(ctx, stored)
+	} else {
+		err = u.refreshTokenRepo.Rotate(ctx, replacing, stored, now)
 	}
+	if errors.Is(err, auth.ErrRefreshTokenReused) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &auth.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(u.tokens.AccessTTL.Seconds()),
+	}, nil
+}
+
+// newOpaqueToken returns a random URL-safe token. Only its hash is stored.
+func newOpaqueToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
 
-	return token, nil
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 func (u *authUsecaseImpl) validateUser(user *model.User) error {
@@ -95,8 +508,8 @@ func (u *authUsecaseImpl) validateUser(user *model.User) error {
 		return fmt.Errorf("email is required")
 	}
 
-	if user.Role != "user" && user.Role != "admin" {
-		return fmt.Errorf("invalid role: must be 'user' or 'admin'")
+	if _, err := u.roleRepo.GetRole(context.Background(), user.Role); err != nil {
+		return fmt.Errorf("invalid role: %s", user.Role)
 	}
 
 	return nil
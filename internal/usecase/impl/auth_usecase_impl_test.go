@@ -0,0 +1,228 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"evently/internal/domain/audit"
+	"evently/internal/domain/auth"
+)
+
+func TestProgressiveDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		free     int
+		want     time.Duration
+	}{
+		{failures: 0, free: 3, want: 0},
+		{failures: 2, free: 3, want: 0},
+		{failures: 3, free: 3, want: time.Second},
+		{failures: 4, free: 3, want: 2 * time.Second},
+		{failures: 6, free: 3, want: 8 * time.Second},
+		{failures: 8, free: 3, want: auth.LoginMaxDelay},
+		{failures: 1000, free: 3, want: auth.LoginMaxDelay},
+		{failures: 20, free: 20, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := progressiveDelay(tt.failures, tt.free); got != tt.want {
+			t.Errorf("progressiveDelay(%d, %d) = %s, want %s", tt.failures, tt.free, got, tt.want)
+		}
+	}
+}
+
+func TestLoginRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		at := now.Add(-d)
+		return &at
+	}
+
+	tests := []struct {
+		name  string
+		stats auth.FailureStats
+		want  time.Duration
+	}{
+		{name: "no failures"},
+		{
+			name:  "free failures",
+			stats: auth.FailureStats{EmailFailures: 2, LastEmailFailure: ago(0)},
+		},
+		{
+			name:  "email delay",
+			stats: auth.FailureStats{EmailFailures: 4, LastEmailFailure: ago(time.Second)},
+			want:  time.Second,
+		},
+		{
+			name:  "email delay passed",
+			stats: auth.FailureStats{EmailFailures: 4, LastEmailFailure: ago(5 * time.Second)},
+		},
+		{
+			name:  "email locked",
+			stats: auth.FailureStats{EmailFailures: 1, LastEmailFailure: ago(0), LockedUntil: ago(-10 * time.Minute)},
+			want:  10 * time.Minute,
+		},
+		{
+			name:  "ip delay",
+			stats: auth.FailureStats{IPFailures: auth.IPDelayAfter + 1, LastIPFailure: ago(0)},
+			want:  2 * time.Second,
+		},
+		{
+			name:  "ip locked",
+			stats: auth.FailureStats{IPFailures: auth.IPLockoutThreshold, LastIPFailure: ago(time.Minute)},
+			want:  auth.LockoutDuration - time.Minute,
+		},
+		{
+			name: "longest wait wins",
+			stats: auth.FailureStats{
+				EmailFailures: 8, LastEmailFailure: ago(0),
+				IPFailures: auth.IPDelayAfter, LastIPFailure: ago(0),
+			},
+			want: auth.LoginMaxDelay,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := loginRetryAfter(&tt.stats, now); got != tt.want {
+				t.Errorf("loginRetryAfter = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeLoginAttemptRepo struct {
+	auth.LoginAttemptRepository
+	stats     auth.FailureStats
+	recorded  int
+	forgotten []string
+	locked    map[string]time.Time
+}
+
+func (r *fakeLoginAttemptRepo) BeginAttempt(ctx context.Context, email, ip string, since, now time.Time, throttle auth.ThrottlePolicy) (*auth.LoginAttempt, time.Duration, error) {
+	if wait := throttle(&r.stats, now); wait > 0 {
+		return nil, wait, nil
+	}
+	r.recorded++
+	return &auth.LoginAttempt{
+		ID:            "attempt",
+		EmailFailures: r.stats.EmailFailures + 1,
+		IPFailures:    r.stats.IPFailures + 1,
+	}, 0, nil
+}
+
+func (r *fakeLoginAttemptRepo) ForgetAttempt(ctx context.Context, id string) error {
+	r.forgotten = append(r.forgotten, id)
+	return nil
+}
+
+func (r *fakeLoginAttemptRepo) Lock(ctx context.Context, email string, until time.Time) error {
+	if r.locked == nil {
+		r.locked = map[string]time.Time{}
+	}
+	r.locked[email] = until
+	return nil
+}
+
+type fakeAuditRepo struct {
+	audit.AuditRepository
+	entries []*audit.Entry
+}
+
+func (r *fakeAuditRepo) Create(ctx context.Context, entry *audit.Entry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func TestBeginLoginAttempt(t *testing.T) {
+	now := time.Now()
+	last := now.Add(-time.Second)
+	repo := &fakeLoginAttemptRepo{stats: auth.FailureStats{EmailFailures: 5, LastEmailFailure: &last}}
+
+	_, err := beginLoginAttempt(context.Background(), repo, "a@example.com", "10.0.0.1", now)
+	var throttled *auth.ThrottledError
+	if !errors.As(err, &throttled) || throttled.RetryAfter != 3*time.Second {
+		t.Fatalf("beginLoginAttempt error = %v, want a 3s ThrottledError", err)
+	}
+	if repo.recorded != 0 {
+		t.Errorf("a throttled attempt was recorded")
+	}
+
+	repo.stats = auth.FailureStats{}
+	attempt, err := beginLoginAttempt(context.Background(), repo, "a@example.com", "10.0.0.1", now)
+	if err != nil {
+		t.Fatalf("beginLoginAttempt failed: %v", err)
+	}
+	if attempt.EmailFailures != 1 || repo.recorded != 1 {
+		t.Errorf("attempt = %+v, recorded %d", attempt, repo.recorded)
+	}
+}
+
+func TestLoginFailed(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		attempt     auth.LoginAttempt
+		wantLocked  bool
+		wantActions []string
+	}{
+		{
+			name:    "below the thresholds",
+			attempt: auth.LoginAttempt{EmailFailures: auth.LockoutThreshold - 1, IPFailures: auth.IPLockoutThreshold - 1},
+		},
+		{
+			name:        "email threshold",
+			attempt:     auth.LoginAttempt{EmailFailures: auth.LockoutThreshold, IPFailures: 1},
+			wantLocked:  true,
+			wantActions: []string{audit.ActionLoginLocked},
+		},
+		{
+			name:        "ip threshold",
+			attempt:     auth.LoginAttempt{EmailFailures: 1, IPFailures: auth.IPLockoutThreshold},
+			wantActions: []string{audit.ActionLoginIPLocked},
+		},
+		{
+			name:        "both",
+			attempt:     auth.LoginAttempt{EmailFailures: auth.LockoutThreshold, IPFailures: auth.IPLockoutThreshold},
+			wantLocked:  true,
+			wantActions: []string{audit.ActionLoginLocked, audit.ActionLoginIPLocked},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := &fakeLoginAttemptRepo{}
+			audits := &fakeAuditRepo{}
+			u := &authUsecaseImpl{loginAttemptRepo: attempts, auditRepo: audits}
+
+			if err := u.loginFailed(context.Background(), "a@example.com", "10.0.0.1", &tt.attempt, now); err != nil {
+				t.Fatalf("loginFailed failed: %v", err)
+			}
+
+			until, locked := attempts.locked["a@example.com"]
+			if locked != tt.wantLocked {
+				t.Errorf("locked = %v, want %v", locked, tt.wantLocked)
+			}
+			if locked && !until.Equal(now.Add(auth.LockoutDuration)) {
+				t.Errorf("locked until %s", until)
+			}
+
+			if len(audits.entries) != len(tt.wantActions) {
+				t.Fatalf("got %d audit entries, want %v", len(audits.entries), tt.wantActions)
+			}
+			for i, entry := range audits.entries {
+				if entry.Action != tt.wantActions[i] {
+					t.Errorf("entry %d action = %q, want %q", i, entry.Action, tt.wantActions[i])
+				}
+			}
+			if n := len(audits.entries); n > 0 && tt.wantActions[n-1] == audit.ActionLoginIPLocked {
+				if entry := audits.entries[n-1]; entry.TargetType != "ip" || entry.TargetID != "10.0.0.1" {
+					t.Errorf("ip entry targets %s %s", entry.TargetType, entry.TargetID)
+				}
+			}
+		})
+	}
+}
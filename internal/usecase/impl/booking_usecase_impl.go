@@ -8,6 +8,8 @@ import (
 
 	"evently/internal/domain/booking"
 	"evently/internal/domain/events"
+	"evently/internal/domain/pass"
+	"evently/internal/domain/presale"
 	"evently/internal/domain/waitlist"
 
 	"github.com/google/uuid"
@@ -16,6 +18,9 @@ import (
 type bookingUsecaseImpl struct {
 	bookingRepo     booking.BookingRepository
 	eventRepo       events.EventRepository
+	passRepo        pass.PassRepository
+	presaleRepo     presale.PresaleRepository
+	accessCodeKey   AccessCodeKey
 	waitlistUsecase waitlist.WaitlistUsecase
 	mu              sync.RWMutex // For handling concurrent bookings
 }
@@ -23,16 +28,26 @@ type bookingUsecaseImpl struct {
 func NewBookingUsecase(
 	bookingRepo booking.BookingRepository,
 	eventRepo events.EventRepository,
+	passRepo pass.PassRepository,
+	presaleRepo presale.PresaleRepository,
+	accessCodeKey AccessCodeKey,
 	waitlistUsecase waitlist.WaitlistUsecase,
 ) booking.BookingUsecase {
 	return &bookingUsecaseImpl{
 		bookingRepo:     bookingRepo,
 		eventRepo:       eventRepo,
+		passRepo:        passRepo,
+		presaleRepo:     presaleRepo,
+		accessCodeKey:   accessCodeKey,
 		waitlistUsecase: waitlistUsecase,
 	}
 }
 
 func (u *bookingUsecaseImpl) CreateBooking(ctx context.Context, newBooking *booking.Booking) error {
+	if newBooking.PassID != nil {
+		return u.createPassBooking(ctx, newBooking)
+	}
+
 	// Use mutex to handle concurrent bookings safely
 	u.mu.Lock()
 	defer u.mu.Unlock()
@@ -44,10 +59,17 @@ func (u *bookingUsecaseImpl) CreateBooking(ctx context.Context, newBooking *book
 	}
 
 	// Check if event is in the future
-	if event.EventTime.Before(time.Now()) {
+	if event.HasStarted(time.Now()) {
 		return fmt.Errorf("cannot book tickets for past events")
 	}
 
+	// Check the sale window, letting presale buyers in early
+	err = checkSalesOpen(ctx, u.presaleRepo, u.accessCodeKey, event.ID, event.OnSaleAt, event.OffSaleAt,
+		newBooking.UserID, newBooking.AccessCode, time.Now())
+	if err != nil {
+		return err
+	}
+
 	// Check seat availability
 	if event.AvailableSeats < newBooking.Quantity {
 		return fmt.Errorf("insufficient seats available. Available: %d, Requested: %d",
@@ -105,6 +127,10 @@ func (u *bookingUsecaseImpl) CancelBooking(ctx context.Context, bookingID, userI
 		return fmt.Errorf("booking is already cancelled")
 	}
 
+	if oldBooking.PassID != nil {
+		return u.cancelPassBooking(ctx, oldBooking)
+	}
+
 	// Get event to check cancellation policy (e.g., can't cancel within 24 hours)
 	event, err := u.eventRepo.GetByID(oldBooking.EventID)
 	if err != nil {
@@ -112,7 +138,7 @@ func (u *bookingUsecaseImpl) CancelBooking(ctx context.Context, bookingID, userI
 	}
 
 	// Check if event has already passed
-	if event.EventTime.Before(time.Now()) {
+	if event.HasStarted(time.Now()) {
 		return fmt.Errorf("cannot cancel booking for past events")
 	}
 
@@ -144,10 +170,140 @@ func (u *bookingUsecaseImpl) CancelBooking(ctx context.Context, bookingID, userI
 	return nil
 }
 
+// createPassBooking books a pass by taking one seat per ticket in each of its
+// sessions. Seats are taken in a single transaction, so either every session
+// is booked or none is.
+func (u *bookingUsecaseImpl) createPassBooking(ctx context.Context, newBooking *booking.Booking) error {
+	p, err := u.passRepo.GetByID(ctx, *newBooking.PassID)
+	if err != nil {
+		return fmt.Errorf("pass not found: %w", err)
+	}
+
+	if newBooking.EventID != "" && newBooking.EventID != p.EventID {
+		return fmt.Errorf("validation failed: pass does not belong to event")
+	}
+	newBooking.EventID = p.EventID
+
+	event, err := u.eventRepo.GetByID(p.EventID)
+	if err != nil {
+		return fmt.Errorf("event not found: %w", err)
+	}
+
+	// A pass has its own sale window; times it leaves unset follow the event
+	onSaleAt, offSaleAt := event.OnSaleAt, event.OffSaleAt
+	if p.OnSaleAt != nil {
+		onSaleAt = p.OnSaleAt
+	}
+	if p.OffSaleAt != nil {
+		offSaleAt = p.OffSaleAt
+	}
+	err = checkSalesOpen(ctx, u.presaleRepo, u.accessCodeKey, event.ID, onSaleAt, offSaleAt,
+		newBooking.UserID, newBooking.AccessCode, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, sessionID := range p.SessionIDs {
+		session, err := u.eventRepo.GetByID(sessionID)
+		if err != nil {
+			return fmt.Errorf("session not found: %w", err)
+		}
+		if session.HasStarted(time.Now()) {
+			return fmt.Errorf("cannot book a pass that includes past sessions")
+		}
+	}
+
+	now := time.Now()
+	newBooking.ID = uuid.New().String()
+	newBooking.Status = booking.BookingStatusConfirmed
+	newBooking.BookingTime = now
+	newBooking.CreatedAt = now
+	newBooking.UpdatedAt = now
+	newBooking.TotalAmount = float64(newBooking.Quantity) * p.Price
+
+	if err := u.validateBooking(newBooking); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := u.bookingRepo.CreateWithSessionSeats(ctx, newBooking, p.SessionIDs); err != nil {
+		return fmt.Errorf("failed to create booking: %w", err)
+	}
+
+	return nil
+}
+
+// cancelPassBooking releases the seats of a pass booking in every session
+// and offers them to each session's waitlist.
+func (u *bookingUsecaseImpl) cancelPassBooking(ctx context.Context, oldBooking *booking.Booking) error {
+	p, err := u.passRepo.GetByID(ctx, *oldBooking.PassID)
+	if err != nil {
+		return fmt.Errorf("pass not found: %w", err)
+	}
+
+	var sessions []*events.Event
+	for _, sessionID := range p.SessionIDs {
+		session, err := u.eventRepo.GetByID(sessionID)
+		if err != nil {
+			return fmt.Errorf("session not found: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	// A pass can only be cancelled before its first session starts
+	for _, session := range sessions {
+		if session.HasStarted(time.Now()) {
+			return fmt.Errorf("cannot cancel booking for past events")
+		}
+	}
+
+	now := time.Now()
+	oldBooking.Status = booking.BookingStatusCancelled
+	oldBooking.CancelledAt = &now
+	oldBooking.UpdatedAt = now
+
+	if err := u.bookingRepo.CancelWithSessionSeats(ctx, oldBooking, p.SessionIDs); err != nil {
+		return fmt.Errorf("failed to cancel booking: %w", err)
+	}
+
+	if u.waitlistUsecase != nil {
+		for _, session := range sessions {
+			if err := u.waitlistUsecase.ProcessWaitlistNotifications(ctx, session.ID, oldBooking.Quantity); err != nil {
+				// Log error but don't fail the cancellation
+				fmt.Printf("Failed to process waitlist notifications: %v\n", err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (u *bookingUsecaseImpl) GetBooking(ctx context.Context, bookingID string) (*booking.Booking, error) {
 	return u.bookingRepo.GetByID(bookingID)
 }
 
+func (u *bookingUsecaseImpl) CheckIn(ctx context.Context, eventID, bookingID string) (*booking.Booking, error) {
+	b, err := u.bookingRepo.GetByID(bookingID)
+	if err != nil || b.EventID != eventID {
+		return nil, fmt.Errorf("booking not found")
+	}
+
+	if b.Status != booking.BookingStatusConfirmed {
+		return nil, booking.ErrNotConfirmed
+	}
+	if b.CheckedInAt != nil {
+		return nil, booking.ErrAlreadyCheckedIn
+	}
+
+	now := time.Now()
+	if err := u.bookingRepo.CheckIn(ctx, b.ID, now); err != nil {
+		return nil, err
+	}
+	b.CheckedInAt = &now
+	b.UpdatedAt = now
+
+	return b, nil
+}
+
 func (u *bookingUsecaseImpl) GetUserBookings(ctx context.Context, userID string, limit, offset int) ([]*booking.Booking, error) {
 	if limit <= 0 {
 		limit = 10
@@ -0,0 +1,163 @@
+package impl
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"evently/internal/domain/booking"
+	"evently/internal/domain/calendar"
+	"evently/internal/domain/catalog"
+	"evently/internal/domain/events"
+	"evently/internal/domain/venue"
+)
+
+// Bookings for events further in the past than this are left out of the
+// user feed.
+const userFeedHistory = 30 * 24 * time.Hour
+
+type calendarUsecaseImpl struct {
+	calendarRepo calendar.CalendarRepository
+	eventRepo    events.EventRepository
+	catalogRepo  catalog.CatalogRepository
+	venueRepo    venue.VenueRepository
+}
+
+func NewCalendarUsecase(calendarRepo calendar.CalendarRepository, eventRepo events.EventRepository, catalogRepo catalog.CatalogRepository, venueRepo venue.VenueRepository) calendar.CalendarUsecase {
+	return &calendarUsecaseImpl{
+		calendarRepo: calendarRepo,
+		eventRepo:    eventRepo,
+		catalogRepo:  catalogRepo,
+		venueRepo:    venueRepo,
+	}
+}
+
+func (u *calendarUsecaseImpl) BookingCalendar(ctx context.Context, b *booking.Booking) (*calendar.Calendar, error) {
+	event, err := u.eventRepo.GetByID(b.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found: %w", err)
+	}
+
+	return &calendar.Calendar{
+		Name:    event.Name,
+		Entries: []*calendar.Entry{bookingEntry(b, event)},
+	}, nil
+}
+
+func (u *calendarUsecaseImpl) CreateFeedToken(ctx context.Context, userID string) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate feed token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	if err := u.calendarRepo.SetFeedToken(ctx, userID, hashFeedToken(token)); err != nil {
+		return "", fmt.Errorf("failed to store feed token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (u *calendarUsecaseImpl) UserFeed(ctx context.Context, token string) (*calendar.Calendar, error) {
+	userID, err := u.calendarRepo.GetUserIDByFeedToken(ctx, hashFeedToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("feed not found: %w", err)
+	}
+
+	bookings, err := u.calendarRepo.ListUserBookings(ctx, userID, time.Now().Add(-userFeedHistory), calendar.MaxFeedEvents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookings: %w", err)
+	}
+
+	cal := &calendar.Calendar{Name: "My Evently bookings"}
+	for _, entry := range bookings {
+		cal.Entries = append(cal.Entries, bookingEntry(entry.Booking, entry.Event))
+	}
+
+	return cal, nil
+}
+
+func (u *calendarUsecaseImpl) CategoryFeed(ctx context.Context, category string) (*calendar.Calendar, error) {
+	c, err := u.catalogRepo.GetCategory(ctx, category)
+	if err != nil {
+		return nil, fmt.Errorf("feed not found: %w", err)
+	}
+
+	list, err := u.eventRepo.ListUpcoming(events.EventFilter{Category: c.ID}, calendar.MaxFeedEvents, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	return eventCalendar("Evently: "+c.Name, list), nil
+}
+
+func (u *calendarUsecaseImpl) VenueFeed(ctx context.Context, venueID string) (*calendar.Calendar, error) {
+	v, err := u.venueRepo.GetByID(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("feed not found: %w", err)
+	}
+
+	list, err := u.eventRepo.ListUpcoming(events.EventFilter{VenueID: v.ID}, calendar.MaxFeedEvents, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	return eventCalendar("Evently: "+v.Name, list), nil
+}
+
+func eventCalendar(name string, list []*events.Event) *calendar.Calendar {
+	cal := &calendar.Calendar{Name: name}
+	for _, event := range list {
+		cal.Entries = append(cal.Entries, &calendar.Entry{
+			UID:          fmt.Sprintf("event-%s@evently", event.ID),
+			Sequence:     event.Sequence,
+			Status:       calendar.StatusConfirmed,
+			Summary:      event.Name,
+			Description:  event.Description,
+			Location:     event.Venue,
+			Start:        event.EventTime,
+			Timezone:     event.Timezone,
+			LastModified: event.UpdatedAt,
+		})
+	}
+	return cal
+}
+
+// bookingEntry builds the entry for a booking. The UID is derived from the
+// booking, so the single-booking download and the user feed update the same
+// entry. A cancellation is one more revision on top of the event's.
+func bookingEntry(b *booking.Booking, event *events.Event) *calendar.Entry {
+	entry := &calendar.Entry{
+		UID:          fmt.Sprintf("booking-%s@evently", b.ID),
+		Sequence:     event.Sequence,
+		Status:       calendar.StatusConfirmed,
+		Summary:      event.Name,
+		Description:  fmt.Sprintf("%s\n\nTickets: %d\nBooking: %s", event.Description, b.Quantity, b.ID),
+		Location:     event.Venue,
+		Start:        event.EventTime,
+		Timezone:     event.Timezone,
+		LastModified: event.UpdatedAt,
+	}
+
+	switch b.Status {
+	case booking.BookingStatusCancelled:
+		entry.Status = calendar.StatusCancelled
+		entry.Sequence++
+		if b.UpdatedAt.After(entry.LastModified) {
+			entry.LastModified = b.UpdatedAt
+		}
+	case booking.BookingStatusPending:
+		entry.Status = calendar.StatusTentative
+	}
+
+	return entry
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
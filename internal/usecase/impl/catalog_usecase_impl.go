@@ -0,0 +1,221 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/internal/domain/catalog"
+
+	"github.com/google/uuid"
+)
+
+type catalogUsecaseImpl struct {
+	catalogRepo catalog.CatalogRepository
+}
+
+func NewCatalogUsecase(catalogRepo catalog.CatalogRepository) catalog.CatalogUsecase {
+	return &catalogUsecaseImpl{
+		catalogRepo: catalogRepo,
+	}
+}
+
+// Categories
+
+func (u *catalogUsecaseImpl) CreateCategory(ctx context.Context, category *catalog.Category) error {
+	if category.Name == "" {
+		return fmt.Errorf("validation failed: category name is required")
+	}
+	if category.Slug == "" {
+		category.Slug = catalog.Slugify(category.Name)
+	}
+
+	// The parent may be given by ID or slug; the ID is stored
+	if category.ParentID != nil {
+		parent, err := u.catalogRepo.GetCategory(ctx, *category.ParentID)
+		if err != nil {
+			return fmt.Errorf("parent category not found: %w", err)
+		}
+		category.ParentID = &parent.ID
+	}
+
+	category.ID = uuid.New().String()
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = time.Now()
+
+	return u.catalogRepo.CreateCategory(ctx, category)
+}
+
+func (u *catalogUsecaseImpl) UpdateCategory(ctx context.Context, category *catalog.Category) error {
+	existing, err := u.catalogRepo.GetCategory(ctx, category.ID)
+	if err != nil {
+		return fmt.Errorf("category not found: %w", err)
+	}
+
+	if category.Name == "" {
+		return fmt.Errorf("validation failed: category name is required")
+	}
+	if category.Slug == "" {
+		category.Slug = catalog.Slugify(category.Name)
+	}
+
+	// The category and its parent may be given by ID or slug; both are
+	// resolved to IDs before the tree is checked and stored
+	category.ID = existing.ID
+	if category.ParentID != nil {
+		parent, err := u.catalogRepo.GetCategory(ctx, *category.ParentID)
+		if err != nil {
+			return fmt.Errorf("parent category not found: %w", err)
+		}
+		category.ParentID = &parent.ID
+
+		// Moving a category under itself or one of its descendants would create a cycle
+		cycle, err := u.catalogRepo.IsDescendant(ctx, existing.ID, parent.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check category tree: %w", err)
+		}
+		if cycle {
+			return fmt.Errorf("validation failed: a category cannot be moved under itself or its subcategories")
+		}
+	}
+
+	category.CreatedAt = existing.CreatedAt
+	category.UpdatedAt = time.Now()
+
+	return u.catalogRepo.UpdateCategory(ctx, category)
+}
+
+func (u *catalogUsecaseImpl) DeleteCategory(ctx context.Context, id string) error {
+	return u.catalogRepo.DeleteCategory(ctx, id)
+}
+
+func (u *catalogUsecaseImpl) ListCategories(ctx context.Context) ([]*catalog.Category, error) {
+	return u.catalogRepo.ListCategories(ctx)
+}
+
+func (u *catalogUsecaseImpl) GetCategoryAnalytics(ctx context.Context, limit int) ([]*catalog.CategoryAnalytics, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	return u.catalogRepo.GetCategoryAnalytics(ctx, limit)
+}
+
+// Tags
+
+func (u *catalogUsecaseImpl) CreateTag(ctx context.Context, tag *catalog.Tag) error {
+	tag.Slug = catalog.Slugify(tag.Name)
+	if tag.Slug == "" {
+		return fmt.Errorf("validation failed: tag name is required")
+	}
+
+	tag.ID = uuid.New().String()
+	tag.CreatedAt = time.Now()
+
+	return u.catalogRepo.CreateTag(ctx, tag)
+}
+
+func (u *catalogUsecaseImpl) UpdateTag(ctx context.Context, tag *catalog.Tag) error {
+	tag.Slug = catalog.Slugify(tag.Name)
+	if tag.Slug == "" {
+		return fmt.Errorf("validation failed: tag name is required")
+	}
+
+	return u.catalogRepo.UpdateTag(ctx, tag)
+}
+
+func (u *catalogUsecaseImpl) DeleteTag(ctx context.Context, id string) error {
+	return u.catalogRepo.DeleteTag(ctx, id)
+}
+
+func (u *catalogUsecaseImpl) ListTags(ctx context.Context) ([]*catalog.Tag, error) {
+	return u.catalogRepo.ListTags(ctx)
+}
+
+// Collections
+
+func (u *catalogUsecaseImpl) CreateCollection(ctx context.Context, collection *catalog.Collection) error {
+	if err := validateCollection(collection); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	if collection.EventIDs == nil {
+		collection.EventIDs = []string{}
+	}
+
+	collection.ID = uuid.New().String()
+	collection.CreatedAt = time.Now()
+	collection.UpdatedAt = time.Now()
+
+	return u.catalogRepo.CreateCollection(ctx, collection)
+}
+
+func (u *catalogUsecaseImpl) UpdateCollection(ctx context.Context, collection *catalog.Collection) error {
+	existing, err := u.catalogRepo.GetCollection(ctx, collection.ID)
+	if err != nil {
+		return fmt.Errorf("collection not found: %w", err)
+	}
+
+	if err := validateCollection(collection); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	collection.CreatedBy = existing.CreatedBy
+	collection.CreatedAt = existing.CreatedAt
+	collection.UpdatedAt = time.Now()
+
+	return u.catalogRepo.UpdateCollection(ctx, collection)
+}
+
+func (u *catalogUsecaseImpl) DeleteCollection(ctx context.Context, id string) error {
+	return u.catalogRepo.DeleteCollection(ctx, id)
+}
+
+func (u *catalogUsecaseImpl) GetCollection(ctx context.Context, slug string) (*catalog.Collection, error) {
+	collection, err := u.catalogRepo.GetCollection(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.Events, err = u.catalogRepo.GetCollectionEvents(ctx, collection.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection events: %w", err)
+	}
+
+	return collection, nil
+}
+
+func (u *catalogUsecaseImpl) ListCollections(ctx context.Context, limit, offset int) ([]*catalog.Collection, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.catalogRepo.ListCollections(ctx, limit, offset)
+}
+
+func validateCollection(collection *catalog.Collection) error {
+	if collection.Title == "" {
+		return fmt.Errorf("collection title is required")
+	}
+
+	if collection.Slug == "" {
+		collection.Slug = catalog.Slugify(collection.Title)
+	} else {
+		collection.Slug = catalog.Slugify(collection.Slug)
+	}
+	if collection.Slug == "" {
+		return fmt.Errorf("collection slug is required")
+	}
+
+	seen := make(map[string]bool, len(collection.EventIDs))
+	for _, eventID := range collection.EventIDs {
+		if seen[eventID] {
+			return fmt.Errorf("event %s is listed twice", eventID)
+		}
+		seen[eventID] = true
+	}
+
+	return nil
+}
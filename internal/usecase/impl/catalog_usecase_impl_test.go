@@ -0,0 +1,105 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"evently/internal/domain/catalog"
+)
+
+type fakeCatalogRepo struct {
+	catalog.CatalogRepository
+	categories []*catalog.Category
+	updated    *catalog.Category
+}
+
+func (r *fakeCatalogRepo) GetCategory(ctx context.Context, idOrSlug string) (*catalog.Category, error) {
+	for _, category := range r.categories {
+		if category.ID == idOrSlug || category.Slug == idOrSlug {
+			return category, nil
+		}
+	}
+	return nil, errors.New("category not found")
+}
+
+// IsDescendant walks up from candidateID, so it only works with IDs like
+// the real query.
+func (r *fakeCatalogRepo) IsDescendant(ctx context.Context, categoryID, candidateID string) (bool, error) {
+	for id := &candidateID; id != nil; {
+		if *id == categoryID {
+			return true, nil
+		}
+		var parent *string
+		for _, category := range r.categories {
+			if category.ID == *id {
+				parent = category.ParentID
+			}
+		}
+		id = parent
+	}
+	return false, nil
+}
+
+func (r *fakeCatalogRepo) UpdateCategory(ctx context.Context, category *catalog.Category) error {
+	r.updated = category
+	return nil
+}
+
+func TestUpdateCategoryParent(t *testing.T) {
+	music, concerts := "cat-music", "cat-concerts"
+	newRepo := func() *fakeCatalogRepo {
+		return &fakeCatalogRepo{categories: []*catalog.Category{
+			{ID: music, Name: "Music", Slug: "music"},
+			{ID: concerts, Name: "Concerts", Slug: "concerts", ParentID: &music},
+			{ID: "cat-jazz", Name: "Jazz", Slug: "jazz", ParentID: &concerts},
+			{ID: "cat-talks", Name: "Talks", Slug: "talks"},
+		}}
+	}
+
+	tests := []struct {
+		name       string
+		category   string
+		parent     string
+		wantParent string
+		wantErr    string
+	}{
+		{name: "parent by slug", category: "cat-jazz", parent: "talks", wantParent: "cat-talks"},
+		{name: "both by slug", category: "jazz", parent: "music", wantParent: "cat-music"},
+		{name: "parent by id", category: "talks", parent: "cat-music", wantParent: "cat-music"},
+		{name: "under its subcategory by slug", category: "music", parent: "jazz", wantErr: "validation failed"},
+		{name: "under itself by slug", category: "cat-concerts", parent: "concerts", wantErr: "validation failed"},
+		{name: "unknown parent", category: "jazz", parent: "theatre", wantErr: "parent category not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepo()
+			u := NewCatalogUsecase(repo)
+
+			parent := tt.parent
+			err := u.UpdateCategory(context.Background(), &catalog.Category{ID: tt.category, Name: "Moved", Slug: "moved", ParentID: &parent})
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Fatalf("UpdateCategory error = %v, want %q", err, tt.wantErr)
+				}
+				if repo.updated != nil {
+					t.Errorf("category was updated: %+v", repo.updated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateCategory failed: %v", err)
+			}
+
+			existing, _ := repo.GetCategory(context.Background(), tt.category)
+			if repo.updated == nil || repo.updated.ID != existing.ID {
+				t.Fatalf("updated %+v, want category %s", repo.updated, existing.ID)
+			}
+			if repo.updated.ParentID == nil || *repo.updated.ParentID != tt.wantParent {
+				t.Errorf("parent = %v, want %s", repo.updated.ParentID, tt.wantParent)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package impl
+
+import (
+	"context"
+	"crypto/ecdh"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"evently/internal/domain/channel"
+	"evently/internal/domain/dispatch"
+	"evently/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// planBatchSize is how many notifications are planned per transaction.
+	planBatchSize = 100
+	// sendBatchSize is how many deliveries a worker claims at a time, and
+	// sendConcurrency how many of them it sends at once.
+	sendBatchSize   = 50
+	sendConcurrency = 4
+	// sendTimeout bounds a single send. Claims outlast a whole batch sent
+	// sendConcurrency at a time, plus claimMargin for loading notifications
+	// and recording outcomes, so a delivery is only taken over once its
+	// worker is surely gone.
+	sendTimeout = 30 * time.Second
+	claimMargin = 2 * time.Minute
+	claimLease  = (sendBatchSize+sendConcurrency-1)/sendConcurrency*sendTimeout + claimMargin
+	// maxRetryDelay caps the exponential backoff.
+	maxRetryDelay = 6 * time.Hour
+)
+
+type dispatchUsecaseImpl struct {
+	deliveryRepo     dispatch.DeliveryRepository
+	pushRepo         dispatch.PushSubscriptionRepository
+	notificationRepo model.NotificationRepository
+	channels         map[string]channel.Channel
+	channelNames     []string
+	cfg              model.DeliveryConfig
+	vapidPublicKey   string
+}
+
+// NewDispatchUsecase delivers notifications through channels, skipping the
+// nil ones of channels that are not configured. vapidPublicKey is empty
+// when web push is off.
+func NewDispatchUsecase(deliveryRepo dispatch.DeliveryRepository, pushRepo dispatch.PushSubscriptionRepository, notificationRepo model.NotificationRepository, channels []channel.Channel, cfg model.DeliveryConfig, vapidPublicKey string) dispatch.DispatchUsecase {
+	u := &dispatchUsecaseImpl{
+		deliveryRepo:     deliveryRepo,
+		pushRepo:         pushRepo,
+		notificationRepo: notificationRepo,
+		channels:         map[string]channel.Channel{},
+		cfg:              cfg,
+		vapidPublicKey:   vapidPublicKey,
+	}
+	for _, ch := range channels {
+		if ch != nil {
+			u.channels[ch.Name()] = ch
+			u.channelNames = append(u.channelNames, ch.Name())
+		}
+	}
+
+	return u
+}
+
+func (u *dispatchUsecaseImpl) Dispatch(ctx context.Context) (int, error) {
+	for {
+		planned, err := u.deliveryRepo.Plan(ctx, u.channelNames, time.Now(), planBatchSize)
+		if err != nil {
+			return 0, fmt.Errorf("failed to plan deliveries: %w", err)
+		}
+		if planned < planBatchSize {
+			break
+		}
+	}
+
+	sent := 0
+	for ctx.Err() == nil {
+		now := time.Now()
+		deliveries, err := u.deliveryRepo.Claim(ctx, now, now.Add(claimLease), sendBatchSize)
+		if err != nil {
+			return sent, fmt.Errorf("failed to claim deliveries: %w", err)
+		}
+
+		sent += u.sendAll(ctx, deliveries)
+		if len(deliveries) < sendBatchSize {
+			break
+		}
+	}
+
+	return sent, nil
+}
+
+// sendAll sends deliveries a few at a time and returns how many were sent.
+func (u *dispatchUsecaseImpl) sendAll(ctx context.Context, deliveries []*dispatch.Delivery) int {
+	var wg sync.WaitGroup
+	var sent atomic.Int64
+	slots := make(chan struct{}, sendConcurrency)
+
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if u.send(ctx, delivery) {
+				sent.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return int(sent.Load())
+}
+
+// send makes one attempt at a claimed delivery and records the outcome.
+func (u *dispatchUsecaseImpl) send(ctx context.Context, delivery *dispatch.Delivery) bool {
+	sendErr := u.deliver(ctx, delivery)
+
+	// The outcome is recorded even when shutdown cancelled the send, so
+	// the delivery need not wait for its claim to run out
+	ctx = context.WithoutCancel(ctx)
+	now := time.Now()
+
+	if sendErr == nil {
+		if err := u.deliveryRepo.MarkSent(ctx, delivery.ID, now); err != nil {
+			log.Printf("failed to mark delivery %s as sent: %v", delivery.ID, err)
+		}
+		return true
+	}
+
+	var retryAt *time.Time
+	if !errors.Is(sendErr, channel.ErrUndeliverable) && delivery.Attempts < u.cfg.MaxAttempts {
+		at := now.Add(u.retryDelay(delivery.Attempts))
+		retryAt = &at
+	} else {
+		log.Printf("giving up on %s delivery %s after %d attempts: %v", delivery.Channel, delivery.ID, delivery.Attempts, sendErr)
+	}
+
+	if err := u.deliveryRepo.MarkFailed(ctx, delivery.ID, sendErr.Error(), retryAt, now); err != nil {
+		log.Printf("failed to record failed delivery %s: %v", delivery.ID, err)
+	}
+	return false
+}
+
+func (u *dispatchUsecaseImpl) deliver(ctx context.Context, delivery *dispatch.Delivery) error {
+	ch, ok := u.channels[delivery.Channel]
+	if !ok {
+		// Stays retryable in case the channel is configured again
+		return fmt.Errorf("channel %s is not configured", delivery.Channel)
+	}
+
+	notification, err := u.notificationRepo.GetByID(delivery.NotificationID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return fmt.Errorf("%w: %v", channel.ErrUndeliverable, err)
+		}
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	return ch.Send(ctx, &channel.Message{
+		To:      delivery.Recipient,
+		Subject: notification.Title,
+		Body:    notification.Message,
+	})
+}
+
+// retryDelay doubles the configured backoff with every attempt made, with
+// some jitter so that deliveries failed by one outage do not all come back
+// at once.
+func (u *dispatchUsecaseImpl) retryDelay(attempts int) time.Duration {
+	delay := u.cfg.RetryBackoff
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxRetryDelay)
+
+	return delay + rand.N(delay/4+1)
+}
+
+func (u *dispatchUsecaseImpl) ListDeliveries(ctx context.Context, filter dispatch.DeliveryFilter) ([]*dispatch.Delivery, int, error) {
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 50
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	statuses := []string{dispatch.StatusPending, dispatch.StatusSending, dispatch.StatusSent, dispatch.StatusDead}
+	if filter.Status != "" && !slices.Contains(statuses, filter.Status) {
+		return nil, 0, fmt.Errorf("validation failed: status must be one of %s", strings.Join(statuses, ", "))
+	}
+	channels := []string{channel.Email, channel.SMS, channel.WebPush}
+	if filter.Channel != "" && !slices.Contains(channels, filter.Channel) {
+		return nil, 0, fmt.Errorf("validation failed: channel must be one of %s", strings.Join(channels, ", "))
+	}
+
+	return u.deliveryRepo.List(ctx, filter)
+}
+
+func (u *dispatchUsecaseImpl) RetryDelivery(ctx context.Context, id string) (*dispatch.Delivery, error) {
+	return u.deliveryRepo.Requeue(ctx, id, time.Now())
+}
+
+func (u *dispatchUsecaseImpl) VAPIDPublicKey() string {
+	return u.vapidPublicKey
+}
+
+func (u *dispatchUsecaseImpl) SubscribePush(ctx context.Context, userID string, req *dispatch.PushSubscriptionRequest) (*dispatch.PushSubscription, error) {
+	if u.vapidPublicKey == "" {
+		return nil, fmt.Errorf("validation failed: web push is not enabled")
+	}
+
+	endpoint, err := url.Parse(req.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" || len(req.Endpoint) > 2048 {
+		return nil, fmt.Errorf("validation failed: endpoint must be an https URL")
+	}
+	if !validPushKeys(req.Keys.P256dh, req.Keys.Auth) {
+		return nil, fmt.Errorf("validation failed: keys.p256dh and keys.auth must be the keys of the browser's subscription")
+	}
+
+	return u.pushRepo.Save(ctx, &dispatch.PushSubscription{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Endpoint:  req.Endpoint,
+		P256dh:    req.Keys.P256dh,
+		Auth:      req.Keys.Auth,
+		CreatedAt: time.Now(),
+	})
+}
+
+// validPushKeys reports whether p256dh is a P-256 public key and auth a
+// 16 byte secret, both base64url encoded.
+func validPushKeys(p256dh, auth string) bool {
+	public, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(p256dh, "="))
+	if err != nil {
+		return false
+	}
+	if _, err := ecdh.P256().NewPublicKey(public); err != nil {
+		return false
+	}
+	secret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(auth, "="))
+	return err == nil && len(secret) == 16
+}
+
+func (u *dispatchUsecaseImpl) ListPushSubscriptions(ctx context.Context, userID string) ([]*dispatch.PushSubscription, error) {
+	return u.pushRepo.ListByUser(ctx, userID)
+}
+
+func (u *dispatchUsecaseImpl) UnsubscribePush(ctx context.Context, userID, id string) error {
+	return u.pushRepo.Delete(ctx, id, userID)
+}
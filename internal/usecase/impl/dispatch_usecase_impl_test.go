@@ -0,0 +1,216 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"evently/internal/domain/channel"
+	"evently/internal/domain/dispatch"
+	"evently/internal/domain/model"
+)
+
+func TestClaimLease(t *testing.T) {
+	// Every round of sendConcurrency sends may take sendTimeout
+	rounds := (sendBatchSize + sendConcurrency - 1) / sendConcurrency
+	if batch := time.Duration(rounds) * sendTimeout; claimLease <= batch {
+		t.Errorf("claimLease %s does not outlast a batch of %s", claimLease, batch)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	u := &dispatchUsecaseImpl{cfg: model.DeliveryConfig{RetryBackoff: time.Minute}}
+
+	tests := []struct {
+		attempts int
+		base     time.Duration
+	}{
+		{attempts: 0, base: time.Minute},
+		{attempts: 1, base: time.Minute},
+		{attempts: 2, base: 2 * time.Minute},
+		{attempts: 3, base: 4 * time.Minute},
+		{attempts: 9, base: 256 * time.Minute},
+		{attempts: 10, base: maxRetryDelay},
+		{attempts: 1000, base: maxRetryDelay},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.attempts), func(t *testing.T) {
+			// Jitter adds up to a quarter
+			for i := 0; i < 100; i++ {
+				delay := u.retryDelay(tt.attempts)
+				if delay < tt.base || delay > tt.base+tt.base/4 {
+					t.Fatalf("retryDelay(%d) = %s, want %s plus up to a quarter", tt.attempts, delay, tt.base)
+				}
+			}
+		})
+	}
+}
+
+type fakeChannel struct {
+	name string
+	err  error
+
+	mu   sync.Mutex
+	sent []*channel.Message
+}
+
+func (c *fakeChannel) Name() string {
+	return c.name
+}
+
+func (c *fakeChannel) Send(ctx context.Context, msg *channel.Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, msg)
+	return c.err
+}
+
+// deliveryOutcome is what the worker recorded for a delivery.
+type deliveryOutcome struct {
+	sent      bool
+	lastError string
+	retryAt   *time.Time
+	at        time.Time
+}
+
+type fakeDeliveryRepo struct {
+	dispatch.DeliveryRepository
+	due []*dispatch.Delivery
+
+	mu          sync.Mutex
+	lockedUntil time.Duration
+	outcomes    map[string]deliveryOutcome
+}
+
+func (r *fakeDeliveryRepo) Plan(ctx context.Context, channels []string, now time.Time, limit int) (int, error) {
+	return 0, nil
+}
+
+func (r *fakeDeliveryRepo) Claim(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*dispatch.Delivery, error) {
+	r.lockedUntil = lockedUntil.Sub(now)
+	claimed := r.due[:min(limit, len(r.due))]
+	r.due = r.due[len(claimed):]
+	for _, delivery := range claimed {
+		delivery.Attempts++
+	}
+	return claimed, nil
+}
+
+func (r *fakeDeliveryRepo) MarkSent(ctx context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.outcomes[id] = deliveryOutcome{sent: true, at: at}
+	return nil
+}
+
+func (r *fakeDeliveryRepo) MarkFailed(ctx context.Context, id, lastError string, retryAt *time.Time, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.outcomes[id] = deliveryOutcome{lastError: lastError, retryAt: retryAt, at: at}
+	return nil
+}
+
+func TestDispatchOutcomes(t *testing.T) {
+	const backoff = time.Minute
+	unreachable := fmt.Errorf("%w: 550 no such user", channel.ErrUndeliverable)
+
+	tests := []struct {
+		name           string
+		channel        *fakeChannel
+		deliveryTo     string
+		attemptsBefore int
+		notification   string
+		wantSent       bool
+		wantRetryAfter time.Duration
+		wantDead       bool
+	}{
+		{name: "sent", channel: &fakeChannel{name: channel.Email}, notification: "n1", wantSent: true},
+		{name: "first failure", channel: &fakeChannel{name: channel.Email, err: errors.New("timeout")}, notification: "n1", wantRetryAfter: backoff},
+		{name: "second failure", channel: &fakeChannel{name: channel.Email, err: errors.New("timeout")}, attemptsBefore: 1, notification: "n1", wantRetryAfter: 2 * backoff},
+		{name: "last attempt", channel: &fakeChannel{name: channel.Email, err: errors.New("timeout")}, attemptsBefore: 2, notification: "n1", wantDead: true},
+		{name: "undeliverable", channel: &fakeChannel{name: channel.Email, err: unreachable}, notification: "n1", wantDead: true},
+		{name: "notification deleted", channel: &fakeChannel{name: channel.Email}, notification: "gone", wantDead: true},
+		{name: "channel not configured", channel: &fakeChannel{name: channel.SMS}, notification: "n1", wantRetryAfter: backoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deliveries := &fakeDeliveryRepo{
+				due: []*dispatch.Delivery{{
+					ID:             "d1",
+					NotificationID: tt.notification,
+					Channel:        channel.Email,
+					Recipient:      "guest@example.com",
+					Attempts:       tt.attemptsBefore,
+				}},
+				outcomes: map[string]deliveryOutcome{},
+			}
+			notifications := &fakeNotificationRepo{notifications: []*model.Notification{{ID: "n1", Title: "Booked", Message: "See you"}}}
+			cfg := model.DeliveryConfig{MaxAttempts: 3, RetryBackoff: backoff}
+			u := NewDispatchUsecase(deliveries, nil, notifications, []channel.Channel{tt.channel, nil}, cfg, "")
+
+			sent, err := u.Dispatch(context.Background())
+			if err != nil {
+				t.Fatalf("Dispatch failed: %v", err)
+			}
+			if deliveries.lockedUntil != claimLease {
+				t.Errorf("claimed for %s, want %s", deliveries.lockedUntil, claimLease)
+			}
+			if (sent == 1) != tt.wantSent {
+				t.Errorf("sent %d", sent)
+			}
+
+			outcome, ok := deliveries.outcomes["d1"]
+			switch {
+			case !ok:
+				t.Fatal("no outcome was recorded")
+			case tt.wantSent:
+				if !outcome.sent {
+					t.Errorf("delivery was not marked sent: %+v", outcome)
+				}
+				if len(tt.channel.sent) != 1 || tt.channel.sent[0].To != "guest@example.com" || tt.channel.sent[0].Subject != "Booked" {
+					t.Errorf("channel sent %+v", tt.channel.sent)
+				}
+			case tt.wantDead:
+				if outcome.sent || outcome.retryAt != nil || outcome.lastError == "" {
+					t.Errorf("delivery is not dead: %+v", outcome)
+				}
+			default:
+				if outcome.sent || outcome.retryAt == nil {
+					t.Fatalf("delivery is not retried: %+v", outcome)
+				}
+				wait := outcome.retryAt.Sub(outcome.at)
+				if wait < tt.wantRetryAfter || wait > tt.wantRetryAfter+tt.wantRetryAfter/4 {
+					t.Errorf("retried after %s, want %s plus jitter", wait, tt.wantRetryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestDispatchBatches(t *testing.T) {
+	deliveries := &fakeDeliveryRepo{outcomes: map[string]deliveryOutcome{}}
+	for i := 0; i < sendBatchSize+3; i++ {
+		deliveries.due = append(deliveries.due, &dispatch.Delivery{
+			ID:             fmt.Sprintf("d%d", i),
+			NotificationID: "n1",
+			Channel:        channel.Email,
+			Recipient:      "guest@example.com",
+		})
+	}
+	notifications := &fakeNotificationRepo{notifications: []*model.Notification{{ID: "n1"}}}
+	email := &fakeChannel{name: channel.Email}
+	u := NewDispatchUsecase(deliveries, nil, notifications, []channel.Channel{email}, model.DeliveryConfig{MaxAttempts: 3, RetryBackoff: time.Minute}, "")
+
+	sent, err := u.Dispatch(context.Background())
+	if err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if sent != sendBatchSize+3 || len(deliveries.outcomes) != sendBatchSize+3 {
+		t.Errorf("sent %d and recorded %d, want %d", sent, len(deliveries.outcomes), sendBatchSize+3)
+	}
+}
@@ -3,32 +3,33 @@ package impl
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"evently/internal/domain/events"
+	"evently/internal/domain/venue"
+	"evently/internal/domain/waitlist"
 
 	"github.com/google/uuid"
 )
 
 type eventUsecaseImpl struct {
-	eventRepo events.EventRepository
+	eventRepo       events.EventRepository
+	venueRepo       venue.VenueRepository
+	waitlistUsecase waitlist.WaitlistUsecase
 }
 
-func NewEventUsecase(eventRepo events.EventRepository) events.EventUsecase {
+func NewEventUsecase(eventRepo events.EventRepository, venueRepo venue.VenueRepository, waitlistUsecase waitlist.WaitlistUsecase) events.EventUsecase {
 	return &eventUsecaseImpl{
-		eventRepo: eventRepo,
+		eventRepo:       eventRepo,
+		venueRepo:       venueRepo,
+		waitlistUsecase: waitlistUsecase,
 	}
 }
 
 func (u *eventUsecaseImpl) CreateEvent(ctx context.Context, event *events.Event) error {
-	event.ID = uuid.New().String()
-	event.CreatedAt = time.Now()
-	event.UpdatedAt = time.Now()
-
-	event.AvailableSeats = event.TotalCapacity
-
-	if err := u.validateEvent(event); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+	if err := prepareNewEvent(ctx, u.venueRepo, event); err != nil {
+		return err
 	}
 
 	return u.eventRepo.Create(event)
@@ -42,15 +43,45 @@ func (u *eventUsecaseImpl) UpdateEvent(ctx context.Context, event *events.Event)
 
 	event.CreatedAt = existingEvent.CreatedAt
 	event.CreatedBy = existingEvent.CreatedBy
-	event.AvailableSeats = event.TotalCapacity - existingEvent.TotalCapacity + existingEvent.AvailableSeats
-	event.CreatedBy = existingEvent.CreatedBy
-	event.UpdatedAt = time.Now()
 
-	if err := u.validateEvent(event); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+	// The venue fields the request changed win over those it left as read,
+	// so a new venue name is not overridden by the venue_id sent back with
+	// it. Leaving out both keeps the venue.
+	switch {
+	case event.Venue == "" && (event.VenueID == nil || *event.VenueID == ""):
+		event.VenueID = existingEvent.VenueID
+	case event.Venue != "" && event.Venue != existingEvent.Venue && sameID(event.VenueID, existingEvent.VenueID):
+		event.VenueID = nil
+	}
+
+	// Like tags, a missing category_id keeps the category and an empty one
+	// clears it
+	if event.CategoryID == nil {
+		event.CategoryID = existingEvent.CategoryID
+	} else if *event.CategoryID == "" {
+		event.CategoryID = nil
+	}
+
+	if err := prepareEventUpdate(ctx, u.venueRepo, event); err != nil {
+		return err
+	}
+
+	// Available seats are recomputed against the locked row so that bookings
+	// made since existingEvent was read are taken into account.
+	freedSeats, err := u.eventRepo.UpdateWithCapacity(ctx, event)
+	if err != nil {
+		return err
 	}
 
-	return u.eventRepo.Update(event)
+	// Offer the newly available seats to the waitlist
+	if freedSeats > 0 && u.waitlistUsecase != nil {
+		if err := u.waitlistUsecase.ProcessWaitlistNotifications(ctx, event.ID, freedSeats); err != nil {
+			// Log error but don't fail the update
+			log.Printf("Failed to process waitlist notifications: %v", err)
+		}
+	}
+
+	return nil
 }
 
 func (u *eventUsecaseImpl) DeleteEvent(ctx context.Context, eventID string) error {
@@ -66,7 +97,7 @@ func (u *eventUsecaseImpl) GetEvent(ctx context.Context, eventID string) (*event
 	return u.eventRepo.GetByID(eventID)
 }
 
-func (u *eventUsecaseImpl) ListUpcomingEvents(ctx context.Context, limit, offset int) ([]*events.Event, error) {
+func (u *eventUsecaseImpl) ListUpcomingEvents(ctx context.Context, filter events.EventFilter, limit, offset int) ([]*events.Event, error) {
 	if limit <= 0 {
 		limit = 10
 	}
@@ -74,7 +105,7 @@ func (u *eventUsecaseImpl) ListUpcomingEvents(ctx context.Context, limit, offset
 		offset = 0
 	}
 
-	return u.eventRepo.ListUpcoming(limit, offset)
+	return u.eventRepo.ListUpcoming(filter, limit, offset)
 }
 
 func (u *eventUsecaseImpl) ListAllEvents(ctx context.Context, limit, offset int) ([]*events.Event, error) {
@@ -88,6 +119,17 @@ func (u *eventUsecaseImpl) ListAllEvents(ctx context.Context, limit, offset int)
 	return u.eventRepo.ListAll(limit, offset)
 }
 
+func (u *eventUsecaseImpl) ListManagedEvents(ctx context.Context, userID string, limit, offset int) ([]*events.Event, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.eventRepo.ListManaged(ctx, userID, limit, offset)
+}
+
 func (u *eventUsecaseImpl) GetMostPopularEvents(ctx context.Context, limit int) ([]*events.EventAnalytics, error) {
 	if limit <= 0 {
 		limit = 10
@@ -96,7 +138,151 @@ func (u *eventUsecaseImpl) GetMostPopularEvents(ctx context.Context, limit int)
 	return u.eventRepo.GetMostPopularEvents(ctx, limit)
 }
 
-func (u *eventUsecaseImpl) validateEvent(event *events.Event) error {
+func (u *eventUsecaseImpl) GetMostPopularManagedEvents(ctx context.Context, userID string, limit int) ([]*events.EventAnalytics, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	return u.eventRepo.GetMostPopularManagedEvents(ctx, userID, limit)
+}
+
+func (u *eventUsecaseImpl) SearchEvents(ctx context.Context, params *events.SearchParams) (*events.SearchResult, error) {
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+	if params.Offset < 0 {
+		params.Offset = 0
+	}
+
+	// Like ListUpcomingEvents, search only covers upcoming events unless a
+	// start of the date range is given
+	if params.From == nil {
+		now := time.Now()
+		params.From = &now
+	}
+
+	switch params.Sort {
+	case "":
+		params.Sort = events.SearchSortDate
+		if params.Query != "" {
+			params.Sort = events.SearchSortRelevance
+		}
+	case events.SearchSortRelevance, events.SearchSortDate, events.SearchSortDateDesc,
+		events.SearchSortPrice, events.SearchSortPriceDesc, events.SearchSortPopularity:
+	default:
+		return nil, fmt.Errorf("invalid sort: must be one of relevance, date, -date, price, -price, popularity")
+	}
+
+	switch params.Availability {
+	case "", events.AvailabilityHasSeats, events.AvailabilitySoldOut:
+	default:
+		return nil, fmt.Errorf("invalid availability: must be 'available' or 'sold_out'")
+	}
+
+	if params.MinPrice != nil && params.MaxPrice != nil && *params.MinPrice > *params.MaxPrice {
+		return nil, fmt.Errorf("min_price cannot be greater than max_price")
+	}
+
+	return u.eventRepo.Search(ctx, params)
+}
+
+func (u *eventUsecaseImpl) CreateSession(ctx context.Context, parentEventID string, session *events.Event) error {
+	parent, err := u.eventRepo.GetByID(parentEventID)
+	if err != nil {
+		return fmt.Errorf("event not found: %w", err)
+	}
+
+	if parent.ParentEventID != nil {
+		return fmt.Errorf("validation failed: sessions cannot be nested")
+	}
+
+	if err := prepareNewEvent(ctx, u.venueRepo, session); err != nil {
+		return err
+	}
+	session.ParentEventID = &parent.ID
+
+	return u.eventRepo.Create(session)
+}
+
+func (u *eventUsecaseImpl) ListSessions(ctx context.Context, parentEventID string) ([]*events.Event, error) {
+	return u.eventRepo.ListSessions(ctx, parentEventID)
+}
+
+func (u *eventUsecaseImpl) GetSessionUtilization(ctx context.Context, parentEventID string) ([]*events.SessionUtilization, error) {
+	return u.eventRepo.GetSessionUtilization(ctx, parentEventID)
+}
+
+// prepareNewEvent assigns the ID of a new event and validates it before it
+// is stored. The links to a parent event or series are cleared, as clients
+// may send them: callers that create sessions or occurrences set them
+// afterwards.
+func prepareNewEvent(ctx context.Context, venueRepo venue.VenueRepository, event *events.Event) error {
+	event.ID = uuid.New().String()
+	event.CreatedAt = time.Now()
+	event.UpdatedAt = time.Now()
+	event.ParentEventID = nil
+	event.SeriesID = nil
+	event.Sequence = 0
+
+	event.AvailableSeats = event.TotalCapacity
+	if event.CategoryID != nil && *event.CategoryID == "" {
+		event.CategoryID = nil
+	}
+
+	if err := applyVenue(ctx, venueRepo, event); err != nil {
+		return err
+	}
+
+	if err := validateEvent(event); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	event.Localize()
+
+	return nil
+}
+
+// prepareEventUpdate validates an edited event before it is stored.
+func prepareEventUpdate(ctx context.Context, venueRepo venue.VenueRepository, event *events.Event) error {
+	event.UpdatedAt = time.Now()
+
+	if err := applyVenue(ctx, venueRepo, event); err != nil {
+		return err
+	}
+
+	if err := validateEvent(event); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	event.Localize()
+
+	return nil
+}
+
+// sameID reports whether an optional ID is set and equal to stored.
+func sameID(id, stored *string) bool {
+	return id != nil && stored != nil && *id == *stored
+}
+
+// applyVenue links the event to its venue and stores the venue's canonical
+// name, so free-text spellings cannot drift apart again.
+func applyVenue(ctx context.Context, venueRepo venue.VenueRepository, event *events.Event) error {
+	v, err := resolveVenue(ctx, venueRepo, event.VenueID, event.Venue, event.TotalCapacity)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	event.VenueID = &v.ID
+	event.Venue = v.Name
+	if event.Timezone == "" {
+		event.Timezone = v.Timezone
+	}
+
+	return nil
+}
+
+func validateEvent(event *events.Event) error {
 	if event.Name == "" {
 		return fmt.Errorf("event name is required")
 	}
@@ -105,10 +291,14 @@ func (u *eventUsecaseImpl) validateEvent(event *events.Event) error {
 		return fmt.Errorf("event venue is required")
 	}
 
-	if event.EventTime.Before(time.Now()) {
+	if event.HasStarted(time.Now()) {
 		return fmt.Errorf("event time must be in the future")
 	}
 
+	if _, err := time.LoadLocation(event.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", event.Timezone)
+	}
+
 	if event.TotalCapacity <= 0 {
 		return fmt.Errorf("event capacity must be positive")
 	}
@@ -117,5 +307,9 @@ func (u *eventUsecaseImpl) validateEvent(event *events.Event) error {
 		return fmt.Errorf("event price cannot be negative")
 	}
 
+	if err := validateSaleWindow(event.OnSaleAt, event.OffSaleAt); err != nil {
+		return err
+	}
+
 	return nil
 }
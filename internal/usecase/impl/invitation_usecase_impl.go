@@ -0,0 +1,152 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"evently/internal/delivery/http/middleware"
+	"evently/internal/domain/invitation"
+	"evently/internal/domain/model"
+	"evently/internal/domain/rbac"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type invitationUsecaseImpl struct {
+	invitationRepo invitation.InvitationRepository
+	userRepo       model.UserRepository
+	roleRepo       rbac.RoleRepository
+	tokens         *middleware.JWTConfig
+}
+
+func NewInvitationUsecase(invitationRepo invitation.InvitationRepository, userRepo model.UserRepository, roleRepo rbac.RoleRepository, tokens *middleware.JWTConfig) invitation.InvitationUsecase {
+	return &invitationUsecaseImpl{
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		tokens:         tokens,
+	}
+}
+
+func (u *invitationUsecaseImpl) CreateInvitation(ctx context.Context, req *invitation.CreateRequest, invitedBy string) (*invitation.Invitation, error) {
+	email := strings.TrimSpace(req.Email)
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, fmt.Errorf("validation failed: a valid email is required")
+	}
+
+	if _, err := u.roleRepo.GetRole(ctx, req.Role); err != nil {
+		return nil, fmt.Errorf("validation failed: unknown role %q", req.Role)
+	}
+
+	if existing, _ := u.userRepo.GetByEmail(email); existing != nil {
+		return nil, fmt.Errorf("validation failed: user with email %s already exists", email)
+	}
+
+	ttl := invitation.DefaultTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	if ttl > invitation.MaxTTL {
+		return nil, fmt.Errorf("validation failed: invitations expire after at most %d hours", int(invitation.MaxTTL.Hours()))
+	}
+
+	now := time.Now()
+	inv := &invitation.Invitation{
+		ID:        uuid.New().String(),
+		Email:     email,
+		Role:      req.Role,
+		InvitedBy: &invitedBy,
+		Status:    invitation.StatusPending,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+
+	token, err := u.tokens.GenerateInvitationToken(inv.ID, inv.Email, inv.Role, inv.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign invitation: %w", err)
+	}
+
+	if err := u.invitationRepo.Create(ctx, inv); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+	inv.Token = token
+
+	return inv, nil
+}
+
+func (u *invitationUsecaseImpl) ListInvitations(ctx context.Context, status invitation.Status, limit, offset int) ([]*invitation.Invitation, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	now := time.Now()
+	invitations, err := u.invitationRepo.List(ctx, status, now, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, inv := range invitations {
+		if inv.Status == invitation.StatusPending && !inv.ExpiresAt.After(now) {
+			inv.Status = invitation.StatusExpired
+		}
+	}
+
+	return invitations, nil
+}
+
+func (u *invitationUsecaseImpl) RevokeInvitation(ctx context.Context, id string) error {
+	return u.invitationRepo.Revoke(ctx, id, time.Now())
+}
+
+// AcceptInvitation creates the invited account. The token proves the
+// invitation was issued by us and has not expired; the stored invitation
+// decides whether it was revoked or already used.
+func (u *invitationUsecaseImpl) AcceptInvitation(ctx context.Context, req *invitation.AcceptRequest) (*model.User, error) {
+	claims, err := u.tokens.ParseInvitationToken(req.Token)
+	if err != nil {
+		return nil, fmt.Errorf("invitation is no longer valid: %w", err)
+	}
+
+	inv, err := u.invitationRepo.GetByID(ctx, claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invitation is no longer valid: %w", err)
+	}
+	if inv.Status != invitation.StatusPending || inv.Email != claims.Email || inv.Role != claims.Role {
+		return nil, fmt.Errorf("invitation is no longer valid")
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("validation failed: name is required")
+	}
+
+	if existing, _ := u.userRepo.GetByEmail(inv.Email); existing != nil {
+		return nil, fmt.Errorf("validation failed: user with email %s already exists", inv.Email)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	now := time.Now()
+	user := &model.User{
+		ID:        uuid.New().String(),
+		Name:      strings.TrimSpace(req.Name),
+		Email:     inv.Email,
+		Password:  string(hashedPassword),
+		Role:      inv.Role,
+		CreatedAt: now,
+	}
+
+	if err := u.invitationRepo.Accept(ctx, inv.ID, user, now); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
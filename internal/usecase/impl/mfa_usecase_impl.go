@@ -0,0 +1,228 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"evently/internal/domain/audit"
+	"evently/internal/domain/auth"
+	"evently/internal/domain/mfa"
+	"evently/internal/domain/model"
+	"evently/internal/domain/rbac"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type mfaUsecaseImpl struct {
+	mfaRepo          mfa.MFARepository
+	userRepo         model.UserRepository
+	roleRepo         rbac.RoleRepository
+	loginAttemptRepo auth.LoginAttemptRepository
+	auditRepo        audit.AuditRepository
+	config           *model.Config
+}
+
+func NewMFAUsecase(mfaRepo mfa.MFARepository, userRepo model.UserRepository, roleRepo rbac.RoleRepository, loginAttemptRepo auth.LoginAttemptRepository, auditRepo audit.AuditRepository, config *model.Config) mfa.MFAUsecase {
+	return &mfaUsecaseImpl{
+		mfaRepo:          mfaRepo,
+		userRepo:         userRepo,
+		roleRepo:         roleRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		auditRepo:        auditRepo,
+		config:           config,
+	}
+}
+
+func (u *mfaUsecaseImpl) GetStatus(ctx context.Context, userID string) (*mfa.Status, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	required, err := u.required(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	status := &mfa.Status{Required: required}
+
+	enrollment, err := u.mfaRepo.Get(ctx, userID)
+	if err != nil || enrollment.EnabledAt == nil {
+		return status, nil
+	}
+
+	status.Enabled = true
+	status.EnabledAt = enrollment.EnabledAt
+	status.RecoveryCodesRemaining, err = u.mfaRepo.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return status, nil
+}
+
+func (u *mfaUsecaseImpl) Setup(ctx context.Context, userID string) (*mfa.Setup, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	err = u.mfaRepo.SavePending(ctx, &mfa.Enrollment{
+		UserID:    user.ID,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mfa.Setup{Secret: secret, OTPAuthURI: totpURI(user.Email, secret)}, nil
+}
+
+func (u *mfaUsecaseImpl) Enable(ctx context.Context, userID, code string) ([]string, error) {
+	enrollment, err := u.mfaRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: start the two-factor setup first")
+	}
+	if enrollment.EnabledAt != nil {
+		return nil, fmt.Errorf("validation failed: two-factor authentication is already enabled")
+	}
+
+	now := time.Now()
+	step, ok := verifyTOTP(enrollment.Secret, strings.TrimSpace(code), now)
+	if !ok {
+		return nil, auth.ErrInvalidMFACode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %w", err)
+	}
+
+	if err := u.mfaRepo.Enable(ctx, userID, step, now, hashes); err != nil {
+		return nil, err
+	}
+
+	if err := u.audit(ctx, userID, audit.ActionMFAEnabled, "", now); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (u *mfaUsecaseImpl) Disable(ctx context.Context, userID, password, code, ip string) error {
+	user, err := u.reauthenticate(ctx, userID, password, code, ip)
+	if err != nil {
+		return err
+	}
+
+	required, err := u.required(ctx, user)
+	if err != nil {
+		return err
+	}
+	if required {
+		return fmt.Errorf("validation failed: two-factor authentication is required for your role")
+	}
+
+	if err := u.mfaRepo.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	return u.audit(ctx, userID, audit.ActionMFADisabled, ip, time.Now())
+}
+
+func (u *mfaUsecaseImpl) RegenerateRecoveryCodes(ctx context.Context, userID, password, code, ip string) ([]string, error) {
+	if _, err := u.reauthenticate(ctx, userID, password, code, ip); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %w", err)
+	}
+
+	if err := u.mfaRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	if err := u.audit(ctx, userID, audit.ActionMFARecoveryCodesRegenerated, ip, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// reauthenticate checks the password and a second factor again before
+// weakening the account. Failures count like failed logins.
+func (u *mfaUsecaseImpl) reauthenticate(ctx context.Context, userID, password, code, ip string) (*model.User, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	now := time.Now()
+	key := strings.ToLower(strings.TrimSpace(user.Email))
+	enrollment, err := u.mfaRepo.Get(ctx, userID)
+	if err != nil || enrollment.EnabledAt == nil {
+		return nil, fmt.Errorf("validation failed: two-factor authentication is not enabled")
+	}
+
+	// A failed attempt stays counted
+	attempt, err := beginLoginAttempt(ctx, u.loginAttemptRepo, key, ip, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return nil, auth.ErrInvalidCredentials
+	}
+
+	_, ok, err := checkSecondFactor(ctx, u.mfaRepo, enrollment, code, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check two-factor code: %w", err)
+	}
+	if !ok {
+		return nil, auth.ErrInvalidMFACode
+	}
+
+	forgetLoginAttempt(ctx, u.loginAttemptRepo, attempt)
+	return user, nil
+}
+
+// required reports whether the policy makes two-factor mandatory for the
+// user's role.
+func (u *mfaUsecaseImpl) required(ctx context.Context, user *model.User) (bool, error) {
+	if !u.config.Auth.RequireMFAForPrivileged {
+		return false, nil
+	}
+
+	role, err := u.roleRepo.GetRole(ctx, user.Role)
+	if err != nil {
+		return false, fmt.Errorf("failed to load role %s: %w", user.Role, err)
+	}
+
+	return role.IsPrivileged(), nil
+}
+
+func (u *mfaUsecaseImpl) audit(ctx context.Context, userID, action, ip string, at time.Time) error {
+	err := u.auditRepo.Create(ctx, &audit.Entry{
+		ID:         uuid.New().String(),
+		ActorID:    &userID,
+		Action:     action,
+		TargetType: "user",
+		TargetID:   userID,
+		IP:         ip,
+		CreatedAt:  at,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package impl
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 
 	"evently/internal/domain/events"
@@ -52,14 +54,48 @@ func (u *notificationUsecaseImpl) GetUserNotifications(ctx context.Context, user
 	return u.notificationRepo.GetByUserID(userID, limit, offset)
 }
 
-func (u *notificationUsecaseImpl) MarkNotificationAsRead(ctx context.Context, notificationID string) error {
-	return u.notificationRepo.MarkAsRead(notificationID)
+func (u *notificationUsecaseImpl) ListNotifications(ctx context.Context, userID string, filter model.NotificationFilter) (*model.NotificationPage, error) {
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+
+	var after *model.NotificationCursor
+	if filter.Cursor != "" {
+		cursor, err := decodeNotificationCursor(filter.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("validation failed: invalid cursor")
+		}
+		after = cursor
+	}
+
+	// One extra row tells whether another page follows
+	notifications, err := u.notificationRepo.List(userID, filter.UnreadOnly, after, filter.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	page := &model.NotificationPage{Notifications: notifications}
+	if len(notifications) > filter.Limit {
+		page.Notifications = notifications[:filter.Limit]
+		last := page.Notifications[filter.Limit-1]
+		page.NextCursor = encodeNotificationCursor(&model.NotificationCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return page, nil
+}
+
+func (u *notificationUsecaseImpl) MarkNotificationAsRead(ctx context.Context, userID, notificationID string) error {
+	return u.notificationRepo.MarkAsRead(notificationID, userID)
 }
 
 func (u *notificationUsecaseImpl) MarkAllNotificationsAsRead(ctx context.Context, userID string) error {
 	return u.notificationRepo.MarkAllAsRead(userID)
 }
 
+func (u *notificationUsecaseImpl) DeleteNotification(ctx context.Context, userID, notificationID string) error {
+	return u.notificationRepo.Delete(notificationID, userID)
+}
+
 func (u *notificationUsecaseImpl) GetUnreadNotificationCount(ctx context.Context, userID string) (int, error) {
 	return u.notificationRepo.GetUnreadCount(userID)
 }
@@ -86,3 +122,29 @@ func (u *notificationUsecaseImpl) SendWaitlistNotification(ctx context.Context,
 
 	return u.notificationRepo.Create(notification)
 }
+
+// Cursors are opaque to clients: the creation time and ID of the last
+// notification seen, base64 encoded.
+func encodeNotificationCursor(cursor *model.NotificationCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeNotificationCursor(encoded string) (*model.NotificationCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	at, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.NotificationCursor{CreatedAt: at, ID: id}, nil
+}
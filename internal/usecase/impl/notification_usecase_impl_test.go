@@ -0,0 +1,144 @@
+package impl
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"evently/internal/domain/model"
+)
+
+func TestNotificationCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor model.NotificationCursor
+	}{
+		{name: "utc", cursor: model.NotificationCursor{CreatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), ID: "a1"}},
+		{name: "microseconds", cursor: model.NotificationCursor{CreatedAt: time.Date(2026, 10, 1, 12, 0, 0, 123456000, time.UTC), ID: "a1"}},
+		{name: "other zone", cursor: model.NotificationCursor{CreatedAt: time.Date(2026, 10, 1, 14, 0, 0, 1, time.FixedZone("CEST", 2*60*60)), ID: "a1"}},
+		{name: "separator in id", cursor: model.NotificationCursor{CreatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), ID: "a|b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := encodeNotificationCursor(&tt.cursor)
+			if strings.ContainsAny(encoded, "+/=") {
+				t.Errorf("cursor %q is not URL safe", encoded)
+			}
+
+			decoded, err := decodeNotificationCursor(encoded)
+			if err != nil {
+				t.Fatalf("decodeNotificationCursor failed: %v", err)
+			}
+			if !decoded.CreatedAt.Equal(tt.cursor.CreatedAt) || decoded.ID != tt.cursor.ID {
+				t.Errorf("decoded %+v, want %+v", decoded, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeNotificationCursorInvalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{name: "not base64", encoded: "not a cursor!"},
+		{name: "padded", encoded: base64.URLEncoding.EncodeToString([]byte("2026-10-01T12:00:00Z|a1"))},
+		{name: "no separator", encoded: encode("2026-10-01T12:00:00Z")},
+		{name: "no id", encoded: encode("2026-10-01T12:00:00Z|")},
+		{name: "bad time", encoded: encode("yesterday|a1")},
+		{name: "empty", encoded: encode("")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cursor, err := decodeNotificationCursor(tt.encoded); err == nil {
+				t.Errorf("decodeNotificationCursor(%q) = %+v, want an error", tt.encoded, cursor)
+			}
+		})
+	}
+}
+
+type fakeNotificationRepo struct {
+	model.NotificationRepository
+	notifications []*model.Notification
+	lastAfter     *model.NotificationCursor
+	lastLimit     int
+}
+
+func (r *fakeNotificationRepo) GetByID(id string) (*model.Notification, error) {
+	for _, n := range r.notifications {
+		if n.ID == id {
+			return n, nil
+		}
+	}
+	return nil, errors.New("notification not found")
+}
+
+// List pages through the notifications, which are sorted newest first.
+func (r *fakeNotificationRepo) List(userID string, unreadOnly bool, after *model.NotificationCursor, limit int) ([]*model.Notification, error) {
+	r.lastAfter, r.lastLimit = after, limit
+
+	var page []*model.Notification
+	for _, n := range r.notifications {
+		if after != nil && !n.CreatedAt.Before(after.CreatedAt) && !(n.CreatedAt.Equal(after.CreatedAt) && n.ID < after.ID) {
+			continue
+		}
+		if len(page) == limit {
+			break
+		}
+		page = append(page, n)
+	}
+	return page, nil
+}
+
+func TestListNotificationsPages(t *testing.T) {
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	repo := &fakeNotificationRepo{}
+	for i := 0; i < 5; i++ {
+		repo.notifications = append(repo.notifications, &model.Notification{
+			ID:        fmt.Sprintf("n%d", 5-i),
+			CreatedAt: start.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	u := NewNotificationUsecase(repo, nil)
+
+	var seen []string
+	filter := model.NotificationFilter{Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("paging did not end")
+		}
+		page, err := u.ListNotifications(context.Background(), "user-1", filter)
+		if err != nil {
+			t.Fatalf("ListNotifications failed: %v", err)
+		}
+		if repo.lastLimit != 3 {
+			t.Errorf("asked the repository for %d rows, want one more than the limit", repo.lastLimit)
+		}
+		for _, n := range page.Notifications {
+			seen = append(seen, n.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+
+	if got := strings.Join(seen, ","); got != "n5,n4,n3,n2,n1" {
+		t.Errorf("paged through %s", got)
+	}
+
+	_, err := u.ListNotifications(context.Background(), "user-1", model.NotificationFilter{Cursor: "garbage!"})
+	if err == nil || !strings.HasPrefix(err.Error(), "validation failed") {
+		t.Errorf("invalid cursor error = %v, want a validation error", err)
+	}
+}
@@ -0,0 +1,312 @@
+package impl
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"evently/internal/domain/audit"
+	"evently/internal/domain/auth"
+	"evently/internal/domain/model"
+	"evently/internal/domain/oidc"
+	"evently/internal/domain/rbac"
+	"evently/internal/domain/usecase"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// groupRole maps a provider group to an Evently role.
+type groupRole struct {
+	group string
+	role  string
+}
+
+type oidcUsecaseImpl struct {
+	oidcRepo         oidc.OIDCRepository
+	provider         oidc.Provider
+	userRepo         model.UserRepository
+	roleRepo         rbac.RoleRepository
+	refreshTokenRepo auth.RefreshTokenRepository
+	auditRepo        audit.AuditRepository
+	authUsecase      usecase.AuthUseCase
+	groupRoles       []groupRole
+}
+
+// NewOIDCUsecase parses groupRoles, a list of "group=role" entries, and
+// returns the single sign-on flow on top of provider.
+func NewOIDCUsecase(oidcRepo oidc.OIDCRepository, provider oidc.Provider, userRepo model.UserRepository, roleRepo rbac.RoleRepository, refreshTokenRepo auth.RefreshTokenRepository, auditRepo audit.AuditRepository, authUsecase usecase.AuthUseCase, groupRoles []string) (oidc.OIDCUsecase, error) {
+	mappings := make([]groupRole, 0, len(groupRoles))
+	for _, entry := range groupRoles {
+		group, role, ok := strings.Cut(entry, "=")
+		group, role = strings.TrimSpace(group), strings.TrimSpace(role)
+		if !ok || group == "" || role == "" {
+			return nil, fmt.Errorf("invalid OIDC_GROUP_ROLES entry %q, expected group=role", entry)
+		}
+		mappings = append(mappings, groupRole{group: group, role: role})
+	}
+
+	return &oidcUsecaseImpl{
+		oidcRepo:         oidcRepo,
+		provider:         provider,
+		userRepo:         userRepo,
+		roleRepo:         roleRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		auditRepo:        auditRepo,
+		authUsecase:      authUsecase,
+		groupRoles:       mappings,
+	}, nil
+}
+
+func (u *oidcUsecaseImpl) StartLogin(ctx context.Context) (string, string, error) {
+	state, err := newOpaqueToken()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate state: %w", err)
+	}
+	nonce, err := newOpaqueToken()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	verifier, err := newOpaqueToken()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+
+	now := time.Now()
+	err = u.oidcRepo.SaveState(ctx, &oidc.LoginState{
+		StateHash:    hashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    now.Add(oidc.LoginStateTTL),
+		CreatedAt:    now,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to store login state: %w", err)
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	authURL, err := u.provider.AuthCodeURL(ctx, state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		return "", "", err
+	}
+
+	return authURL, state, nil
+}
+
+func (u *oidcUsecaseImpl) CompleteLogin(ctx context.Context, state, browserState, code, ip string) (*auth.TokenPair, error) {
+	// Without this, an attacker could send a victim to the callback with
+	// the attacker's own code and state and sign them in as the attacker
+	if browserState == "" || subtle.ConstantTimeCompare([]byte(state), []byte(browserState)) != 1 {
+		return nil, oidc.ErrInvalidState
+	}
+
+	now := time.Now()
+	pending, err := u.oidcRepo.ConsumeState(ctx, hashToken(state), now)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := u.provider.Exchange(ctx, code, pending.CodeVerifier, pending.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.resolveUser(ctx, claims, ip, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.syncRole(ctx, user, claims.Groups, ip, now); err != nil {
+		return nil, err
+	}
+
+	return u.authUsecase.StartSession(ctx, user)
+}
+
+// resolveUser finds the user linked to the provider account. Accounts seen
+// for the first time are linked to the user with the same email, or get a
+// new user, but only when the provider vouches for the email.
+func (u *oidcUsecaseImpl) resolveUser(ctx context.Context, claims *oidc.Claims, ip string, now time.Time) (*model.User, error) {
+	identity, err := u.oidcRepo.GetIdentity(ctx, claims.Issuer, claims.Subject)
+	if err == nil {
+		user, err := u.userRepo.GetByID(identity.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("user not found")
+		}
+		if err := u.oidcRepo.TouchIdentity(ctx, identity.ID, claims.Email, now); err != nil {
+			return nil, fmt.Errorf("failed to update identity: %w", err)
+		}
+		return user, nil
+	}
+
+	email := strings.TrimSpace(claims.Email)
+	if email == "" || !claims.EmailVerified {
+		return nil, fmt.Errorf("%w: the provider did not confirm an email address", oidc.ErrLoginRejected)
+	}
+
+	action := audit.ActionSSOLinked
+	user, err := u.userRepo.GetByEmail(email)
+	if err != nil {
+		action = audit.ActionSSOProvisioned
+		user, err = u.provisionUser(ctx, claims, email, now)
+		if err != nil {
+			return nil, err
+		}
+	} else if user.EmailVerifiedAt == nil {
+		// Whoever registered the address without verifying it may not be
+		// its owner, so their password and sessions stop working
+		if err := u.lockOutPassword(ctx, user, now); err != nil {
+			return nil, err
+		}
+	}
+
+	err = u.oidcRepo.CreateIdentity(ctx, &oidc.Identity{
+		ID:          uuid.New().String(),
+		UserID:      user.ID,
+		Issuer:      claims.Issuer,
+		Subject:     claims.Subject,
+		Email:       email,
+		CreatedAt:   now,
+		LastLoginAt: &now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	err = u.audit(ctx, user.ID, action, ip, now, map[string]any{
+		"issuer":  claims.Issuer,
+		"subject": claims.Subject,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *oidcUsecaseImpl) provisionUser(ctx context.Context, claims *oidc.Claims, email string, now time.Time) (*model.User, error) {
+	name := strings.TrimSpace(claims.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+
+	password, err := unusablePassword()
+	if err != nil {
+		return nil, err
+	}
+
+	user := &model.User{
+		ID:              uuid.New().String(),
+		Name:            name,
+		Email:           email,
+		Password:        password,
+		Role:            model.RoleUser,
+		EmailVerifiedAt: &now,
+		CreatedAt:       now,
+	}
+	if err := u.userRepo.Create(user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (u *oidcUsecaseImpl) lockOutPassword(ctx context.Context, user *model.User, now time.Time) error {
+	password, err := unusablePassword()
+	if err != nil {
+		return err
+	}
+	if err := u.userRepo.UpdatePassword(user.ID, password); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	if _, err := u.refreshTokenRepo.RevokeAllForUser(ctx, user.ID, now); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	if err := u.userRepo.MarkEmailVerified(user.ID, now); err != nil {
+		return fmt.Errorf("failed to mark email verified: %w", err)
+	}
+
+	user.EmailVerifiedAt = &now
+	return nil
+}
+
+// syncRole gives the user the role of the first mapping whose group they
+// are in, or the default role when none matches. Without mappings roles are
+// managed in Evently only.
+func (u *oidcUsecaseImpl) syncRole(ctx context.Context, user *model.User, groups []string, ip string, now time.Time) error {
+	if len(u.groupRoles) == 0 {
+		return nil
+	}
+
+	member := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		member[group] = true
+	}
+
+	role := model.RoleUser
+	for _, mapping := range u.groupRoles {
+		if member[mapping.group] {
+			role = mapping.role
+			break
+		}
+	}
+
+	if role == user.Role {
+		return nil
+	}
+
+	if _, err := u.roleRepo.GetRole(ctx, role); err != nil {
+		return fmt.Errorf("OIDC_GROUP_ROLES names unknown role %q: %w", role, err)
+	}
+	if err := u.roleRepo.SetUserRole(ctx, user.ID, role); err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	err := u.audit(ctx, user.ID, audit.ActionSSORoleSynced, ip, now, map[string]any{
+		"from": user.Role,
+		"to":   role,
+	})
+	if err != nil {
+		return err
+	}
+
+	user.Role = role
+	return nil
+}
+
+func (u *oidcUsecaseImpl) audit(ctx context.Context, userID, action, ip string, at time.Time, metadata map[string]any) error {
+	err := u.auditRepo.Create(ctx, &audit.Entry{
+		ID:         uuid.New().String(),
+		ActorID:    &userID,
+		Action:     action,
+		TargetType: "user",
+		TargetID:   userID,
+		IP:         ip,
+		Metadata:   metadata,
+		CreatedAt:  at,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// unusablePassword returns the hash of a random password nobody knows.
+// Single sign-on users can still set one through a password reset.
+func unusablePassword() (string, error) {
+	secret, err := newOpaqueToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate password: %w", err)
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return string(hashed), nil
+}
@@ -0,0 +1,388 @@
+package impl
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"evently/internal/domain/auth"
+	"evently/internal/domain/model"
+	"evently/internal/domain/oidc"
+	"evently/internal/domain/usecase"
+	oidcImpl "evently/internal/usecase/oidc"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testClientID = "evently"
+
+// fakeIdentityProvider serves discovery, token and key endpoints. Each
+// code remembers the PKCE challenge and nonce it was issued for.
+type fakeIdentityProvider struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PrivateKey
+	signingKid  string
+	codes       map[string]fakeAuthorization
+	issued      int
+	jwksFetches int
+
+	// Claims overrides, empty for the right values
+	issuer   string
+	audience string
+	nonce    string
+}
+
+type fakeAuthorization struct {
+	challenge string
+	nonce     string
+	email     string
+}
+
+func newFakeIdentityProvider(t *testing.T) *fakeIdentityProvider {
+	t.Helper()
+	idp := &fakeIdentityProvider{t: t, keys: map[string]*rsa.PrivateKey{}, codes: map[string]fakeAuthorization{}}
+	idp.rotate("key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", idp.jwks)
+	mux.HandleFunc("/token", idp.token)
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+// rotate publishes a new key and signs with it from now on.
+func (idp *fakeIdentityProvider) rotate(kid string) {
+	idp.t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		idp.t.Fatalf("failed to generate key: %v", err)
+	}
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.keys[kid] = key
+	idp.signingKid = kid
+}
+
+// authorize stands in for the user signing in at the provider.
+func (idp *fakeIdentityProvider) authorize(authURL, email string) string {
+	idp.t.Helper()
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		idp.t.Fatalf("invalid auth URL: %v", err)
+	}
+	query := parsed.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("client_id") != testClientID {
+		idp.t.Fatalf("unexpected auth URL %s", authURL)
+	}
+
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.issued++
+	code := fmt.Sprintf("code-%d", idp.issued)
+	idp.codes[code] = fakeAuthorization{
+		challenge: query.Get("code_challenge"),
+		nonce:     query.Get("nonce"),
+		email:     email,
+	}
+	return code
+}
+
+func (idp *fakeIdentityProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.jwksFetches++
+
+	keys := []map[string]string{}
+	for kid, key := range idp.keys {
+		keys = append(keys, map[string]string{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+}
+
+func (idp *fakeIdentityProvider) token(w http.ResponseWriter, r *http.Request) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	r.ParseForm()
+	authorization, ok := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code"))
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(challenge[:]) != authorization.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":            orDefault(idp.issuer, idp.server.URL),
+		"aud":            orDefault(idp.audience, testClientID),
+		"sub":            "subject-" + authorization.email,
+		"nonce":          orDefault(idp.nonce, authorization.nonce),
+		"email":          authorization.email,
+		"email_verified": true,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(5 * time.Minute).Unix(),
+	}
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = idp.signingKid
+	signed, err := idToken.SignedString(idp.keys[idp.signingKid])
+	if err != nil {
+		idp.t.Errorf("failed to sign id token: %v", err)
+	}
+	json.NewEncoder(w).Encode(map[string]string{"id_token": signed})
+}
+
+func orDefault(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
+
+type fakeOIDCRepo struct {
+	oidc.OIDCRepository
+	states     map[string]*oidc.LoginState
+	identities map[string]*oidc.Identity
+}
+
+func (r *fakeOIDCRepo) SaveState(ctx context.Context, state *oidc.LoginState) error {
+	r.states[state.StateHash] = state
+	return nil
+}
+
+func (r *fakeOIDCRepo) ConsumeState(ctx context.Context, stateHash string, now time.Time) (*oidc.LoginState, error) {
+	state, ok := r.states[stateHash]
+	delete(r.states, stateHash)
+	if !ok || now.After(state.ExpiresAt) {
+		return nil, oidc.ErrInvalidState
+	}
+	return state, nil
+}
+
+func (r *fakeOIDCRepo) GetIdentity(ctx context.Context, issuer, subject string) (*oidc.Identity, error) {
+	identity, ok := r.identities[issuer+" "+subject]
+	if !ok {
+		return nil, errors.New("identity not found")
+	}
+	return identity, nil
+}
+
+func (r *fakeOIDCRepo) CreateIdentity(ctx context.Context, identity *oidc.Identity) error {
+	r.identities[identity.Issuer+" "+identity.Subject] = identity
+	return nil
+}
+
+func (r *fakeOIDCRepo) TouchIdentity(ctx context.Context, id, email string, at time.Time) error {
+	return nil
+}
+
+type fakeUserRepo struct {
+	model.UserRepository
+	users map[string]*model.User
+}
+
+func (r *fakeUserRepo) Create(user *model.User) error {
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *fakeUserRepo) GetByID(id string) (*model.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+func (r *fakeUserRepo) GetByEmail(email string) (*model.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
+type fakeSessionStarter struct {
+	usecase.AuthUseCase
+}
+
+func (f *fakeSessionStarter) StartSession(ctx context.Context, user *model.User) (*auth.TokenPair, error) {
+	return &auth.TokenPair{AccessToken: "access-" + user.Email}, nil
+}
+
+func newTestOIDCUsecase(t *testing.T, idp *fakeIdentityProvider) oidc.OIDCUsecase {
+	t.Helper()
+	provider, err := oidcImpl.NewProvider(model.OIDCConfig{
+		Issuer:      idp.server.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost:8080/api/auth/oidc/callback",
+	})
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+
+	u, err := NewOIDCUsecase(
+		&fakeOIDCRepo{states: map[string]*oidc.LoginState{}, identities: map[string]*oidc.Identity{}},
+		provider,
+		&fakeUserRepo{users: map[string]*model.User{}},
+		nil, nil,
+		&fakeAuditRepo{},
+		&fakeSessionStarter{},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewOIDCUsecase failed: %v", err)
+	}
+	return u
+}
+
+// startLogin starts a login and has the provider sign email in.
+func startLogin(t *testing.T, u oidc.OIDCUsecase, idp *fakeIdentityProvider, email string) (state, code string) {
+	t.Helper()
+	authURL, state, err := u.StartLogin(context.Background())
+	if err != nil {
+		t.Fatalf("StartLogin failed: %v", err)
+	}
+	parsed, _ := url.Parse(authURL)
+	if parsed.Query().Get("state") != state {
+		t.Fatalf("auth URL state does not match the browser state")
+	}
+	return state, idp.authorize(authURL, email)
+}
+
+func TestOIDCLogin(t *testing.T) {
+	idp := newFakeIdentityProvider(t)
+	u := newTestOIDCUsecase(t, idp)
+
+	state, code := startLogin(t, u, idp, "staff@example.com")
+	tokens, err := u.CompleteLogin(context.Background(), state, state, code, "10.0.0.1")
+	if err != nil {
+		t.Fatalf("CompleteLogin failed: %v", err)
+	}
+	if tokens.AccessToken != "access-staff@example.com" {
+		t.Errorf("signed in as %q", tokens.AccessToken)
+	}
+
+	// States are single use
+	if _, err := u.CompleteLogin(context.Background(), state, state, code, "10.0.0.1"); !errors.Is(err, oidc.ErrInvalidState) {
+		t.Errorf("replayed state error = %v, want ErrInvalidState", err)
+	}
+}
+
+func TestOIDCLoginOtherBrowser(t *testing.T) {
+	idp := newFakeIdentityProvider(t)
+	u := newTestOIDCUsecase(t, idp)
+
+	state, code := startLogin(t, u, idp, "attacker@example.com")
+	victimState, _ := startLogin(t, u, idp, "victim@example.com")
+
+	for _, browserState := range []string{"", victimState} {
+		if _, err := u.CompleteLogin(context.Background(), state, browserState, code, "10.0.0.1"); !errors.Is(err, oidc.ErrInvalidState) {
+			t.Errorf("browser state %q: error = %v, want ErrInvalidState", browserState, err)
+		}
+	}
+
+	// Refused callbacks leave the state to its own browser
+	if _, err := u.CompleteLogin(context.Background(), state, state, code, "10.0.0.1"); err != nil {
+		t.Errorf("CompleteLogin failed after refused callbacks: %v", err)
+	}
+}
+
+func TestOIDCLoginRejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(idp *fakeIdentityProvider)
+		code  func(code string) string
+	}{
+		{name: "nonce mismatch", setup: func(idp *fakeIdentityProvider) { idp.nonce = "replayed" }},
+		{name: "issuer mismatch", setup: func(idp *fakeIdentityProvider) { idp.issuer = "https://evil.example.com" }},
+		{name: "audience mismatch", setup: func(idp *fakeIdentityProvider) { idp.audience = "someone-else" }},
+		{name: "unknown code", code: func(string) string { return "stolen" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := newFakeIdentityProvider(t)
+			u := newTestOIDCUsecase(t, idp)
+			if tt.setup != nil {
+				tt.setup(idp)
+			}
+
+			state, code := startLogin(t, u, idp, "staff@example.com")
+			if tt.code != nil {
+				code = tt.code(code)
+			}
+			if _, err := u.CompleteLogin(context.Background(), state, state, code, "10.0.0.1"); !errors.Is(err, oidc.ErrLoginRejected) {
+				t.Errorf("CompleteLogin error = %v, want ErrLoginRejected", err)
+			}
+		})
+	}
+}
+
+func TestOIDCLoginPKCE(t *testing.T) {
+	idp := newFakeIdentityProvider(t)
+	u := newTestOIDCUsecase(t, idp)
+
+	// A code issued for another login's challenge does not redeem with
+	// this login's verifier
+	state, _ := startLogin(t, u, idp, "victim@example.com")
+	_, otherCode := startLogin(t, u, idp, "attacker@example.com")
+
+	if _, err := u.CompleteLogin(context.Background(), state, state, otherCode, "10.0.0.1"); !errors.Is(err, oidc.ErrLoginRejected) {
+		t.Errorf("CompleteLogin error = %v, want ErrLoginRejected", err)
+	}
+}
+
+func TestOIDCLoginUnknownKey(t *testing.T) {
+	idp := newFakeIdentityProvider(t)
+	u := newTestOIDCUsecase(t, idp)
+
+	state, code := startLogin(t, u, idp, "staff@example.com")
+	if _, err := u.CompleteLogin(context.Background(), state, state, code, "10.0.0.1"); err != nil {
+		t.Fatalf("CompleteLogin failed: %v", err)
+	}
+
+	// Right after a fetch, a token naming an unknown key is refused without
+	// fetching the keys again; later fetches are covered by the provider
+	idp.rotate("key-2")
+	state, code = startLogin(t, u, idp, "staff@example.com")
+	if _, err := u.CompleteLogin(context.Background(), state, state, code, "10.0.0.1"); !errors.Is(err, oidc.ErrLoginRejected) {
+		t.Fatalf("CompleteLogin error = %v, want ErrLoginRejected", err)
+	}
+	if idp.jwksFetches != 1 {
+		t.Errorf("keys fetched %d times, want 1", idp.jwksFetches)
+	}
+}
@@ -0,0 +1,107 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/model"
+	"evently/internal/domain/organizer"
+	"evently/internal/domain/rbac"
+)
+
+type organizerUsecaseImpl struct {
+	organizerRepo organizer.OrganizerRepository
+	eventRepo     events.EventRepository
+	userRepo      model.UserRepository
+	roleRepo      rbac.RoleRepository
+}
+
+func NewOrganizerUsecase(organizerRepo organizer.OrganizerRepository, eventRepo events.EventRepository, userRepo model.UserRepository, roleRepo rbac.RoleRepository) organizer.OrganizerUsecase {
+	return &organizerUsecaseImpl{
+		organizerRepo: organizerRepo,
+		eventRepo:     eventRepo,
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+	}
+}
+
+func (u *organizerUsecaseImpl) CanManageEvent(ctx context.Context, eventID, userID string) (bool, error) {
+	return u.organizerRepo.CanManageEvent(ctx, eventID, userID)
+}
+
+func (u *organizerUsecaseImpl) AddCollaborator(ctx context.Context, eventID, email, addedBy string) (*organizer.Collaborator, error) {
+	event, err := u.rootEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetByEmail(strings.TrimSpace(email))
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	role, err := u.roleRepo.GetRole(ctx, user.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load role %s: %w", user.Role, err)
+	}
+	if !role.HasPermission(rbac.PermEventsWrite) {
+		return nil, fmt.Errorf("validation failed: only users who can manage events can be added as collaborators")
+	}
+	if user.ID == event.CreatedBy {
+		return nil, fmt.Errorf("validation failed: the user already owns this event")
+	}
+
+	collaborator := &organizer.Collaborator{
+		EventID:   event.ID,
+		UserID:    user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		AddedBy:   &addedBy,
+		CreatedAt: time.Now(),
+	}
+
+	if err := u.organizerRepo.AddCollaborator(ctx, collaborator); err != nil {
+		return nil, fmt.Errorf("failed to add collaborator: %w", err)
+	}
+
+	return collaborator, nil
+}
+
+func (u *organizerUsecaseImpl) RemoveCollaborator(ctx context.Context, eventID, userID string) error {
+	event, err := u.rootEvent(eventID)
+	if err != nil {
+		return err
+	}
+
+	return u.organizerRepo.RemoveCollaborator(ctx, event.ID, userID)
+}
+
+func (u *organizerUsecaseImpl) ListCollaborators(ctx context.Context, eventID string) ([]*organizer.Collaborator, error) {
+	event, err := u.rootEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.organizerRepo.ListCollaborators(ctx, event.ID)
+}
+
+// rootEvent returns the event itself, or the parent event for a session,
+// since collaborators are always recorded on the parent.
+func (u *organizerUsecaseImpl) rootEvent(eventID string) (*events.Event, error) {
+	event, err := u.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found: %w", err)
+	}
+
+	if event.ParentEventID != nil {
+		event, err = u.eventRepo.GetByID(*event.ParentEventID)
+		if err != nil {
+			return nil, fmt.Errorf("event not found: %w", err)
+		}
+	}
+
+	return event, nil
+}
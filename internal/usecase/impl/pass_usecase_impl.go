@@ -0,0 +1,82 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/pass"
+
+	"github.com/google/uuid"
+)
+
+type passUsecaseImpl struct {
+	passRepo  pass.PassRepository
+	eventRepo events.EventRepository
+}
+
+func NewPassUsecase(passRepo pass.PassRepository, eventRepo events.EventRepository) pass.PassUsecase {
+	return &passUsecaseImpl{
+		passRepo:  passRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+func (u *passUsecaseImpl) CreatePass(ctx context.Context, newPass *pass.Pass) error {
+	if err := validatePass(newPass); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if _, err := u.eventRepo.GetByID(newPass.EventID); err != nil {
+		return fmt.Errorf("event not found: %w", err)
+	}
+
+	seen := make(map[string]bool, len(newPass.SessionIDs))
+	for _, sessionID := range newPass.SessionIDs {
+		if seen[sessionID] {
+			return fmt.Errorf("validation failed: session %s is listed twice", sessionID)
+		}
+		seen[sessionID] = true
+
+		session, err := u.eventRepo.GetByID(sessionID)
+		if err != nil {
+			return fmt.Errorf("session %s not found: %w", sessionID, err)
+		}
+		if session.ParentEventID == nil || *session.ParentEventID != newPass.EventID {
+			return fmt.Errorf("validation failed: session %s does not belong to event", sessionID)
+		}
+	}
+
+	newPass.ID = uuid.New().String()
+	newPass.CreatedAt = time.Now()
+	newPass.UpdatedAt = time.Now()
+
+	return u.passRepo.Create(ctx, newPass)
+}
+
+func (u *passUsecaseImpl) GetPass(ctx context.Context, passID string) (*pass.Pass, error) {
+	return u.passRepo.GetByID(ctx, passID)
+}
+
+func (u *passUsecaseImpl) ListEventPasses(ctx context.Context, eventID string) ([]*pass.Pass, error) {
+	return u.passRepo.ListByEvent(ctx, eventID)
+}
+
+func (u *passUsecaseImpl) DeletePass(ctx context.Context, eventID, passID string) error {
+	return u.passRepo.Delete(ctx, eventID, passID)
+}
+
+func validatePass(p *pass.Pass) error {
+	if p.Name == "" {
+		return fmt.Errorf("pass name is required")
+	}
+	if p.Price < 0 {
+		return fmt.Errorf("pass price cannot be negative")
+	}
+	if len(p.SessionIDs) == 0 {
+		return fmt.Errorf("a pass must include at least one session")
+	}
+
+	return validateSaleWindow(p.OnSaleAt, p.OffSaleAt)
+}
@@ -0,0 +1,219 @@
+package impl
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/model"
+	"evently/internal/domain/presale"
+
+	"github.com/google/uuid"
+)
+
+// AccessCodeKey is the server secret presale access codes are hashed with,
+// so that a leaked presales table does not give the codes away.
+type AccessCodeKey []byte
+
+// NewAccessCodeKey reads the key from cfg. Outside development it must be
+// set.
+func NewAccessCodeKey(cfg model.PresaleConfig, devMode bool) (AccessCodeKey, error) {
+	switch {
+	case cfg.CodeSecret != "":
+		return AccessCodeKey(cfg.CodeSecret), nil
+	case devMode:
+		log.Println("PRESALE_CODE_SECRET is not set, hashing access codes with a development secret")
+		return AccessCodeKey("evently-development"), nil
+	default:
+		return nil, fmt.Errorf("PRESALE_CODE_SECRET is required outside development")
+	}
+}
+
+// hash normalizes and hashes a presale access code. Codes are not case
+// sensitive. An empty code hashes to the empty string, which matches no
+// presale.
+func (k AccessCodeKey) hash(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, k)
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type presaleUsecaseImpl struct {
+	presaleRepo   presale.PresaleRepository
+	eventRepo     events.EventRepository
+	userRepo      model.UserRepository
+	accessCodeKey AccessCodeKey
+}
+
+func NewPresaleUsecase(presaleRepo presale.PresaleRepository, eventRepo events.EventRepository, userRepo model.UserRepository, accessCodeKey AccessCodeKey) presale.PresaleUsecase {
+	return &presaleUsecaseImpl{
+		presaleRepo:   presaleRepo,
+		eventRepo:     eventRepo,
+		userRepo:      userRepo,
+		accessCodeKey: accessCodeKey,
+	}
+}
+
+func (u *presaleUsecaseImpl) CreatePresale(ctx context.Context, p *presale.Presale) error {
+	event, err := u.eventRepo.GetByID(p.EventID)
+	if err != nil {
+		return fmt.Errorf("event not found: %w", err)
+	}
+
+	if err := u.validatePresale(p, event); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	// Only the hash is stored; the code itself is echoed back once
+	var codeHash *string
+	if p.Kind == presale.KindAccessCode {
+		hash := u.accessCodeKey.hash(p.AccessCode)
+		codeHash = &hash
+	}
+
+	p.ID = uuid.New().String()
+	p.CreatedAt = time.Now()
+
+	if err := u.presaleRepo.Create(ctx, p, codeHash); err != nil {
+		return fmt.Errorf("failed to create presale: %w", err)
+	}
+
+	return nil
+}
+
+func (u *presaleUsecaseImpl) DeletePresale(ctx context.Context, eventID, presaleID string) error {
+	return u.presaleRepo.Delete(ctx, eventID, presaleID)
+}
+
+func (u *presaleUsecaseImpl) ListPresales(ctx context.Context, eventID string) ([]*presale.Presale, error) {
+	return u.presaleRepo.ListByEvent(ctx, eventID)
+}
+
+func (u *presaleUsecaseImpl) GetSchedule(ctx context.Context, eventID string) (*presale.Schedule, error) {
+	event, err := u.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found: %w", err)
+	}
+
+	presales, err := u.presaleRepo.ListByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get presales: %w", err)
+	}
+
+	schedule := &presale.Schedule{
+		OnSaleAt:  event.OnSaleAt,
+		OffSaleAt: event.OffSaleAt,
+		Presales:  []*presale.Window{},
+	}
+	for _, p := range presales {
+		schedule.Presales = append(schedule.Presales, &presale.Window{
+			Name:     p.Name,
+			Kind:     p.Kind,
+			StartsAt: p.StartsAt,
+			EndsAt:   p.EndsAt,
+		})
+	}
+
+	return schedule, nil
+}
+
+func (u *presaleUsecaseImpl) validatePresale(p *presale.Presale, event *events.Event) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return fmt.Errorf("presale name is required")
+	}
+
+	if !p.StartsAt.Before(p.EndsAt) {
+		return fmt.Errorf("presale must end after it starts")
+	}
+
+	if !p.StartsAt.Before(event.EventTime) {
+		return fmt.Errorf("presale must start before the event")
+	}
+
+	switch p.Kind {
+	case presale.KindAccessCode:
+		if len(strings.TrimSpace(p.AccessCode)) < 4 {
+			return fmt.Errorf("access code must be at least 4 characters")
+		}
+	case presale.KindUserList:
+		if len(p.UserIDs) == 0 {
+			return fmt.Errorf("a user list presale needs at least one user")
+		}
+		for _, userID := range p.UserIDs {
+			if _, err := u.userRepo.GetByID(userID); err != nil {
+				return fmt.Errorf("user %s not found", userID)
+			}
+		}
+	case presale.KindPreviousAttendees:
+		if len(p.SourceEventIDs) == 0 {
+			return fmt.Errorf("a previous attendees presale needs at least one source event")
+		}
+		for _, sourceID := range p.SourceEventIDs {
+			if _, err := u.eventRepo.GetByID(sourceID); err != nil {
+				return fmt.Errorf("source event %s not found", sourceID)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown presale kind %q", p.Kind)
+	}
+
+	// Fields of other kinds are dropped rather than stored unused
+	if p.Kind != presale.KindAccessCode {
+		p.AccessCode = ""
+	}
+	if p.Kind != presale.KindUserList {
+		p.UserIDs = nil
+	}
+	if p.Kind != presale.KindPreviousAttendees {
+		p.SourceEventIDs = nil
+	}
+
+	return nil
+}
+
+// checkSalesOpen returns a *presale.SalesClosedError unless tickets can be
+// bought at now. Before the on-sale time only buyers admitted by a running
+// presale of the event get through.
+func checkSalesOpen(ctx context.Context, presaleRepo presale.PresaleRepository, accessCodeKey AccessCodeKey, eventID string, onSaleAt, offSaleAt *time.Time, userID, accessCode string, now time.Time) error {
+	if offSaleAt != nil && !now.Before(*offSaleAt) {
+		return &presale.SalesClosedError{Reason: "sales have ended"}
+	}
+
+	if onSaleAt == nil || !now.Before(*onSaleAt) {
+		return nil
+	}
+
+	ok, err := presaleRepo.HasAccess(ctx, eventID, userID, accessCodeKey.hash(accessCode), now)
+	if err != nil {
+		return fmt.Errorf("failed to check presale access: %w", err)
+	}
+	if ok {
+		return nil
+	}
+
+	reason := "sales have not opened yet"
+	if accessCode != "" {
+		reason = "access code is not valid for a running presale"
+	}
+
+	return &presale.SalesClosedError{Reason: reason, OpensAt: onSaleAt}
+}
+
+// validateSaleWindow checks optional on-sale and off-sale times.
+func validateSaleWindow(onSaleAt, offSaleAt *time.Time) error {
+	if onSaleAt != nil && offSaleAt != nil && !onSaleAt.Before(*offSaleAt) {
+		return fmt.Errorf("off-sale time must be after on-sale time")
+	}
+	return nil
+}
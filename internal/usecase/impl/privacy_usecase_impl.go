@@ -0,0 +1,237 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"evently/internal/domain/audit"
+	"evently/internal/domain/auth"
+	"evently/internal/domain/booking"
+	"evently/internal/domain/dispatch"
+	"evently/internal/domain/model"
+	"evently/internal/domain/privacy"
+	"evently/internal/domain/waitlist"
+
+	"github.com/google/uuid"
+)
+
+// exportPageSize is how many rows of each kind an export reads at a time.
+const exportPageSize = 500
+
+type privacyUsecaseImpl struct {
+	privacyRepo      privacy.PrivacyRepository
+	userRepo         model.UserRepository
+	bookingRepo      booking.BookingRepository
+	waitlistRepo     waitlist.WaitlistRepository
+	notificationRepo model.NotificationRepository
+	pushRepo         dispatch.PushSubscriptionRepository
+	loginAttemptRepo auth.LoginAttemptRepository
+	auditRepo        audit.AuditRepository
+	gracePeriod      time.Duration
+}
+
+func NewPrivacyUsecase(privacyRepo privacy.PrivacyRepository, userRepo model.UserRepository, bookingRepo booking.BookingRepository, waitlistRepo waitlist.WaitlistRepository, notificationRepo model.NotificationRepository, pushRepo dispatch.PushSubscriptionRepository, loginAttemptRepo auth.LoginAttemptRepository, auditRepo audit.AuditRepository, gracePeriod time.Duration) privacy.PrivacyUsecase {
+	return &privacyUsecaseImpl{
+		privacyRepo:      privacyRepo,
+		userRepo:         userRepo,
+		bookingRepo:      bookingRepo,
+		waitlistRepo:     waitlistRepo,
+		notificationRepo: notificationRepo,
+		pushRepo:         pushRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		auditRepo:        auditRepo,
+		gracePeriod:      gracePeriod,
+	}
+}
+
+func (u *privacyUsecaseImpl) ExportData(ctx context.Context, userID string) (*privacy.Export, error) {
+	user, err := u.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &privacy.Export{
+		ExportedAt:    time.Now(),
+		Profile:       user,
+		Bookings:      []*booking.Booking{},
+		Waitlist:      []*waitlist.Waitlist{},
+		Notifications: []*model.Notification{},
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		page, err := u.bookingRepo.GetByUserID(userID, exportPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export bookings: %w", err)
+		}
+		export.Bookings = append(export.Bookings, page...)
+		if len(page) < exportPageSize {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		page, err := u.waitlistRepo.GetByUserID(userID, exportPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export waitlist entries: %w", err)
+		}
+		export.Waitlist = append(export.Waitlist, page...)
+		if len(page) < exportPageSize {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		page, err := u.notificationRepo.GetByUserID(userID, exportPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export notifications: %w", err)
+		}
+		export.Notifications = append(export.Notifications, page...)
+		if len(page) < exportPageSize {
+			break
+		}
+	}
+
+	export.PushSubscriptions, err = u.pushRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export push subscriptions: %w", err)
+	}
+
+	return export, nil
+}
+
+func (u *privacyUsecaseImpl) RequestDeletion(ctx context.Context, userID, password, ip string) (*model.User, error) {
+	user, err := u.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPassword(ctx, u.loginAttemptRepo, user, password, ip); err != nil {
+		return nil, err
+	}
+	if err := u.checkNotLastAdmin(user); err != nil {
+		return nil, err
+	}
+
+	scheduledAt := time.Now().Add(u.gracePeriod)
+	if err := u.privacyRepo.ScheduleDeletion(ctx, user.ID, &scheduledAt); err != nil {
+		return nil, err
+	}
+	user.DeletionScheduledAt = &scheduledAt
+
+	err = u.audit(ctx, &user.ID, audit.ActionUserDeletionRequested, user.ID, ip, map[string]any{
+		"scheduled_at": scheduledAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *privacyUsecaseImpl) CancelDeletion(ctx context.Context, actorID, userID, ip string) error {
+	user, err := u.getUser(userID)
+	if err != nil {
+		return err
+	}
+	if user.DeletionScheduledAt == nil {
+		return fmt.Errorf("validation failed: no deletion is scheduled")
+	}
+
+	if err := u.privacyRepo.ScheduleDeletion(ctx, user.ID, nil); err != nil {
+		return err
+	}
+
+	return u.audit(ctx, &actorID, audit.ActionUserDeletionCancelled, user.ID, ip, nil)
+}
+
+func (u *privacyUsecaseImpl) DeleteNow(ctx context.Context, actorID, userID, ip string) error {
+	if actorID == userID {
+		return fmt.Errorf("validation failed: delete your own account through /users/me/deletion")
+	}
+
+	user, err := u.getUser(userID)
+	if err != nil {
+		return err
+	}
+
+	return u.anonymize(ctx, &actorID, user, ip)
+}
+
+func (u *privacyUsecaseImpl) PurgeDue(ctx context.Context) (int, error) {
+	ids, err := u.privacyRepo.ListDueForDeletion(ctx, time.Now(), 100)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list accounts due for deletion: %w", err)
+	}
+
+	purged := 0
+	for _, id := range ids {
+		user, err := u.getUser(id)
+		if err == nil {
+			err = u.anonymize(ctx, nil, user, "")
+		}
+		if err != nil {
+			// One stuck account must not hold up the others
+			log.Printf("failed to delete account %s: %v", id, err)
+			continue
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+func (u *privacyUsecaseImpl) anonymize(ctx context.Context, actorID *string, user *model.User, ip string) error {
+	if err := u.checkNotLastAdmin(user); err != nil {
+		return err
+	}
+
+	if err := u.privacyRepo.Anonymize(ctx, user.ID, time.Now()); err != nil {
+		return fmt.Errorf("failed to anonymize user: %w", err)
+	}
+
+	return u.audit(ctx, actorID, audit.ActionUserAnonymized, user.ID, ip, nil)
+}
+
+// getUser returns the user unless they are unknown or already deleted.
+func (u *privacyUsecaseImpl) getUser(userID string) (*model.User, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil || user.AnonymizedAt != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	return user, nil
+}
+
+func (u *privacyUsecaseImpl) checkNotLastAdmin(user *model.User) error {
+	if user.Role != model.RoleAdmin {
+		return nil
+	}
+
+	admins, err := u.userRepo.CountByRole(model.RoleAdmin)
+	if err != nil {
+		return fmt.Errorf("failed to count admins: %w", err)
+	}
+	if admins <= 1 {
+		return fmt.Errorf("validation failed: the last admin account cannot be deleted")
+	}
+
+	return nil
+}
+
+func (u *privacyUsecaseImpl) audit(ctx context.Context, actorID *string, action, userID, ip string, metadata map[string]any) error {
+	err := u.auditRepo.Create(ctx, &audit.Entry{
+		ID:         uuid.New().String(),
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: "user",
+		TargetID:   userID,
+		IP:         ip,
+		Metadata:   metadata,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"evently/internal/domain/model"
+	"evently/internal/domain/rbac"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+
+type roleUsecaseImpl struct {
+	roleRepo rbac.RoleRepository
+	userRepo model.UserRepository
+}
+
+func NewRoleUsecase(roleRepo rbac.RoleRepository, userRepo model.UserRepository) rbac.RoleUsecase {
+	return &roleUsecaseImpl{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (u *roleUsecaseImpl) ListPermissions(ctx context.Context) ([]*rbac.Permission, error) {
+	return u.roleRepo.ListPermissions(ctx)
+}
+
+func (u *roleUsecaseImpl) ListRoles(ctx context.Context) ([]*rbac.Role, error) {
+	return u.roleRepo.ListRoles(ctx)
+}
+
+func (u *roleUsecaseImpl) GetRole(ctx context.Context, name string) (*rbac.Role, error) {
+	return u.roleRepo.GetRole(ctx, name)
+}
+
+func (u *roleUsecaseImpl) CreateRole(ctx context.Context, role *rbac.Role) error {
+	role.Name = strings.ToLower(strings.TrimSpace(role.Name))
+	if !roleNamePattern.MatchString(role.Name) {
+		return fmt.Errorf("validation failed: role name must be 2-50 lowercase letters, digits, '_' or '-'")
+	}
+
+	if err := u.validatePermissions(ctx, role.Permissions); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if _, err := u.roleRepo.GetRole(ctx, role.Name); err == nil {
+		return fmt.Errorf("validation failed: role %s already exists", role.Name)
+	}
+
+	role.IsSystem = false
+	role.CreatedAt = time.Now()
+	role.UpdatedAt = time.Now()
+
+	return u.roleRepo.CreateRole(ctx, role)
+}
+
+func (u *roleUsecaseImpl) UpdateRole(ctx context.Context, role *rbac.Role) error {
+	existing, err := u.roleRepo.GetRole(ctx, role.Name)
+	if err != nil {
+		return fmt.Errorf("role not found: %w", err)
+	}
+
+	// The admin role keeps every permission
+	if existing.Name == model.RoleAdmin {
+		return fmt.Errorf("validation failed: the admin role cannot be changed")
+	}
+
+	if err := u.validatePermissions(ctx, role.Permissions); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	role.IsSystem = existing.IsSystem
+	role.CreatedAt = existing.CreatedAt
+	role.UpdatedAt = time.Now()
+
+	return u.roleRepo.UpdateRole(ctx, role)
+}
+
+func (u *roleUsecaseImpl) DeleteRole(ctx context.Context, name string) error {
+	role, err := u.roleRepo.GetRole(ctx, name)
+	if err != nil {
+		return fmt.Errorf("role not found: %w", err)
+	}
+
+	if role.IsSystem {
+		return fmt.Errorf("validation failed: built-in roles cannot be deleted")
+	}
+
+	return u.roleRepo.DeleteRole(ctx, name)
+}
+
+func (u *roleUsecaseImpl) AssignRole(ctx context.Context, actorID, userID, role string) error {
+	if actorID == userID {
+		return fmt.Errorf("validation failed: you cannot change your own role")
+	}
+
+	if _, err := u.userRepo.GetByID(userID); err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+
+	if _, err := u.roleRepo.GetRole(ctx, role); err != nil {
+		return fmt.Errorf("validation failed: unknown role %q", role)
+	}
+
+	return u.roleRepo.SetUserRole(ctx, userID, role)
+}
+
+func (u *roleUsecaseImpl) validatePermissions(ctx context.Context, permissions []string) error {
+	known, err := u.roleRepo.ListPermissions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get permissions: %w", err)
+	}
+
+	names := make(map[string]bool, len(known))
+	for _, p := range known {
+		names[p.Name] = true
+	}
+
+	for _, p := range permissions {
+		if !names[p] {
+			return fmt.Errorf("unknown permission %q", p)
+		}
+	}
+
+	return nil
+}
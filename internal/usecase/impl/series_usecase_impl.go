@@ -0,0 +1,280 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/series"
+	"evently/internal/domain/venue"
+	"evently/internal/domain/waitlist"
+
+	"github.com/google/uuid"
+)
+
+type seriesUsecaseImpl struct {
+	seriesRepo      series.SeriesRepository
+	venueRepo       venue.VenueRepository
+	eventUsecase    events.EventUsecase
+	waitlistUsecase waitlist.WaitlistUsecase
+}
+
+func NewSeriesUsecase(seriesRepo series.SeriesRepository, venueRepo venue.VenueRepository, eventUsecase events.EventUsecase, waitlistUsecase waitlist.WaitlistUsecase) series.SeriesUsecase {
+	return &seriesUsecaseImpl{
+		seriesRepo:      seriesRepo,
+		venueRepo:       venueRepo,
+		eventUsecase:    eventUsecase,
+		waitlistUsecase: waitlistUsecase,
+	}
+}
+
+func (u *seriesUsecaseImpl) CreateSeries(ctx context.Context, newSeries *series.Series) ([]*events.Event, error) {
+	v, err := resolveVenue(ctx, u.venueRepo, newSeries.VenueID, newSeries.Venue, newSeries.TotalCapacity)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	newSeries.VenueID = &v.ID
+	newSeries.Venue = v.Name
+
+	if newSeries.Timezone == "" {
+		newSeries.Timezone = v.Timezone
+	}
+	loc, err := time.LoadLocation(newSeries.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: unknown timezone %q", newSeries.Timezone)
+	}
+
+	rule, err := series.ParseRRule(newSeries.RRule, loc)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: invalid rrule: %w", err)
+	}
+
+	// Occurrences are generated in the series' own zone so that a weekly
+	// 19:00 event stays at 19:00 local time across DST changes
+	times, err := rule.Occurrences(newSeries.StartTime.In(loc), newSeries.Exceptions)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if len(times) == 0 {
+		return nil, fmt.Errorf("validation failed: recurrence rule produces no occurrences")
+	}
+
+	now := time.Now()
+	newSeries.ID = uuid.New().String()
+	newSeries.CreatedAt = now
+	newSeries.UpdatedAt = now
+	if newSeries.Exceptions == nil {
+		newSeries.Exceptions = []time.Time{}
+	}
+
+	occurrences := make([]*events.Event, 0, len(times))
+	for _, eventTime := range times {
+		occurrence := &events.Event{
+			ID:             uuid.New().String(),
+			Name:           newSeries.Name,
+			Description:    newSeries.Description,
+			Venue:          newSeries.Venue,
+			VenueID:        newSeries.VenueID,
+			EventTime:      eventTime,
+			Timezone:       newSeries.Timezone,
+			TotalCapacity:  newSeries.TotalCapacity,
+			AvailableSeats: newSeries.TotalCapacity,
+			Price:          newSeries.Price,
+			CreatedBy:      newSeries.CreatedBy,
+			SeriesID:       &newSeries.ID,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+
+		if err := validateEvent(occurrence); err != nil {
+			return nil, fmt.Errorf("validation failed for occurrence at %s: %w", eventTime.Format(time.RFC3339), err)
+		}
+		occurrence.Localize()
+
+		occurrences = append(occurrences, occurrence)
+	}
+
+	if err := u.seriesRepo.CreateWithOccurrences(ctx, newSeries, occurrences); err != nil {
+		return nil, fmt.Errorf("failed to create series: %w", err)
+	}
+
+	return occurrences, nil
+}
+
+func (u *seriesUsecaseImpl) GetSeries(ctx context.Context, seriesID string) (*series.Series, error) {
+	return u.seriesRepo.GetByID(ctx, seriesID)
+}
+
+func (u *seriesUsecaseImpl) ListSeries(ctx context.Context, limit, offset int) ([]*series.Series, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.seriesRepo.List(ctx, limit, offset)
+}
+
+func (u *seriesUsecaseImpl) ListUpcomingOccurrences(ctx context.Context, seriesID string, limit, offset int) ([]*events.Event, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.seriesRepo.GetOccurrences(ctx, seriesID, time.Now(), limit, offset)
+}
+
+func (u *seriesUsecaseImpl) UpdateOccurrences(ctx context.Context, seriesID, eventID string, scope series.EditScope, update *series.OccurrenceUpdate) ([]*events.Event, error) {
+	existingSeries, err := u.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("series not found: %w", err)
+	}
+
+	target, err := u.eventUsecase.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found: %w", err)
+	}
+	if target.SeriesID == nil || *target.SeriesID != seriesID {
+		return nil, fmt.Errorf("event does not belong to series")
+	}
+
+	var affected []*events.Event
+	switch scope {
+	case series.EditScopeThis:
+		affected = []*events.Event{target}
+	case series.EditScopeFollowing:
+		affected, err = u.seriesRepo.GetOccurrences(ctx, seriesID, target.EventTime, series.MaxOccurrences, 0)
+	case series.EditScopeAll:
+		// Past occurrences are left untouched; they can no longer be edited
+		affected, err = u.seriesRepo.GetOccurrences(ctx, seriesID, time.Now(), series.MaxOccurrences, 0)
+	default:
+		return nil, fmt.Errorf("invalid scope: must be 'this', 'following' or 'all'")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrences: %w", err)
+	}
+
+	// A venue given by name is resolved once; each occurrence still has its
+	// capacity checked against it
+	var newVenue *venue.Venue
+	if update.Venue != nil {
+		newVenue, err = resolveVenue(ctx, u.venueRepo, nil, *update.Venue, 0)
+		if err != nil {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+	}
+
+	// The shift is measured on the target's local calendar and wall clock,
+	// so moving 19:00 to 20:00 gives 20:00 on both sides of a DST change
+	var shiftDays int
+	var shiftClock time.Duration
+	if update.EventTime != nil {
+		shiftDays, shiftClock = wallClockShift(target.EventTime, *update.EventTime, target.Location())
+	}
+
+	for _, occurrence := range affected {
+		applyOccurrenceUpdate(occurrence, update)
+		if newVenue != nil {
+			occurrence.VenueID = &newVenue.ID
+			occurrence.Venue = newVenue.Name
+		}
+		if update.EventTime != nil {
+			occurrence.EventTime = shiftWallClock(occurrence.EventTime, shiftDays, shiftClock, occurrence.Location())
+		}
+
+		if err := prepareEventUpdate(ctx, u.venueRepo, occurrence); err != nil {
+			return nil, err
+		}
+	}
+
+	// Series-wide edits also change the template shown on the series page
+	var updatedSeries *series.Series
+	if scope == series.EditScopeAll {
+		applySeriesUpdate(existingSeries, update)
+		if newVenue != nil {
+			existingSeries.VenueID = &newVenue.ID
+			existingSeries.Venue = newVenue.Name
+		}
+		existingSeries.UpdatedAt = time.Now()
+		updatedSeries = existingSeries
+	}
+
+	// Either every occurrence in scope changes or none does
+	freedSeats, err := u.seriesRepo.UpdateWithOccurrences(ctx, updatedSeries, affected)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, occurrence := range affected {
+		if freedSeats[i] > 0 && u.waitlistUsecase != nil {
+			if err := u.waitlistUsecase.ProcessWaitlistNotifications(ctx, occurrence.ID, freedSeats[i]); err != nil {
+				log.Printf("Failed to process waitlist notifications: %v", err)
+			}
+		}
+	}
+
+	return affected, nil
+}
+
+// wallClockShift returns how far to is from from in calendar days and time
+// of day, both read as local time in loc.
+func wallClockShift(from, to time.Time, loc *time.Location) (int, time.Duration) {
+	from, to = from.In(loc), to.In(loc)
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(toDate.Sub(fromDate).Hours() / 24)
+	return days, timeOfDay(to) - timeOfDay(from)
+}
+
+// shiftWallClock moves t by days and clock on the local calendar in loc.
+func shiftWallClock(t time.Time, days int, clock time.Duration, loc *time.Location) time.Time {
+	local := t.In(loc)
+	wall := timeOfDay(local) + clock
+	return time.Date(local.Year(), local.Month(), local.Day()+days, 0, 0, 0, int(wall), loc)
+}
+
+func timeOfDay(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
+
+func applyOccurrenceUpdate(event *events.Event, update *series.OccurrenceUpdate) {
+	if update.Name != nil {
+		event.Name = *update.Name
+	}
+	if update.Description != nil {
+		event.Description = *update.Description
+	}
+	if update.Venue != nil {
+		event.Venue = *update.Venue
+	}
+	if update.TotalCapacity != nil {
+		event.TotalCapacity = *update.TotalCapacity
+	}
+	if update.Price != nil {
+		event.Price = *update.Price
+	}
+}
+
+func applySeriesUpdate(s *series.Series, update *series.OccurrenceUpdate) {
+	if update.Name != nil {
+		s.Name = *update.Name
+	}
+	if update.Description != nil {
+		s.Description = *update.Description
+	}
+	if update.Venue != nil {
+		s.Venue = *update.Venue
+	}
+	if update.TotalCapacity != nil {
+		s.TotalCapacity = *update.TotalCapacity
+	}
+	if update.Price != nil {
+		s.Price = *update.Price
+	}
+}
@@ -0,0 +1,138 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/series"
+	"evently/internal/domain/venue"
+)
+
+type fakeSeriesRepo struct {
+	series.SeriesRepository
+	series      *series.Series
+	occurrences []*events.Event
+}
+
+func (r *fakeSeriesRepo) GetByID(ctx context.Context, id string) (*series.Series, error) {
+	return r.series, nil
+}
+
+func (r *fakeSeriesRepo) GetOccurrences(ctx context.Context, seriesID string, from time.Time, limit, offset int) ([]*events.Event, error) {
+	var result []*events.Event
+	for _, occurrence := range r.occurrences {
+		if !occurrence.EventTime.Before(from) {
+			result = append(result, occurrence)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeSeriesRepo) UpdateWithOccurrences(ctx context.Context, s *series.Series, occurrences []*events.Event) ([]int, error) {
+	return make([]int, len(occurrences)), nil
+}
+
+type fakeEventUsecase struct {
+	events.EventUsecase
+	events []*events.Event
+}
+
+func (u *fakeEventUsecase) GetEvent(ctx context.Context, eventID string) (*events.Event, error) {
+	for _, event := range u.events {
+		if event.ID == eventID {
+			return event, nil
+		}
+	}
+	return nil, errors.New("event not found")
+}
+
+type fakeSeriesVenueRepo struct {
+	venue.VenueRepository
+}
+
+func (r *fakeSeriesVenueRepo) GetByID(ctx context.Context, id string) (*venue.Venue, error) {
+	return &venue.Venue{ID: id, Name: "Main Hall", Timezone: "Europe/Berlin", MaxCapacity: 1000}, nil
+}
+
+func TestUpdateOccurrencesShiftsWallClock(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// Weekly on Monday at 19:00 in Berlin; DST starts on 28 March 2027
+	mondays := []int{22, 29}
+	newOccurrences := func() []*events.Event {
+		seriesID, venueID := "series-1", "venue-1"
+		var occurrences []*events.Event
+		for _, day := range mondays {
+			occurrences = append(occurrences, &events.Event{
+				ID:            fmt.Sprintf("occurrence-%d", day),
+				Name:          "Jam session",
+				Venue:         "Main Hall",
+				VenueID:       &venueID,
+				EventTime:     time.Date(2027, 3, day, 19, 0, 0, 0, berlin),
+				Timezone:      "Europe/Berlin",
+				TotalCapacity: 100,
+				SeriesID:      &seriesID,
+			})
+		}
+		return occurrences
+	}
+
+	tests := []struct {
+		name    string
+		newTime time.Time
+		want    []time.Time
+	}{
+		{
+			name:    "later in the day",
+			newTime: time.Date(2027, 3, 22, 20, 30, 0, 0, berlin),
+			want: []time.Time{
+				time.Date(2027, 3, 22, 20, 30, 0, 0, berlin),
+				time.Date(2027, 3, 29, 20, 30, 0, 0, berlin),
+			},
+		},
+		{
+			name:    "a week later across DST",
+			newTime: time.Date(2027, 3, 29, 19, 0, 0, 0, berlin),
+			want: []time.Time{
+				time.Date(2027, 3, 29, 19, 0, 0, 0, berlin),
+				time.Date(2027, 4, 5, 19, 0, 0, 0, berlin),
+			},
+		},
+		{
+			name:    "given in another zone",
+			newTime: time.Date(2027, 3, 23, 17, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2027, 3, 23, 18, 0, 0, 0, berlin),
+				time.Date(2027, 3, 30, 18, 0, 0, 0, berlin),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			occurrences := newOccurrences()
+			repo := &fakeSeriesRepo{series: &series.Series{ID: "series-1"}, occurrences: occurrences}
+			u := NewSeriesUsecase(repo, &fakeSeriesVenueRepo{}, &fakeEventUsecase{events: occurrences}, nil)
+
+			updated, err := u.UpdateOccurrences(context.Background(), "series-1", occurrences[0].ID, series.EditScopeFollowing, &series.OccurrenceUpdate{EventTime: &tt.newTime})
+			if err != nil {
+				t.Fatalf("UpdateOccurrences failed: %v", err)
+			}
+			if len(updated) != len(tt.want) {
+				t.Fatalf("updated %d occurrences, want %d", len(updated), len(tt.want))
+			}
+			for i, occurrence := range updated {
+				if !occurrence.EventTime.Equal(tt.want[i]) {
+					t.Errorf("occurrence %d at %s, want %s", i, occurrence.EventTime.In(berlin), tt.want[i])
+				}
+			}
+		})
+	}
+}
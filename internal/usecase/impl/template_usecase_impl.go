@@ -0,0 +1,295 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/pass"
+	"evently/internal/domain/template"
+	"evently/internal/domain/venue"
+
+	"github.com/google/uuid"
+)
+
+type templateUsecaseImpl struct {
+	templateRepo template.TemplateRepository
+	venueRepo    venue.VenueRepository
+	eventUsecase events.EventUsecase
+	passUsecase  pass.PassUsecase
+}
+
+func NewTemplateUsecase(templateRepo template.TemplateRepository, venueRepo venue.VenueRepository, eventUsecase events.EventUsecase, passUsecase pass.PassUsecase) template.TemplateUsecase {
+	return &templateUsecaseImpl{
+		templateRepo: templateRepo,
+		venueRepo:    venueRepo,
+		eventUsecase: eventUsecase,
+		passUsecase:  passUsecase,
+	}
+}
+
+func (u *templateUsecaseImpl) CreateTemplate(ctx context.Context, t *template.Template) error {
+	if err := validateTemplate(t); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	t.ID = uuid.New().String()
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = time.Now()
+
+	return u.templateRepo.Create(ctx, t)
+}
+
+func (u *templateUsecaseImpl) CreateTemplateFromEvent(ctx context.Context, eventID string, t *template.Template) error {
+	event, err := u.eventUsecase.GetEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("event not found: %w", err)
+	}
+
+	t.Config = template.EventConfig{
+		Name:          event.Name,
+		Description:   event.Description,
+		Venue:         event.Venue,
+		VenueID:       event.VenueID,
+		Timezone:      event.Timezone,
+		TotalCapacity: event.TotalCapacity,
+		Price:         event.Price,
+		CategoryID:    event.CategoryID,
+		Tags:          event.Tags,
+	}
+	if t.Name == "" {
+		t.Name = event.Name
+	}
+
+	return u.CreateTemplate(ctx, t)
+}
+
+func (u *templateUsecaseImpl) UpdateTemplate(ctx context.Context, t *template.Template) error {
+	existing, err := u.templateRepo.GetByID(ctx, t.ID)
+	if err != nil {
+		return fmt.Errorf("template not found: %w", err)
+	}
+
+	if err := validateTemplate(t); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	t.CreatedBy = existing.CreatedBy
+	t.CreatedAt = existing.CreatedAt
+	t.UpdatedAt = time.Now()
+
+	return u.templateRepo.Update(ctx, t)
+}
+
+func (u *templateUsecaseImpl) DeleteTemplate(ctx context.Context, templateID string) error {
+	return u.templateRepo.Delete(ctx, templateID)
+}
+
+func (u *templateUsecaseImpl) GetTemplate(ctx context.Context, templateID string) (*template.Template, error) {
+	return u.templateRepo.GetByID(ctx, templateID)
+}
+
+func (u *templateUsecaseImpl) ListTemplates(ctx context.Context, limit, offset int) ([]*template.Template, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.templateRepo.List(ctx, limit, offset)
+}
+
+func (u *templateUsecaseImpl) CreateEventFromTemplate(ctx context.Context, templateID string, overrides *template.Overrides, createdBy string) (*events.Event, error) {
+	t, err := u.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("template not found: %w", err)
+	}
+
+	cfg := t.Config
+	applyTemplateOverrides(&cfg, overrides)
+
+	event := &events.Event{
+		Name:          cfg.Name,
+		Description:   cfg.Description,
+		Venue:         cfg.Venue,
+		VenueID:       cfg.VenueID,
+		EventTime:     overrides.EventTime,
+		Timezone:      cfg.Timezone,
+		TotalCapacity: cfg.TotalCapacity,
+		Price:         cfg.Price,
+		CategoryID:    cfg.CategoryID,
+		Tags:          cfg.Tags,
+		CreatedBy:     createdBy,
+	}
+
+	if err := u.eventUsecase.CreateEvent(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (u *templateUsecaseImpl) CloneEvent(ctx context.Context, eventID string, eventTime time.Time, createdBy string) (*events.Event, error) {
+	source, err := u.eventUsecase.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found: %w", err)
+	}
+	if source.ParentEventID != nil {
+		return nil, fmt.Errorf("validation failed: sessions cannot be cloned on their own, clone the parent event")
+	}
+
+	// Sessions and sale windows keep their position relative to the event
+	shift := eventTime.Sub(source.EventTime)
+
+	clone := cloneEventConfig(source, eventTime, createdBy)
+	clone.OnSaleAt = shiftTime(source.OnSaleAt, shift)
+	clone.OffSaleAt = shiftTime(source.OffSaleAt, shift)
+	if err := prepareNewEvent(ctx, u.venueRepo, clone); err != nil {
+		return nil, err
+	}
+
+	sessions, err := u.eventUsecase.ListSessions(ctx, source.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	sessionIDs := make(map[string]string, len(sessions))
+	copiedSessions := make([]*events.Event, 0, len(sessions))
+	for _, session := range sessions {
+		copied := cloneEventConfig(session, session.EventTime.Add(shift), createdBy)
+		copied.OnSaleAt = shiftTime(session.OnSaleAt, shift)
+		copied.OffSaleAt = shiftTime(session.OffSaleAt, shift)
+		if err := prepareNewEvent(ctx, u.venueRepo, copied); err != nil {
+			return nil, err
+		}
+		copied.ParentEventID = &clone.ID
+		sessionIDs[session.ID] = copied.ID
+		copiedSessions = append(copiedSessions, copied)
+	}
+
+	passes, err := u.passUsecase.ListEventPasses(ctx, source.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get passes: %w", err)
+	}
+
+	now := time.Now()
+	copiedPasses := make([]*pass.Pass, 0, len(passes))
+	for _, p := range passes {
+		copied := &pass.Pass{
+			ID:          uuid.New().String(),
+			EventID:     clone.ID,
+			Name:        p.Name,
+			Description: p.Description,
+			Price:       p.Price,
+			OnSaleAt:    shiftTime(p.OnSaleAt, shift),
+			OffSaleAt:   shiftTime(p.OffSaleAt, shift),
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		for _, sessionID := range p.SessionIDs {
+			copied.SessionIDs = append(copied.SessionIDs, sessionIDs[sessionID])
+		}
+		if err := validatePass(copied); err != nil {
+			return nil, fmt.Errorf("validation failed: pass %s: %w", p.ID, err)
+		}
+		copiedPasses = append(copiedPasses, copied)
+	}
+
+	// Either the whole event is cloned or nothing is
+	if err := u.templateRepo.CreateClone(ctx, clone, copiedSessions, copiedPasses); err != nil {
+		return nil, err
+	}
+
+	return clone, nil
+}
+
+// cloneEventConfig copies the configuration of an event. Seat counts start
+// from the full capacity and series membership is not carried over.
+func cloneEventConfig(source *events.Event, eventTime time.Time, createdBy string) *events.Event {
+	tags := make([]string, len(source.Tags))
+	copy(tags, source.Tags)
+
+	return &events.Event{
+		Name:          source.Name,
+		Description:   source.Description,
+		Venue:         source.Venue,
+		VenueID:       source.VenueID,
+		EventTime:     eventTime,
+		Timezone:      source.Timezone,
+		TotalCapacity: source.TotalCapacity,
+		Price:         source.Price,
+		CategoryID:    source.CategoryID,
+		Tags:          tags,
+		CreatedBy:     createdBy,
+	}
+}
+
+// shiftTime moves an optional time by d.
+func shiftTime(t *time.Time, d time.Duration) *time.Time {
+	if t == nil {
+		return nil
+	}
+	shifted := t.Add(d)
+	return &shifted
+}
+
+func applyTemplateOverrides(cfg *template.EventConfig, overrides *template.Overrides) {
+	if overrides.Name != nil {
+		cfg.Name = *overrides.Name
+	}
+	if overrides.Description != nil {
+		cfg.Description = *overrides.Description
+	}
+	// A venue override replaces both the name and the ID, and the time zone
+	// then follows the new venue unless it is overridden too
+	if overrides.VenueID != nil {
+		cfg.VenueID = overrides.VenueID
+		cfg.Venue = ""
+		cfg.Timezone = ""
+	} else if overrides.Venue != nil {
+		cfg.VenueID = nil
+		cfg.Venue = *overrides.Venue
+		cfg.Timezone = ""
+	}
+	if overrides.Timezone != nil {
+		cfg.Timezone = *overrides.Timezone
+	}
+	if overrides.TotalCapacity != nil {
+		cfg.TotalCapacity = *overrides.TotalCapacity
+	}
+	if overrides.Price != nil {
+		cfg.Price = *overrides.Price
+	}
+	if overrides.CategoryID != nil {
+		cfg.CategoryID = overrides.CategoryID
+	}
+	if overrides.Tags != nil {
+		cfg.Tags = *overrides.Tags
+	}
+}
+
+func validateTemplate(t *template.Template) error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return fmt.Errorf("template name is required")
+	}
+
+	if t.Config.TotalCapacity < 0 {
+		return fmt.Errorf("template capacity cannot be negative")
+	}
+
+	if t.Config.Price < 0 {
+		return fmt.Errorf("template price cannot be negative")
+	}
+
+	if t.Config.Timezone != "" {
+		if _, err := time.LoadLocation(t.Config.Timezone); err != nil {
+			return fmt.Errorf("unknown timezone %q", t.Config.Timezone)
+		}
+	}
+
+	return nil
+}
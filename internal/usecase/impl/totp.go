@@ -0,0 +1,119 @@
+package impl
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+
+	"evently/internal/domain/mfa"
+)
+
+// TOTP as in RFC 6238 with the parameters authenticator apps default to:
+// HMAC-SHA1, 6 digits, 30 second steps. One step of clock drift either way
+// is accepted.
+const (
+	totpIssuer = "Evently"
+	totpDigits = 6
+	totpPeriod = 30
+	totpSkew   = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// totpModulus keeps the last totpDigits digits of a code.
+var totpModulus = uint32(math.Pow10(totpDigits))
+
+func newTOTPSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+func totpURI(account, secret string) string {
+	label := url.PathEscape(totpIssuer + ":" + account)
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", totpIssuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// totpCode computes the code for a time step (RFC 4226 section 5.3).
+func totpCode(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%totpModulus)
+}
+
+// verifyTOTP returns the time step the code belongs to.
+func verifyTOTP(secret, code string, now time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// newRecoveryCodes returns codes like "k3vq-7mzp" and their hashes.
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	for range mfa.RecoveryCodeCount {
+		raw := make([]byte, 5)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(encoding.EncodeToString(raw))
+		codes = append(codes, code[:4]+"-"+code[4:])
+		hashes = append(hashes, hashToken(code))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode accepts codes with any case, spacing or dashes.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// checkSecondFactor accepts a current TOTP code or an unused recovery code
+// for an enabled enrollment, and reports which one it was.
+func checkSecondFactor(ctx context.Context, mfaRepo mfa.MFARepository, enrollment *mfa.Enrollment, code string, now time.Time) (string, bool, error) {
+	code = strings.TrimSpace(code)
+
+	if step, ok := verifyTOTP(enrollment.Secret, code, now); ok {
+		// A code works once, even within its 30 seconds
+		fresh, err := mfaRepo.UseStep(ctx, enrollment.UserID, step)
+		return "totp", fresh, err
+	}
+
+	used, err := mfaRepo.UseRecoveryCode(ctx, enrollment.UserID, hashToken(normalizeRecoveryCode(code)), now)
+	return "recovery_code", used, err
+}
@@ -0,0 +1,143 @@
+package impl
+
+import (
+	"encoding/base32"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The shared secret of the RFC 4226 and RFC 6238 test vectors.
+var rfcSecret = []byte("12345678901234567890")
+
+func TestTOTPCodeRFC4226(t *testing.T) {
+	// RFC 4226 Appendix D
+	want := []string{
+		"755224", "287082", "359152", "969429", "338314",
+		"254676", "287922", "162583", "399871", "520489",
+	}
+
+	for counter, code := range want {
+		if got := totpCode(rfcSecret, int64(counter)); got != code {
+			t.Errorf("counter %d: got %s, want %s", counter, got, code)
+		}
+	}
+}
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	// RFC 6238 Appendix B, SHA1, truncated to our six digits
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	for _, tt := range tests {
+		if got := totpCode(rfcSecret, tt.unix/totpPeriod); got != tt.want {
+			t.Errorf("T=%d: got %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	secret := totpEncoding.EncodeToString(rfcSecret)
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / totpPeriod
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", secret: secret, code: totpCode(rfcSecret, current), wantStep: current, wantOK: true},
+		{name: "previous step", secret: secret, code: totpCode(rfcSecret, current-1), wantStep: current - 1, wantOK: true},
+		{name: "next step", secret: secret, code: totpCode(rfcSecret, current+1), wantStep: current + 1, wantOK: true},
+		{name: "too old", secret: secret, code: totpCode(rfcSecret, current-2)},
+		{name: "too new", secret: secret, code: totpCode(rfcSecret, current+2)},
+		{name: "wrong length", secret: secret, code: "50471"},
+		{name: "bad secret", secret: "not base32!", code: totpCode(rfcSecret, current)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := verifyTOTP(tt.secret, tt.code, now)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("verifyTOTP = (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	secret, err := newTOTPSecret()
+	if err != nil {
+		t.Fatalf("newTOTPSecret failed: %v", err)
+	}
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("secret %q is not base32: %v", secret, err)
+	}
+	if len(key) != 20 {
+		t.Errorf("secret has %d bytes, want 20", len(key))
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri, err := url.Parse(totpURI("ada@example.com", "JBSWY3DPEHPK3PXP"))
+	if err != nil {
+		t.Fatalf("invalid URI: %v", err)
+	}
+
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Evently:ada@example.com" {
+		t.Errorf("URI = %s", uri)
+	}
+	query := uri.Query()
+	for key, want := range map[string]string{
+		"secret": "JBSWY3DPEHPK3PXP", "issuer": "Evently", "algorithm": "SHA1", "digits": "6", "period": "30",
+	} {
+		if got := query.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		t.Fatalf("newRecoveryCodes failed: %v", err)
+	}
+	if len(codes) != len(hashes) || len(codes) == 0 {
+		t.Fatalf("got %d codes and %d hashes", len(codes), len(hashes))
+	}
+
+	format := regexp.MustCompile(`^[a-z2-7]{4}-[a-z2-7]{4}$`)
+	seen := map[string]bool{}
+	for i, code := range codes {
+		if !format.MatchString(code) {
+			t.Errorf("code %q does not look like xxxx-xxxx", code)
+		}
+		if seen[code] {
+			t.Errorf("code %q repeats", code)
+		}
+		seen[code] = true
+
+		// However the user types it, the code matches its hash
+		typed := " " + strings.ToUpper(strings.ReplaceAll(code, "-", " - ")) + " "
+		if hashToken(normalizeRecoveryCode(strings.TrimSpace(typed))) != hashes[i] {
+			t.Errorf("code %q typed as %q does not match its hash", code, typed)
+		}
+		if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(normalizeRecoveryCode(code))); err != nil {
+			t.Errorf("code %q is not base32: %v", code, err)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"evently/internal/domain/audit"
+	"evently/internal/domain/auth"
+	"evently/internal/domain/model"
+	"evently/internal/domain/usecase"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// phonePattern is an E.164 number such as +4915112345678.
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+type userUsecaseImpl struct {
+	userRepo         model.UserRepository
+	refreshTokenRepo auth.RefreshTokenRepository
+	loginAttemptRepo auth.LoginAttemptRepository
+	auditRepo        audit.AuditRepository
+	authUsecase      usecase.AuthUseCase
+	accountUsecase   auth.AccountUsecase
+}
+
+func NewUserUsecase(userRepo model.UserRepository, refreshTokenRepo auth.RefreshTokenRepository, loginAttemptRepo auth.LoginAttemptRepository, auditRepo audit.AuditRepository, authUsecase usecase.AuthUseCase, accountUsecase auth.AccountUsecase) usecase.UserUseCase {
+	return &userUsecaseImpl{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		auditRepo:        auditRepo,
+		authUsecase:      authUsecase,
+		accountUsecase:   accountUsecase,
+	}
+}
+
+func (u *userUsecaseImpl) GetProfile(ctx context.Context, userID string) (*model.Profile, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	summary, err := u.userRepo.GetActivitySummary(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity summary: %w", err)
+	}
+
+	return &model.Profile{User: user, Summary: summary}, nil
+}
+
+func (u *userUsecaseImpl) UpdateProfile(ctx context.Context, userID string, req *model.UpdateProfileRequest, ip string) (*model.User, *auth.TokenPair, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("user not found")
+	}
+
+	var name, email string
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > 255 {
+			return nil, nil, fmt.Errorf("validation failed: name must be 1-255 characters")
+		}
+	}
+	if req.Email != nil {
+		email = strings.TrimSpace(*req.Email)
+		if email == "" || !strings.Contains(email, "@") {
+			return nil, nil, fmt.Errorf("validation failed: a valid email is required")
+		}
+		if email == user.Email {
+			email = ""
+		} else if existing, _ := u.userRepo.GetByEmail(email); existing != nil {
+			return nil, nil, fmt.Errorf("validation failed: user with email %s already exists", email)
+		}
+	}
+	var phone *string
+	if req.Phone != nil {
+		number := strings.Join(strings.Fields(*req.Phone), "")
+		if number != "" && !phonePattern.MatchString(number) {
+			return nil, nil, fmt.Errorf("validation failed: phone must be in international format, e.g. +4915112345678")
+		}
+		if number != "" {
+			phone = &number
+		}
+	}
+	if req.Password != nil && len(*req.Password) < minPasswordLength {
+		return nil, nil, fmt.Errorf("validation failed: password must be at least %d characters", minPasswordLength)
+	}
+
+	// Whoever holds a stolen token must not be able to take the account
+	// over, so the sensitive changes ask for the password again
+	if email != "" || req.Password != nil {
+		if err := checkPassword(ctx, u.loginAttemptRepo, user, req.CurrentPassword, ip); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if name != "" && name != user.Name {
+		if err := u.userRepo.UpdateName(user.ID, name); err != nil {
+			return nil, nil, fmt.Errorf("failed to update name: %w", err)
+		}
+		user.Name = name
+	}
+
+	if req.Phone != nil {
+		if err := u.userRepo.UpdatePhone(user.ID, phone); err != nil {
+			return nil, nil, fmt.Errorf("failed to update phone: %w", err)
+		}
+		user.Phone = phone
+	}
+
+	if email != "" {
+		if err := u.accountUsecase.RequestEmailChange(ctx, user.ID, email); err != nil {
+			return nil, nil, err
+		}
+		user.PendingEmail = &email
+		if err := u.audit(ctx, user.ID, user.ID, audit.ActionUserEmailChangeRequested, ip, map[string]any{"email": email}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var tokens *auth.TokenPair
+	if req.Password != nil {
+		tokens, err = u.changePassword(ctx, user, *req.Password, ip)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return user, tokens, nil
+}
+
+// checkPassword verifies the password of a signed in user who is about to
+// do something sensitive. Failures count like failed logins.
+func checkPassword(ctx context.Context, loginAttemptRepo auth.LoginAttemptRepository, user *model.User, password, ip string) error {
+	now := time.Now()
+	key := strings.ToLower(strings.TrimSpace(user.Email))
+	attempt, err := beginLoginAttempt(ctx, loginAttemptRepo, key, ip, now)
+	if err != nil {
+		return err
+	}
+
+	// A failed attempt stays counted
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+		return auth.ErrInvalidCredentials
+	}
+
+	forgetLoginAttempt(ctx, loginAttemptRepo, attempt)
+	return nil
+}
+
+// changePassword sets the new password and signs out every session. The
+// tokens of a fresh session are returned when one can be started; a user who
+// still has to set up two-factor gets none and signs in again instead.
+func (u *userUsecaseImpl) changePassword(ctx context.Context, user *model.User, password, ip string) (*auth.TokenPair, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	entry := userAuditEntry(user.ID, user.ID, audit.ActionUserPasswordChanged, ip, nil)
+	if err := u.userRepo.ChangePassword(ctx, user.ID, string(hashedPassword), time.Now(), entry); err != nil {
+		return nil, fmt.Errorf("failed to change password: %w", err)
+	}
+
+	// The password has changed either way, so this is not an error
+	tokens, err := u.authUsecase.StartSession(ctx, user)
+	if err != nil {
+		var enroll *auth.MFAEnrollmentRequiredError
+		if !errors.As(err, &enroll) {
+			log.Printf("Failed to start a session after a password change: %v", err)
+		}
+		return nil, nil
+	}
+
+	return tokens, nil
+}
+
+func (u *userUsecaseImpl) ListUsers(ctx context.Context, filter model.UserFilter) ([]*model.User, int, error) {
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 50
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Status != "" && filter.Status != "active" && filter.Status != "disabled" {
+		return nil, 0, fmt.Errorf("validation failed: status must be active or disabled")
+	}
+
+	return u.userRepo.List(filter)
+}
+
+func (u *userUsecaseImpl) DisableUser(ctx context.Context, actorID, userID, ip string) error {
+	if actorID == userID {
+		return fmt.Errorf("validation failed: you cannot disable your own account")
+	}
+
+	now := time.Now()
+	if err := u.userRepo.SetDisabled(userID, &now); err != nil {
+		return err
+	}
+
+	// Access tokens are refused by the middleware; this ends the sessions
+	// for good
+	if _, err := u.refreshTokenRepo.RevokeAllForUser(ctx, userID, now); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return u.audit(ctx, actorID, userID, audit.ActionUserDisabled, ip, nil)
+}
+
+func (u *userUsecaseImpl) EnableUser(ctx context.Context, actorID, userID, ip string) error {
+	if err := u.userRepo.SetDisabled(userID, nil); err != nil {
+		return err
+	}
+
+	return u.audit(ctx, actorID, userID, audit.ActionUserEnabled, ip, nil)
+}
+
+func (u *userUsecaseImpl) IsDisabled(ctx context.Context, userID string) (bool, error) {
+	user, err := u.userRepo.GetByID(userID)
+	if err != nil {
+		return false, fmt.Errorf("user not found: %w", err)
+	}
+
+	return user.DisabledAt != nil, nil
+}
+
+func (u *userUsecaseImpl) audit(ctx context.Context, actorID, userID, action, ip string, metadata map[string]any) error {
+	if err := u.auditRepo.Create(ctx, userAuditEntry(actorID, userID, action, ip, metadata)); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+func userAuditEntry(actorID, userID, action, ip string, metadata map[string]any) *audit.Entry {
+	return &audit.Entry{
+		ID:         uuid.New().String(),
+		ActorID:    &actorID,
+		Action:     action,
+		TargetType: "user",
+		TargetID:   userID,
+		IP:         ip,
+		Metadata:   metadata,
+		CreatedAt:  time.Now(),
+	}
+}
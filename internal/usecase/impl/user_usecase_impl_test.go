@@ -0,0 +1,81 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"evently/internal/domain/audit"
+	"evently/internal/domain/auth"
+	"evently/internal/domain/model"
+	"evently/internal/domain/usecase"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakePasswordUserRepo records password changes, which the real repository
+// makes in one transaction.
+type fakePasswordUserRepo struct {
+	fakeUserRepo
+	changes []*audit.Entry
+}
+
+func (r *fakePasswordUserRepo) ChangePassword(ctx context.Context, id, hashedPassword string, at time.Time, entry *audit.Entry) error {
+	r.users[id].Password = hashedPassword
+	r.changes = append(r.changes, entry)
+	return nil
+}
+
+type fakeFailingSessionStarter struct {
+	usecase.AuthUseCase
+	err error
+}
+
+func (f *fakeFailingSessionStarter) StartSession(ctx context.Context, user *model.User) (*auth.TokenPair, error) {
+	return nil, f.err
+}
+
+func TestUpdateProfilePassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		sessions   usecase.AuthUseCase
+		wantTokens bool
+	}{
+		{name: "new session", sessions: &fakeSessionStarter{}, wantTokens: true},
+		{name: "two-factor enrollment required", sessions: &fakeFailingSessionStarter{err: &auth.MFAEnrollmentRequiredError{MFAToken: "step"}}},
+		{name: "session cannot start", sessions: &fakeFailingSessionStarter{err: errors.New("database down")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, err := bcrypt.GenerateFromPassword([]byte("old password"), bcrypt.MinCost)
+			if err != nil {
+				t.Fatalf("failed to hash password: %v", err)
+			}
+			users := &fakePasswordUserRepo{fakeUserRepo: fakeUserRepo{users: map[string]*model.User{
+				"user-1": {ID: "user-1", Email: "organizer@example.com", Password: string(current)},
+			}}}
+			u := NewUserUsecase(users, nil, &fakeLoginAttemptRepo{}, nil, tt.sessions, nil)
+
+			password := "new password"
+			_, tokens, err := u.UpdateProfile(context.Background(), "user-1", &model.UpdateProfileRequest{
+				Password:        &password,
+				CurrentPassword: "old password",
+			}, "127.0.0.1")
+			if err != nil {
+				t.Fatalf("UpdateProfile failed: %v", err)
+			}
+			if (tokens != nil) != tt.wantTokens {
+				t.Errorf("tokens = %v, want tokens %v", tokens, tt.wantTokens)
+			}
+
+			if len(users.changes) != 1 || users.changes[0].Action != audit.ActionUserPasswordChanged {
+				t.Fatalf("password changes = %+v", users.changes)
+			}
+			if bcrypt.CompareHashAndPassword([]byte(users.users["user-1"].Password), []byte(password)) != nil {
+				t.Error("the new password was not stored")
+			}
+		})
+	}
+}
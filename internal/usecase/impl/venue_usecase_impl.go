@@ -0,0 +1,166 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"evently/internal/domain/venue"
+
+	"github.com/google/uuid"
+)
+
+type venueUsecaseImpl struct {
+	venueRepo venue.VenueRepository
+}
+
+func NewVenueUsecase(venueRepo venue.VenueRepository) venue.VenueUsecase {
+	return &venueUsecaseImpl{
+		venueRepo: venueRepo,
+	}
+}
+
+func (u *venueUsecaseImpl) CreateVenue(ctx context.Context, v *venue.Venue) error {
+	if err := validateVenue(v); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if _, err := u.venueRepo.GetByName(ctx, v.Name); err == nil {
+		return fmt.Errorf("validation failed: venue %q already exists", v.Name)
+	}
+
+	v.ID = uuid.New().String()
+	v.CreatedAt = time.Now()
+	v.UpdatedAt = time.Now()
+
+	return u.venueRepo.Create(ctx, v)
+}
+
+func (u *venueUsecaseImpl) UpdateVenue(ctx context.Context, v *venue.Venue) error {
+	existing, err := u.venueRepo.GetByID(ctx, v.ID)
+	if err != nil {
+		return fmt.Errorf("venue not found: %w", err)
+	}
+
+	if err := validateVenue(v); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if other, err := u.venueRepo.GetByName(ctx, v.Name); err == nil && other.ID != v.ID {
+		return fmt.Errorf("validation failed: venue %q already exists", v.Name)
+	}
+
+	// Shrinking a venue must not leave already scheduled events oversized
+	largest, err := u.venueRepo.MaxUpcomingCapacity(ctx, v.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check upcoming events: %w", err)
+	}
+	if v.MaxCapacity < largest {
+		return fmt.Errorf("%w: an upcoming event at this venue has %d seats", venue.ErrCapacityExceeded, largest)
+	}
+
+	v.CreatedAt = existing.CreatedAt
+	v.UpdatedAt = time.Now()
+
+	return u.venueRepo.Update(ctx, v)
+}
+
+func (u *venueUsecaseImpl) DeleteVenue(ctx context.Context, venueID string) error {
+	return u.venueRepo.Delete(ctx, venueID)
+}
+
+func (u *venueUsecaseImpl) GetVenue(ctx context.Context, venueID string) (*venue.Venue, error) {
+	return u.venueRepo.GetByID(ctx, venueID)
+}
+
+func (u *venueUsecaseImpl) ListVenues(ctx context.Context, search string, limit, offset int) ([]*venue.Venue, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.venueRepo.List(ctx, strings.TrimSpace(search), limit, offset)
+}
+
+func (u *venueUsecaseImpl) FindNearbyEvents(ctx context.Context, lat, lng, radiusKM float64, limit, offset int) ([]*venue.NearbyEvent, error) {
+	if lat < -90 || lat > 90 {
+		return nil, fmt.Errorf("invalid lat: must be between -90 and 90")
+	}
+	if lng < -180 || lng > 180 {
+		return nil, fmt.Errorf("invalid lng: must be between -180 and 180")
+	}
+	if radiusKM <= 0 {
+		radiusKM = 10
+	}
+	if radiusKM > 500 {
+		return nil, fmt.Errorf("invalid radius_km: must not exceed 500")
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.venueRepo.FindNearbyEvents(ctx, lat, lng, radiusKM, limit, offset)
+}
+
+func validateVenue(v *venue.Venue) error {
+	v.Name = strings.Join(strings.Fields(v.Name), " ")
+	if v.Name == "" {
+		return fmt.Errorf("venue name is required")
+	}
+
+	if v.MaxCapacity <= 0 {
+		return fmt.Errorf("venue max capacity must be positive")
+	}
+
+	if v.Timezone == "" {
+		v.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(v.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", v.Timezone)
+	}
+
+	if (v.Latitude == nil) != (v.Longitude == nil) {
+		return fmt.Errorf("latitude and longitude must be given together")
+	}
+	if v.Latitude != nil && (*v.Latitude < -90 || *v.Latitude > 90) {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if v.Longitude != nil && (*v.Longitude < -180 || *v.Longitude > 180) {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+
+	return nil
+}
+
+// resolveVenue finds the venue an event or series is held at, by venue_id
+// or else by name, and checks that the requested capacity fits in it.
+func resolveVenue(ctx context.Context, venueRepo venue.VenueRepository, venueID *string, name string, capacity int) (*venue.Venue, error) {
+	var (
+		v   *venue.Venue
+		err error
+	)
+	switch {
+	case venueID != nil && *venueID != "":
+		v, err = venueRepo.GetByID(ctx, *venueID)
+	case strings.TrimSpace(name) != "":
+		v, err = venueRepo.GetByName(ctx, name)
+	default:
+		return nil, fmt.Errorf("event venue is required")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("venue not found, create it before scheduling events there: %w", err)
+	}
+
+	if capacity > v.MaxCapacity {
+		return nil, fmt.Errorf("%w: capacity %d is above the %d seats of %s",
+			venue.ErrCapacityExceeded, capacity, v.MaxCapacity, v.Name)
+	}
+
+	return v, nil
+}
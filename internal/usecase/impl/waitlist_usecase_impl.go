@@ -7,6 +7,7 @@ import (
 
 	"evently/internal/domain/events"
 	"evently/internal/domain/model"
+	"evently/internal/domain/presale"
 	"evently/internal/domain/waitlist"
 
 	"github.com/google/uuid"
@@ -15,22 +16,28 @@ import (
 type waitlistUsecaseImpl struct {
 	waitlistRepo     waitlist.WaitlistRepository
 	eventRepo        events.EventRepository
+	presaleRepo      presale.PresaleRepository
+	accessCodeKey    AccessCodeKey
 	notificationRepo model.NotificationRepository
 }
 
 func NewWaitlistUsecase(
 	waitlistRepo waitlist.WaitlistRepository,
 	eventRepo events.EventRepository,
+	presaleRepo presale.PresaleRepository,
+	accessCodeKey AccessCodeKey,
 	notificationRepo model.NotificationRepository,
 ) waitlist.WaitlistUsecase {
 	return &waitlistUsecaseImpl{
 		waitlistRepo:     waitlistRepo,
 		eventRepo:        eventRepo,
+		presaleRepo:      presaleRepo,
+		accessCodeKey:    accessCodeKey,
 		notificationRepo: notificationRepo,
 	}
 }
 
-func (u *waitlistUsecaseImpl) JoinWaitlist(ctx context.Context, userID, eventID string, quantity int) error {
+func (u *waitlistUsecaseImpl) JoinWaitlist(ctx context.Context, userID, eventID string, quantity int, accessCode string) error {
 	// Validate input
 	if quantity <= 0 {
 		return fmt.Errorf("quantity must be positive")
@@ -46,10 +53,17 @@ func (u *waitlistUsecaseImpl) JoinWaitlist(ctx context.Context, userID, eventID
 	}
 
 	// Check if event is in the future
-	if event.EventTime.Before(time.Now()) {
+	if event.HasStarted(time.Now()) {
 		return fmt.Errorf("cannot join waitlist for past events")
 	}
 
+	// The waitlist follows the same sale window as bookings
+	err = checkSalesOpen(ctx, u.presaleRepo, u.accessCodeKey, event.ID, event.OnSaleAt, event.OffSaleAt,
+		userID, accessCode, time.Now())
+	if err != nil {
+		return err
+	}
+
 	// Check if user is already on waitlist for this event
 	existing, err := u.waitlistRepo.GetByUserAndEvent(userID, eventID)
 	if err == nil && existing != nil {
@@ -0,0 +1,261 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"evently/internal/domain/model"
+	"evently/internal/domain/oidc"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuer   = "https://idp.example.com"
+	testClientID = "evently"
+	testNonce    = "nonce-1"
+)
+
+// keyServer publishes a mutable key set and counts how often it is read.
+type keyServer struct {
+	mu      sync.Mutex
+	keys    []jsonWebKey
+	fetches int
+}
+
+func (s *keyServer) publish(jwk jsonWebKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = append(s.keys, jwk)
+}
+
+func (s *keyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetches++
+	json.NewEncoder(w).Encode(map[string]any{"keys": s.keys})
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) jsonWebKey {
+	return jsonWebKey{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func newTestProvider(t *testing.T, keys *keyServer) (*httpProvider, *discoveryDocument) {
+	t.Helper()
+	server := httptest.NewServer(keys)
+	t.Cleanup(server.Close)
+
+	provider, err := NewProvider(model.OIDCConfig{
+		Issuer:      testIssuer,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost:8080/api/auth/oidc/callback",
+		GroupsClaim: "groups",
+	})
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+	return provider.(*httpProvider), &discoveryDocument{Issuer: testIssuer, JWKSURI: server.URL}
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            testIssuer,
+		"aud":            testClientID,
+		"sub":            "subject-1",
+		"nonce":          testNonce,
+		"email":          "staff@example.com",
+		"email_verified": true,
+		"name":           "Staff Member",
+		"groups":         []string{"evently-admins", "staff"},
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+	}
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key crypto.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	keys := &keyServer{}
+	keys.publish(rsaJWK("rsa-1", rsaKey))
+	keys.publish(jsonWebKey{Kty: "OKP", Kid: "ed-1", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(edPublic)})
+	keys.publish(jsonWebKey{Kty: "RSA", Kid: "enc-1", Use: "enc", N: rsaJWK("", otherKey).N, E: "AQAB"})
+
+	with := func(changes map[string]any) jwt.MapClaims {
+		claims := validClaims()
+		for name, value := range changes {
+			if value == nil {
+				delete(claims, name)
+			} else {
+				claims[name] = value
+			}
+		}
+		return claims
+	}
+	rs256 := func(claims jwt.MapClaims) func(t *testing.T) string {
+		return func(t *testing.T) string { return signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims) }
+	}
+
+	tests := []struct {
+		name       string
+		token      func(t *testing.T) string
+		wantErr    string
+		wantGroups []string
+		wantEmail  bool
+	}{
+		{name: "valid", token: rs256(validClaims()), wantGroups: []string{"evently-admins", "staff"}, wantEmail: true},
+		{
+			name: "ed25519 with string claims",
+			token: func(t *testing.T) string {
+				return signToken(t, jwt.SigningMethodEdDSA, "ed-1", edKey, with(map[string]any{"email_verified": "true", "groups": "staff"}))
+			},
+			wantGroups: []string{"staff"},
+			wantEmail:  true,
+		},
+		{name: "unverified email", token: rs256(with(map[string]any{"email_verified": "false", "groups": nil})), wantEmail: false},
+		{name: "several audiences", token: rs256(with(map[string]any{"aud": []string{"other", testClientID}, "azp": testClientID})), wantGroups: []string{"evently-admins", "staff"}, wantEmail: true},
+		{name: "issued to another party", token: rs256(with(map[string]any{"aud": []string{"other", testClientID}, "azp": "other"})), wantErr: "issued to"},
+		{name: "wrong issuer", token: rs256(with(map[string]any{"iss": "https://evil.example.com"})), wantErr: "issuer"},
+		{name: "wrong audience", token: rs256(with(map[string]any{"aud": "other"})), wantErr: "audience"},
+		{name: "expired", token: rs256(with(map[string]any{"exp": time.Now().Add(-2 * time.Minute).Unix()})), wantErr: "expired"},
+		{name: "no expiry", token: rs256(with(map[string]any{"exp": nil})), wantErr: "exp"},
+		{name: "issued in the future", token: rs256(with(map[string]any{"iat": time.Now().Add(5 * time.Minute).Unix()})), wantErr: "before issued"},
+		{name: "nonce mismatch", token: rs256(with(map[string]any{"nonce": "other"})), wantErr: "nonce"},
+		{name: "no nonce", token: rs256(with(map[string]any{"nonce": nil})), wantErr: "nonce"},
+		{name: "no subject", token: rs256(with(map[string]any{"sub": nil})), wantErr: "subject"},
+		{
+			name: "signed with another key",
+			token: func(t *testing.T) string {
+				return signToken(t, jwt.SigningMethodRS256, "rsa-1", otherKey, validClaims())
+			},
+			wantErr: "verification",
+		},
+		{
+			name: "encryption key",
+			token: func(t *testing.T) string {
+				return signToken(t, jwt.SigningMethodRS256, "enc-1", otherKey, validClaims())
+			},
+			wantErr: "unknown signing key",
+		},
+		{
+			name: "symmetric algorithm",
+			token: func(t *testing.T) string {
+				return signToken(t, jwt.SigningMethodHS256, "rsa-1", []byte("secret"), validClaims())
+			},
+			wantErr: "signing method",
+		},
+	}
+
+	provider, doc := newTestProvider(t, keys)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := provider.verifyIDToken(context.Background(), doc, tt.token(t), testNonce)
+			if tt.wantErr != "" {
+				if !errors.Is(err, oidc.ErrLoginRejected) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("verifyIDToken error = %v, want a rejection mentioning %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verifyIDToken failed: %v", err)
+			}
+			if claims.Issuer != testIssuer || claims.Subject != "subject-1" || claims.Email != "staff@example.com" || claims.Name != "Staff Member" {
+				t.Errorf("claims = %+v", claims)
+			}
+			if claims.EmailVerified != tt.wantEmail {
+				t.Errorf("EmailVerified = %v, want %v", claims.EmailVerified, tt.wantEmail)
+			}
+			if strings.Join(claims.Groups, ",") != strings.Join(tt.wantGroups, ",") {
+				t.Errorf("Groups = %v, want %v", claims.Groups, tt.wantGroups)
+			}
+		})
+	}
+
+	if keys.fetches != 1 {
+		t.Errorf("keys fetched %d times, want 1", keys.fetches)
+	}
+}
+
+func TestPublicKeyRefetch(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	keys := &keyServer{}
+	keys.publish(rsaJWK("old", oldKey))
+	provider, doc := newTestProvider(t, keys)
+
+	if _, err := provider.verifyIDToken(context.Background(), doc, signToken(t, jwt.SigningMethodRS256, "old", oldKey, validClaims()), testNonce); err != nil {
+		t.Fatalf("verifyIDToken failed: %v", err)
+	}
+
+	// The provider rotates. Right after a fetch the unknown key is refused
+	// without asking again, so bogus kids cannot hammer the provider.
+	keys.publish(rsaJWK("new", newKey))
+	rotated := signToken(t, jwt.SigningMethodRS256, "new", newKey, validClaims())
+	if _, err := provider.verifyIDToken(context.Background(), doc, rotated, testNonce); err == nil {
+		t.Fatal("verifyIDToken accepted a key before refetching")
+	}
+	if keys.fetches != 1 {
+		t.Fatalf("keys fetched %d times, want 1", keys.fetches)
+	}
+
+	provider.keysFetched = time.Now().Add(-jwksRefetchInterval)
+	if _, err := provider.verifyIDToken(context.Background(), doc, rotated, testNonce); err != nil {
+		t.Fatalf("verifyIDToken failed after the refetch interval: %v", err)
+	}
+	if keys.fetches != 2 {
+		t.Errorf("keys fetched %d times, want 2", keys.fetches)
+	}
+
+	// Tokens of the old key still verify from the refreshed set
+	if _, err := provider.verifyIDToken(context.Background(), doc, signToken(t, jwt.SigningMethodRS256, "old", oldKey, validClaims()), testNonce); err != nil {
+		t.Errorf("verifyIDToken failed for the old key: %v", err)
+	}
+}
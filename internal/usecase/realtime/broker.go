@@ -0,0 +1,211 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"evently/internal/domain/model"
+	"evently/internal/domain/realtime"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// subscriberBuffer is how many messages a stream may fall behind before
+	// it is dropped.
+	subscriberBuffer = 16
+	// reconnectDelay is the wait before listening again after the
+	// connection was lost.
+	reconnectDelay = 2 * time.Second
+)
+
+// topic holds the subscriptions of one kind of message, keyed by user or
+// event. The broker's mutex guards it.
+type topic[T any] map[string]map[chan T]struct{}
+
+func (t topic[T]) subscribe(key string) chan T {
+	ch := make(chan T, subscriberBuffer)
+	if t[key] == nil {
+		t[key] = map[chan T]struct{}{}
+	}
+	t[key][ch] = struct{}{}
+	return ch
+}
+
+func (t topic[T]) unsubscribe(key string, ch chan T) {
+	if _, ok := t[key][ch]; !ok {
+		return
+	}
+	delete(t[key], ch)
+	if len(t[key]) == 0 {
+		delete(t, key)
+	}
+	close(ch)
+}
+
+// publish delivers msg to every subscriber of key. Subscribers whose buffer
+// is full are dropped rather than allowed to hold up the others.
+func (t topic[T]) publish(key string, msg T) {
+	for ch := range t[key] {
+		select {
+		case ch <- msg:
+		default:
+			t.unsubscribe(key, ch)
+		}
+	}
+}
+
+func (t topic[T]) closeAll() {
+	for key, subscribers := range t {
+		for ch := range subscribers {
+			close(ch)
+		}
+		delete(t, key)
+	}
+}
+
+type broker struct {
+	pool             *pgxpool.Pool
+	notificationRepo model.NotificationRepository
+
+	mu            sync.Mutex
+	closed        bool
+	notifications topic[*model.Notification]
+	availability  topic[*realtime.Availability]
+}
+
+// NewBroker returns a broker that listens on a connection of its own from
+// pool once Run is called.
+func NewBroker(pool *pgxpool.Pool, notificationRepo model.NotificationRepository) realtime.Broker {
+	return &broker{
+		pool:             pool,
+		notificationRepo: notificationRepo,
+		notifications:    topic[*model.Notification]{},
+		availability:     topic[*realtime.Availability]{},
+	}
+}
+
+func (b *broker) SubscribeNotifications(userID string) (<-chan *model.Notification, func()) {
+	return subscribe(b, b.notifications, userID)
+}
+
+func (b *broker) SubscribeAvailability(eventID string) (<-chan *realtime.Availability, func()) {
+	return subscribe(b, b.availability, eventID)
+}
+
+func subscribe[T any](b *broker, t topic[T], key string) (<-chan T, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		ch := make(chan T)
+		close(ch)
+		return ch, func() {}
+	}
+
+	ch := t.subscribe(key)
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		t.unsubscribe(key, ch)
+	}
+}
+
+func (b *broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	b.notifications.closeAll()
+	b.availability.closeAll()
+}
+
+func (b *broker) Run(ctx context.Context) {
+	for {
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("realtime: listener stopped, reconnecting: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// listen holds one connection out of the pool for as long as it works.
+// Changes made while it reconnects are not delivered; clients catch up
+// through the regular endpoints.
+func (b *broker) listen(ctx context.Context) error {
+	pooled, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// A listening connection must not go back to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	for _, channel := range []string{realtime.NotificationsChannel, realtime.AvailabilityChannel} {
+		if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+			return err
+		}
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		b.dispatch(notification)
+	}
+}
+
+func (b *broker) dispatch(n *pgconn.Notification) {
+	switch n.Channel {
+	case realtime.NotificationsChannel:
+		var payload struct {
+			ID     string `json:"id"`
+			UserID string `json:"user_id"`
+		}
+		if err := json.Unmarshal([]byte(n.Payload), &payload); err != nil {
+			log.Printf("realtime: bad %s payload: %v", n.Channel, err)
+			return
+		}
+
+		// Only the instances the user is connected to load the row
+		b.mu.Lock()
+		_, subscribed := b.notifications[payload.UserID]
+		b.mu.Unlock()
+		if !subscribed {
+			return
+		}
+
+		notification, err := b.notificationRepo.GetByID(payload.ID)
+		if err != nil {
+			log.Printf("realtime: failed to load notification %s: %v", payload.ID, err)
+			return
+		}
+
+		b.mu.Lock()
+		b.notifications.publish(payload.UserID, notification)
+		b.mu.Unlock()
+
+	case realtime.AvailabilityChannel:
+		availability := &realtime.Availability{}
+		if err := json.Unmarshal([]byte(n.Payload), availability); err != nil {
+			log.Printf("realtime: bad %s payload: %v", n.Channel, err)
+			return
+		}
+
+		b.mu.Lock()
+		b.availability.publish(availability.EventID, availability)
+		b.mu.Unlock()
+	}
+}
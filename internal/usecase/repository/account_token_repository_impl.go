@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"evently/internal/domain/auth"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type accountTokenRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewAccountTokenRepository(db *pgxpool.Pool) auth.AccountTokenRepository {
+	return &accountTokenRepositoryImpl{db: db}
+}
+
+func (r *accountTokenRepositoryImpl) Create(ctx context.Context, token *auth.AccountToken) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM account_tokens
+		WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`,
+		token.UserID, token.Purpose)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO account_tokens (id, user_id, purpose, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		token.ID, token.UserID, token.Purpose, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *accountTokenRepositoryImpl) Consume(ctx context.Context, tokenHash string, purpose auth.TokenPurpose, at time.Time) (*auth.AccountToken, error) {
+	return consumeAccountToken(ctx, r.db, tokenHash, purpose, at)
+}
+
+func (r *accountTokenRepositoryImpl) ResetPassword(ctx context.Context, tokenHash, hashedPassword string, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	token, err := consumeAccountToken(ctx, tx, tokenHash, auth.PurposePasswordReset, at)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE users SET password = $2 WHERE id = $1`, token.UserID, hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	// Whoever knew the old password may still hold a session
+	_, err = tx.Exec(ctx, `
+		UPDATE refresh_tokens SET revoked_at = $2
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2`,
+		token.UserID, at)
+	if err != nil {
+		return err
+	}
+
+	// The reset link reached the inbox, which proves the address too
+	_, err = tx.Exec(ctx, `
+		UPDATE users SET email_verified_at = $2
+		WHERE id = $1 AND email_verified_at IS NULL`,
+		token.UserID, at)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func consumeAccountToken(ctx context.Context, db dbExecutor, tokenHash string, purpose auth.TokenPurpose, at time.Time) (*auth.AccountToken, error) {
+	token := &auth.AccountToken{}
+	err := db.QueryRow(ctx, `
+		UPDATE account_tokens SET used_at = $3
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > $3
+		RETURNING id, user_id, purpose, token_hash, expires_at, used_at, created_at`,
+		tokenHash, purpose, at).Scan(
+		&token.ID, &token.UserID, &token.Purpose, &token.TokenHash, &token.ExpiresAt,
+		&token.UsedAt, &token.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, auth.ErrInvalidAccountToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
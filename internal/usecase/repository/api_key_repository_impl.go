@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"evently/internal/domain/apikey"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type apiKeyRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(db *pgxpool.Pool) apikey.APIKeyRepository {
+	return &apiKeyRepositoryImpl{db: db}
+}
+
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, event_ids, created_by, expires_at, last_used_at, revoked_at, created_at`
+
+func scanAPIKey(row pgx.Row) (*apikey.APIKey, error) {
+	key := &apikey.APIKey{}
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.KeyHash, &key.Scopes, &key.EventIDs,
+		&key.CreatedBy, &key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt, &key.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepositoryImpl) Create(ctx context.Context, key *apikey.APIKey) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO api_keys (id, name, prefix, key_hash, scopes, event_ids, created_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		key.ID, key.Name, key.Prefix, key.KeyHash, key.Scopes, key.EventIDs, key.CreatedBy,
+		key.ExpiresAt, key.CreatedAt)
+
+	return err
+}
+
+func (r *apiKeyRepositoryImpl) GetByID(ctx context.Context, id string) (*apikey.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRow(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("api key not found")
+	}
+	return key, err
+}
+
+func (r *apiKeyRepositoryImpl) GetByPrefix(ctx context.Context, prefix string) (*apikey.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRow(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE prefix = $1`, prefix))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apikey.ErrInvalidAPIKey
+	}
+	return key, err
+}
+
+func (r *apiKeyRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*apikey.APIKey, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`,
+		limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []*apikey.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (r *apiKeyRepositoryImpl) Revoke(ctx context.Context, id string, at time.Time) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE api_keys SET revoked_at = $2
+		WHERE id = $1 AND revoked_at IS NULL`,
+		id, at)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("api key not found")
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepositoryImpl) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE api_keys SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $2 - INTERVAL '1 minute')`,
+		id, at)
+
+	return err
+}
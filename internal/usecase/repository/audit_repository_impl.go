@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"evently/internal/domain/apikey"
+	"evently/internal/domain/audit"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type auditRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) audit.AuditRepository {
+	return &auditRepositoryImpl{db: db}
+}
+
+func (r *auditRepositoryImpl) Create(ctx context.Context, entry *audit.Entry) error {
+	return insertAuditEntry(ctx, r.db, entry)
+}
+
+// insertAuditEntry writes entry through db, so that changes made in a
+// transaction can be recorded in the same one.
+func insertAuditEntry(ctx context.Context, db dbExecutor, entry *audit.Entry) error {
+	metadata := entry.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+
+	// Requests made with an API key carry it in the context
+	apiKeyID := entry.APIKeyID
+	if key, ok := apikey.FromContext(ctx); ok && apiKeyID == nil {
+		apiKeyID = &key.ID
+	}
+
+	_, err := db.Exec(ctx, `
+		INSERT INTO audit_logs (id, actor_id, api_key_id, action, target_type, target_id, ip, metadata, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		entry.ID, entry.ActorID, apiKeyID, entry.Action, entry.TargetType, entry.TargetID, entry.IP,
+		metadata, entry.CreatedAt)
+
+	return err
+}
+
+func (r *auditRepositoryImpl) List(ctx context.Context, action string, limit, offset int) ([]*audit.Entry, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, actor_id, api_key_id, action, target_type, target_id, ip, metadata, created_at
+		FROM audit_logs
+		WHERE $1 = '' OR action = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`,
+		action, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*audit.Entry
+	for rows.Next() {
+		entry := &audit.Entry{}
+		err := rows.Scan(&entry.ID, &entry.ActorID, &entry.APIKeyID, &entry.Action, &entry.TargetType,
+			&entry.TargetID, &entry.IP, &entry.Metadata, &entry.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -3,9 +3,12 @@ package repository
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"evently/internal/domain/booking"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -17,20 +20,124 @@ func NewBookingRepository(db *pgxpool.Pool) booking.BookingRepository {
 	return &bookingRepositoryImpl{db: db}
 }
 
-func (r *bookingRepositoryImpl) Create(newBooking *booking.Booking) error {
+// bookingColumns is the column list read by scanBooking.
+const bookingColumns = `id, user_id, event_id, pass_id, quantity, total_amount, status, 
+			booking_time, cancelled_at, checked_in_at, created_at, updated_at`
+
+func scanBooking(row pgx.Row) (*booking.Booking, error) {
+	b := &booking.Booking{}
+	err := row.Scan(
+		&b.ID, &b.UserID, &b.EventID, &b.PassID, &b.Quantity,
+		&b.TotalAmount, &b.Status, &b.BookingTime,
+		&b.CancelledAt, &b.CheckedInAt, &b.CreatedAt, &b.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func collectBookings(rows pgx.Rows) ([]*booking.Booking, error) {
+	defer rows.Close()
+
+	var bookings []*booking.Booking
+	for rows.Next() {
+		b, err := scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, b)
+	}
+
+	return bookings, rows.Err()
+}
+
+func insertBooking(ctx context.Context, db dbExecutor, newBooking *booking.Booking) error {
 	query := `
-		INSERT INTO bookings (id, user_id, event_id, quantity, total_amount, 
+		INSERT INTO bookings (id, user_id, event_id, pass_id, quantity, total_amount, 
 			status, booking_time, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
-	_, err := r.db.Exec(context.Background(), query,
-		newBooking.ID, newBooking.UserID, newBooking.EventID, newBooking.Quantity,
+	_, err := db.Exec(ctx, query,
+		newBooking.ID, newBooking.UserID, newBooking.EventID, newBooking.PassID, newBooking.Quantity,
 		newBooking.TotalAmount, newBooking.Status, newBooking.BookingTime,
 		newBooking.CreatedAt, newBooking.UpdatedAt)
 
 	return err
 }
 
+func (r *bookingRepositoryImpl) Create(newBooking *booking.Booking) error {
+	return insertBooking(context.Background(), r.db, newBooking)
+}
+
+func (r *bookingRepositoryImpl) CreateWithSessionSeats(ctx context.Context, newBooking *booking.Booking, sessionIDs []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock sessions in a stable order so concurrent pass bookings cannot deadlock
+	sorted := append([]string(nil), sessionIDs...)
+	sort.Strings(sorted)
+
+	for _, sessionID := range sorted {
+		result, err := tx.Exec(ctx, `
+			UPDATE events
+			SET available_seats = available_seats - $2, updated_at = $3
+			WHERE id = $1 AND available_seats >= $2`,
+			sessionID, newBooking.Quantity, time.Now())
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("%w: session %s", booking.ErrSessionSoldOut, sessionID)
+		}
+	}
+
+	if err := insertBooking(ctx, tx, newBooking); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *bookingRepositoryImpl) CancelWithSessionSeats(ctx context.Context, oldBooking *booking.Booking, sessionIDs []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE bookings
+		SET status = $2, cancelled_at = $3, updated_at = $4
+		WHERE id = $1 AND status <> 'cancelled'`,
+		oldBooking.ID, oldBooking.Status, oldBooking.CancelledAt, oldBooking.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("booking not found or already cancelled")
+	}
+
+	sorted := append([]string(nil), sessionIDs...)
+	sort.Strings(sorted)
+
+	for _, sessionID := range sorted {
+		_, err := tx.Exec(ctx, `
+			UPDATE events
+			SET available_seats = available_seats + $2, updated_at = $3
+			WHERE id = $1`,
+			sessionID, oldBooking.Quantity, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
 func (r *bookingRepositoryImpl) Update(oldBooking *booking.Booking) error {
 	query := `
 		UPDATE bookings 
@@ -53,29 +160,33 @@ func (r *bookingRepositoryImpl) Update(oldBooking *booking.Booking) error {
 	return nil
 }
 
-func (r *bookingRepositoryImpl) GetByID(id string) (*booking.Booking, error) {
+func (r *bookingRepositoryImpl) CheckIn(ctx context.Context, id string, at time.Time) error {
 	query := `
-		SELECT id, user_id, event_id, quantity, total_amount, status, 
-			booking_time, cancelled_at, created_at, updated_at
-		FROM bookings WHERE id = $1`
-
-	oldBooking := &booking.Booking{}
-	err := r.db.QueryRow(context.Background(), query, id).Scan(
-		&oldBooking.ID, &oldBooking.UserID, &oldBooking.EventID, &oldBooking.Quantity,
-		&oldBooking.TotalAmount, &oldBooking.Status, &oldBooking.BookingTime,
-		&oldBooking.CancelledAt, &oldBooking.CreatedAt, &oldBooking.UpdatedAt)
+		UPDATE bookings
+		SET checked_in_at = $2, updated_at = $2
+		WHERE id = $1 AND status = 'confirmed' AND checked_in_at IS NULL`
 
+	result, err := r.db.Exec(ctx, query, id, at)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return booking.ErrAlreadyCheckedIn
 	}
 
-	return oldBooking, nil
+	return nil
+}
+
+func (r *bookingRepositoryImpl) GetByID(id string) (*booking.Booking, error) {
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE id = $1`
+
+	return scanBooking(r.db.QueryRow(context.Background(), query, id))
 }
 
 func (r *bookingRepositoryImpl) GetByUserID(userID string, limit, offset int) ([]*booking.Booking, error) {
 	query := `
-		SELECT id, user_id, event_id, quantity, total_amount, status, 
-			booking_time, cancelled_at, created_at, updated_at
+		SELECT ` + bookingColumns + `
 		FROM bookings 
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -85,28 +196,13 @@ func (r *bookingRepositoryImpl) GetByUserID(userID string, limit, offset int) ([
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var bookings []*booking.Booking
-	for rows.Next() {
-		booking := &booking.Booking{}
-		err := rows.Scan(
-			&booking.ID, &booking.UserID, &booking.EventID, &booking.Quantity,
-			&booking.TotalAmount, &booking.Status, &booking.BookingTime,
-			&booking.CancelledAt, &booking.CreatedAt, &booking.UpdatedAt)
-		if err != nil {
-			return nil, err
-		}
-		bookings = append(bookings, booking)
-	}
 
-	return bookings, rows.Err()
+	return collectBookings(rows)
 }
 
 func (r *bookingRepositoryImpl) GetByEventID(eventID string, limit, offset int) ([]*booking.Booking, error) {
 	query := `
-		SELECT id, user_id, event_id, quantity, total_amount, status, 
-			booking_time, cancelled_at, created_at, updated_at
+		SELECT ` + bookingColumns + `
 		FROM bookings 
 		WHERE event_id = $1
 		ORDER BY created_at DESC
@@ -116,22 +212,8 @@ func (r *bookingRepositoryImpl) GetByEventID(eventID string, limit, offset int)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var bookings []*booking.Booking
-	for rows.Next() {
-		booking := &booking.Booking{}
-		err := rows.Scan(
-			&booking.ID, &booking.UserID, &booking.EventID, &booking.Quantity,
-			&booking.TotalAmount, &booking.Status, &booking.BookingTime,
-			&booking.CancelledAt, &booking.CreatedAt, &booking.UpdatedAt)
-		if err != nil {
-			return nil, err
-		}
-		bookings = append(bookings, booking)
-	}
 
-	return bookings, rows.Err()
+	return collectBookings(rows)
 }
 
 func (r *bookingRepositoryImpl) CountByEventID(eventID string) (int, error) {
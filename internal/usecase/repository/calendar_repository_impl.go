@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"evently/internal/domain/booking"
+	"evently/internal/domain/calendar"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type calendarRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewCalendarRepository(db *pgxpool.Pool) calendar.CalendarRepository {
+	return &calendarRepositoryImpl{db: db}
+}
+
+func (r *calendarRepositoryImpl) SetFeedToken(ctx context.Context, userID, tokenHash string) error {
+	query := `
+		INSERT INTO calendar_feed_tokens (user_id, token_hash, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at`
+
+	_, err := r.db.Exec(ctx, query, userID, tokenHash, time.Now())
+
+	return err
+}
+
+func (r *calendarRepositoryImpl) GetUserIDByFeedToken(ctx context.Context, tokenHash string) (string, error) {
+	var userID string
+	err := r.db.QueryRow(ctx,
+		`SELECT user_id FROM calendar_feed_tokens WHERE token_hash = $1`, tokenHash).Scan(&userID)
+
+	return userID, err
+}
+
+func (r *calendarRepositoryImpl) ListUserBookings(ctx context.Context, userID string, since time.Time, limit int) ([]*calendar.BookingEntry, error) {
+	query := `
+		SELECT b.id, b.user_id, b.event_id, b.pass_id, b.quantity, b.total_amount, b.status,
+			b.booking_time, b.cancelled_at, b.created_at, b.updated_at,
+			` + eventColumns + `
+		FROM bookings b
+		JOIN events e ON e.id = b.event_id
+		WHERE b.user_id = $1 AND b.status IN ('confirmed', 'cancelled') AND e.event_time >= $2
+		ORDER BY e.event_time ASC
+		LIMIT $3`
+
+	rows, err := r.db.Query(ctx, query, userID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*calendar.BookingEntry
+	for rows.Next() {
+		b := &booking.Booking{}
+		event, err := scanEvent(rowWithPrefix{rows, []any{
+			&b.ID, &b.UserID, &b.EventID, &b.PassID, &b.Quantity, &b.TotalAmount, &b.Status,
+			&b.BookingTime, &b.CancelledAt, &b.CreatedAt, &b.UpdatedAt,
+		}})
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &calendar.BookingEntry{Booking: b, Event: event})
+	}
+
+	return entries, rows.Err()
+}
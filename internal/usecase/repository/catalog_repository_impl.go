@@ -0,0 +1,412 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"evently/internal/domain/catalog"
+	"evently/internal/domain/events"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type catalogRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewCatalogRepository(db *pgxpool.Pool) catalog.CatalogRepository {
+	return &catalogRepositoryImpl{db: db}
+}
+
+// Categories
+
+func (r *catalogRepositoryImpl) CreateCategory(ctx context.Context, category *catalog.Category) error {
+	query := `
+		INSERT INTO categories (id, name, slug, description, parent_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.Exec(ctx, query,
+		category.ID, category.Name, category.Slug, category.Description, category.ParentID,
+		category.CreatedAt, category.UpdatedAt)
+
+	return err
+}
+
+func (r *catalogRepositoryImpl) UpdateCategory(ctx context.Context, category *catalog.Category) error {
+	query := `
+		UPDATE categories
+		SET name = $2, slug = $3, description = $4, parent_id = $5, updated_at = $6
+		WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query,
+		category.ID, category.Name, category.Slug, category.Description, category.ParentID,
+		category.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("category not found")
+	}
+
+	return nil
+}
+
+func (r *catalogRepositoryImpl) DeleteCategory(ctx context.Context, id string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM categories WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("category not found")
+	}
+
+	return nil
+}
+
+func (r *catalogRepositoryImpl) GetCategory(ctx context.Context, idOrSlug string) (*catalog.Category, error) {
+	query := `
+		SELECT id, name, slug, description, parent_id, created_at, updated_at
+		FROM categories WHERE id = $1 OR slug = $1`
+
+	category := &catalog.Category{}
+	err := r.db.QueryRow(ctx, query, idOrSlug).Scan(
+		&category.ID, &category.Name, &category.Slug, &category.Description,
+		&category.ParentID, &category.CreatedAt, &category.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (r *catalogRepositoryImpl) ListCategories(ctx context.Context) ([]*catalog.Category, error) {
+	query := `
+		SELECT id, name, slug, description, parent_id, created_at, updated_at
+		FROM categories
+		ORDER BY name ASC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []*catalog.Category
+	for rows.Next() {
+		category := &catalog.Category{}
+		err := rows.Scan(
+			&category.ID, &category.Name, &category.Slug, &category.Description,
+			&category.ParentID, &category.CreatedAt, &category.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+func (r *catalogRepositoryImpl) IsDescendant(ctx context.Context, categoryID, candidateID string) (bool, error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = $1
+			UNION ALL
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT EXISTS (SELECT 1 FROM subtree WHERE id = $2)`
+
+	var found bool
+	err := r.db.QueryRow(ctx, query, categoryID, candidateID).Scan(&found)
+
+	return found, err
+}
+
+func (r *catalogRepositoryImpl) GetCategoryAnalytics(ctx context.Context, limit int) ([]*catalog.CategoryAnalytics, error) {
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT id AS root_id, id FROM categories
+			UNION ALL
+			SELECT t.root_id, c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+		),
+		event_stats AS (
+			SELECT
+				e.id,
+				e.category_id,
+				e.total_capacity,
+				COUNT(b.id) AS bookings,
+				COALESCE(SUM(b.total_amount), 0) AS revenue,
+				COALESCE(SUM(b.quantity), 0) AS seats
+			FROM events e
+			LEFT JOIN bookings b ON b.event_id = e.id AND b.status = 'confirmed'
+			WHERE e.category_id IS NOT NULL
+			GROUP BY e.id, e.category_id, e.total_capacity
+		)
+		SELECT
+			c.id as category_id,
+			c.name as category_name,
+			COUNT(s.id) as event_count,
+			COALESCE(SUM(s.bookings), 0) as total_bookings,
+			COALESCE(SUM(s.revenue), 0) as total_revenue,
+			COALESCE(SUM(s.seats), 0) as capacity_used,
+			COALESCE(SUM(s.total_capacity), 0) as capacity_total
+		FROM categories c
+		JOIN tree t ON t.root_id = c.id
+		LEFT JOIN event_stats s ON s.category_id = t.id
+		GROUP BY c.id, c.name
+		ORDER BY total_bookings DESC, total_revenue DESC
+		LIMIT $1`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var analytics []*catalog.CategoryAnalytics
+	for rows.Next() {
+		analytic := &catalog.CategoryAnalytics{}
+		err := rows.Scan(
+			&analytic.CategoryID, &analytic.CategoryName, &analytic.EventCount,
+			&analytic.TotalBookings, &analytic.TotalRevenue, &analytic.CapacityUsed,
+			&analytic.CapacityTotal)
+		if err != nil {
+			return nil, err
+		}
+
+		if analytic.CapacityTotal > 0 {
+			analytic.UtilizationRate = float64(analytic.CapacityUsed) / float64(analytic.CapacityTotal) * 100
+		}
+
+		analytics = append(analytics, analytic)
+	}
+
+	return analytics, rows.Err()
+}
+
+// Tags
+
+func (r *catalogRepositoryImpl) CreateTag(ctx context.Context, tag *catalog.Tag) error {
+	query := `
+		INSERT INTO tags (id, name, slug, created_at)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err := r.db.Exec(ctx, query, tag.ID, tag.Name, tag.Slug, tag.CreatedAt)
+
+	return err
+}
+
+func (r *catalogRepositoryImpl) UpdateTag(ctx context.Context, tag *catalog.Tag) error {
+	result, err := r.db.Exec(ctx,
+		`UPDATE tags SET name = $2, slug = $3 WHERE id = $1`,
+		tag.ID, tag.Name, tag.Slug)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tag not found")
+	}
+
+	return nil
+}
+
+func (r *catalogRepositoryImpl) DeleteTag(ctx context.Context, id string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM tags WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tag not found")
+	}
+
+	return nil
+}
+
+func (r *catalogRepositoryImpl) ListTags(ctx context.Context) ([]*catalog.Tag, error) {
+	query := `
+		SELECT t.id, t.name, t.slug, COUNT(et.event_id) as event_count, t.created_at
+		FROM tags t
+		LEFT JOIN event_tags et ON et.tag_id = t.id
+		GROUP BY t.id, t.name, t.slug, t.created_at
+		ORDER BY t.name ASC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []*catalog.Tag
+	for rows.Next() {
+		tag := &catalog.Tag{}
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.EventCount, &tag.CreatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// Collections
+
+func (r *catalogRepositoryImpl) CreateCollection(ctx context.Context, collection *catalog.Collection) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO collections (id, title, slug, description, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = tx.Exec(ctx, query,
+		collection.ID, collection.Title, collection.Slug, collection.Description,
+		collection.CreatedBy, collection.CreatedAt, collection.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := setCollectionEvents(ctx, tx, collection.ID, collection.EventIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *catalogRepositoryImpl) UpdateCollection(ctx context.Context, collection *catalog.Collection) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE collections
+		SET title = $2, slug = $3, description = $4, updated_at = $5
+		WHERE id = $1`
+
+	result, err := tx.Exec(ctx, query,
+		collection.ID, collection.Title, collection.Slug, collection.Description, collection.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("collection not found")
+	}
+
+	// A nil EventIDs slice keeps the current events
+	if collection.EventIDs != nil {
+		if err := setCollectionEvents(ctx, tx, collection.ID, collection.EventIDs); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func setCollectionEvents(ctx context.Context, tx pgx.Tx, collectionID string, eventIDs []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM collection_events WHERE collection_id = $1`, collectionID); err != nil {
+		return err
+	}
+
+	for position, eventID := range eventIDs {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO collection_events (collection_id, event_id, position)
+			VALUES ($1, $2, $3)`,
+			collectionID, eventID, position)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *catalogRepositoryImpl) DeleteCollection(ctx context.Context, id string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM collections WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("collection not found")
+	}
+
+	return nil
+}
+
+func (r *catalogRepositoryImpl) GetCollection(ctx context.Context, idOrSlug string) (*catalog.Collection, error) {
+	query := `
+		SELECT c.id, c.title, c.slug, c.description,
+			ARRAY(
+				SELECT ce.event_id FROM collection_events ce
+				WHERE ce.collection_id = c.id ORDER BY ce.position
+			),
+			c.created_by, c.created_at, c.updated_at
+		FROM collections c WHERE c.id = $1 OR c.slug = $1`
+
+	collection := &catalog.Collection{}
+	err := r.db.QueryRow(ctx, query, idOrSlug).Scan(
+		&collection.ID, &collection.Title, &collection.Slug, &collection.Description,
+		&collection.EventIDs, &collection.CreatedBy, &collection.CreatedAt, &collection.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (r *catalogRepositoryImpl) ListCollections(ctx context.Context, limit, offset int) ([]*catalog.Collection, error) {
+	query := `
+		SELECT c.id, c.title, c.slug, c.description,
+			ARRAY(
+				SELECT ce.event_id FROM collection_events ce
+				WHERE ce.collection_id = c.id ORDER BY ce.position
+			),
+			c.created_by, c.created_at, c.updated_at
+		FROM collections c
+		ORDER BY c.title ASC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var collections []*catalog.Collection
+	for rows.Next() {
+		collection := &catalog.Collection{}
+		err := rows.Scan(
+			&collection.ID, &collection.Title, &collection.Slug, &collection.Description,
+			&collection.EventIDs, &collection.CreatedBy, &collection.CreatedAt, &collection.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, collection)
+	}
+
+	return collections, rows.Err()
+}
+
+func (r *catalogRepositoryImpl) GetCollectionEvents(ctx context.Context, collectionID string) ([]*events.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM collection_events ce
+		JOIN events e ON e.id = ce.event_id
+		WHERE ce.collection_id = $1
+		ORDER BY ce.position ASC`
+
+	rows, err := r.db.Query(ctx, query, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectEvents(rows)
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"evently/internal/domain/channel"
+	"evently/internal/domain/dispatch"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type deliveryRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewDeliveryRepository(db *pgxpool.Pool) dispatch.DeliveryRepository {
+	return &deliveryRepositoryImpl{db: db}
+}
+
+const deliveryColumns = `id, notification_id, user_id, channel, recipient, status, attempts, next_attempt_at,
+	last_error, sent_at, created_at, updated_at`
+
+func scanDelivery(row pgx.Row) (*dispatch.Delivery, error) {
+	delivery := &dispatch.Delivery{}
+	err := row.Scan(&delivery.ID, &delivery.NotificationID, &delivery.UserID, &delivery.Channel,
+		&delivery.Recipient, &delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt,
+		&delivery.LastError, &delivery.SentAt, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// planTarget is a notification to plan along with how its user can be
+// reached.
+type planTarget struct {
+	notificationID string
+	userID         string
+	email          *string
+	phone          *string
+	active         bool
+}
+
+func (r *deliveryRepositoryImpl) Plan(ctx context.Context, channels []string, now time.Time, limit int) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Rows locked by another worker are being planned there
+	rows, err := tx.Query(ctx, `
+		SELECT n.id, n.user_id,
+			CASE WHEN u.email_verified_at IS NOT NULL THEN u.email END,
+			u.phone,
+			u.disabled_at IS NULL
+		FROM notifications n
+		JOIN users u ON u.id = n.user_id
+		WHERE n.delivery_planned_at IS NULL
+		ORDER BY n.created_at
+		LIMIT $1
+		FOR UPDATE OF n SKIP LOCKED`,
+		limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var targets []*planTarget
+	var notificationIDs, userIDs []string
+	for rows.Next() {
+		target := &planTarget{}
+		if err := rows.Scan(&target.notificationID, &target.userID, &target.email, &target.phone, &target.active); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		targets = append(targets, target)
+		notificationIDs = append(notificationIDs, target.notificationID)
+		userIDs = append(userIDs, target.userID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(targets) == 0 {
+		return 0, nil
+	}
+
+	subscriptions := map[string][]string{}
+	if slices.Contains(channels, channel.WebPush) {
+		rows, err := tx.Query(ctx, `
+			SELECT id, user_id FROM push_subscriptions
+			WHERE user_id = ANY($1)
+			ORDER BY created_at`,
+			userIDs)
+		if err != nil {
+			return 0, err
+		}
+		for rows.Next() {
+			var id, userID string
+			if err := rows.Scan(&id, &userID); err != nil {
+				rows.Close()
+				return 0, err
+			}
+			subscriptions[userID] = append(subscriptions[userID], id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	batch := &pgx.Batch{}
+	queue := func(target *planTarget, name, recipient string) {
+		batch.Queue(`
+			INSERT INTO notification_deliveries (id, notification_id, user_id, channel, recipient,
+				status, next_attempt_at, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, 'pending', $6, $6, $6)
+			ON CONFLICT (notification_id, channel, recipient) DO NOTHING`,
+			uuid.New().String(), target.notificationID, target.userID, name, recipient, now)
+	}
+	for _, target := range targets {
+		if !target.active {
+			continue
+		}
+		for _, name := range channels {
+			switch name {
+			case channel.Email:
+				if target.email != nil {
+					queue(target, name, *target.email)
+				}
+			case channel.SMS:
+				if target.phone != nil {
+					queue(target, name, *target.phone)
+				}
+			case channel.WebPush:
+				for _, id := range subscriptions[target.userID] {
+					queue(target, name, id)
+				}
+			}
+		}
+	}
+	if batch.Len() > 0 {
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE notifications SET delivery_planned_at = $2
+		WHERE id = ANY($1)`,
+		notificationIDs, now)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return len(targets), nil
+}
+
+func (r *deliveryRepositoryImpl) Claim(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*dispatch.Delivery, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE notification_deliveries SET
+			status = 'sending',
+			attempts = attempts + 1,
+			locked_until = $2,
+			updated_at = $1
+		WHERE id IN (
+			SELECT id FROM notification_deliveries
+			WHERE (status = 'pending' AND next_attempt_at <= $1)
+			   OR (status = 'sending' AND locked_until <= $1)
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED)
+		RETURNING `+deliveryColumns,
+		now, lockedUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []*dispatch.Delivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *deliveryRepositoryImpl) MarkSent(ctx context.Context, id string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notification_deliveries SET
+			status = 'sent',
+			sent_at = $2,
+			locked_until = NULL,
+			last_error = NULL,
+			updated_at = $2
+		WHERE id = $1`,
+		id, at)
+
+	return err
+}
+
+func (r *deliveryRepositoryImpl) MarkFailed(ctx context.Context, id, lastError string, retryAt *time.Time, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notification_deliveries SET
+			status = CASE WHEN $3::timestamptz IS NULL THEN 'dead' ELSE 'pending' END,
+			next_attempt_at = COALESCE($3, next_attempt_at),
+			locked_until = NULL,
+			last_error = $2,
+			updated_at = $4
+		WHERE id = $1`,
+		id, lastError, retryAt, at)
+
+	return err
+}
+
+func (r *deliveryRepositoryImpl) Requeue(ctx context.Context, id string, at time.Time) (*dispatch.Delivery, error) {
+	delivery, err := scanDelivery(r.db.QueryRow(ctx, `
+		UPDATE notification_deliveries SET
+			status = 'pending',
+			attempts = 0,
+			next_attempt_at = $2,
+			updated_at = $2
+		WHERE id = $1 AND status = 'dead'
+		RETURNING `+deliveryColumns,
+		id, at))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("dead delivery not found")
+	}
+	return delivery, err
+}
+
+func (r *deliveryRepositoryImpl) List(ctx context.Context, filter dispatch.DeliveryFilter) ([]*dispatch.Delivery, int, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+deliveryColumns+`, COUNT(*) OVER ()
+		FROM notification_deliveries
+		WHERE ($1 = '' OR status = $1)
+		  AND ($2 = '' OR channel = $2)
+		  AND ($3 = '' OR notification_id = $3)
+		ORDER BY created_at DESC, id
+		LIMIT $4 OFFSET $5`,
+		filter.Status, filter.Channel, filter.NotificationID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	deliveries := []*dispatch.Delivery{}
+	total := 0
+	for rows.Next() {
+		delivery := &dispatch.Delivery{}
+		err := rows.Scan(&delivery.ID, &delivery.NotificationID, &delivery.UserID, &delivery.Channel,
+			&delivery.Recipient, &delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt,
+			&delivery.LastError, &delivery.SentAt, &delivery.CreatedAt, &delivery.UpdatedAt, &total)
+		if err != nil {
+			return nil, 0, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, total, rows.Err()
+}
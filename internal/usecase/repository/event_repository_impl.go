@@ -2,11 +2,17 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"evently/internal/domain/catalog"
 	"evently/internal/domain/events"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -18,33 +24,177 @@ func NewEventRepository(db *pgxpool.Pool) events.EventRepository {
 	return &eventRepositoryImpl{db: db}
 }
 
-func (r *eventRepositoryImpl) Create(event *events.Event) error {
-	query := `
-		INSERT INTO events (id, name, description, venue, event_time, total_capacity, 
-			available_seats, price, created_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+// dbExecutor is satisfied by both *pgxpool.Pool and pgx.Tx, so helpers can
+// run inside or outside a transaction.
+type dbExecutor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
 
-	_, err := r.db.Exec(context.Background(), query,
-		event.ID, event.Name, event.Description, event.Venue, event.EventTime,
-		event.TotalCapacity, event.AvailableSeats, event.Price, event.CreatedBy,
-		event.CreatedAt, event.UpdatedAt)
+// eventColumns is the column list read by scanEvent. Queries using it must
+// alias the events table as e.
+const eventColumns = `e.id, e.name, e.description, e.venue, e.venue_id, e.event_time, e.timezone, e.total_capacity, 
+			e.available_seats, e.price, e.on_sale_at, e.off_sale_at, e.created_by, e.series_id, e.parent_event_id, e.category_id,
+			ARRAY(
+				SELECT t.name FROM event_tags et JOIN tags t ON t.id = et.tag_id
+				WHERE et.event_id = e.id ORDER BY t.name
+			) AS tags,
+			e.sequence, e.created_at, e.updated_at`
+
+func scanEvent(row pgx.Row) (*events.Event, error) {
+	event := &events.Event{}
+	err := row.Scan(
+		&event.ID, &event.Name, &event.Description, &event.Venue, &event.VenueID, &event.EventTime, &event.Timezone,
+		&event.TotalCapacity, &event.AvailableSeats, &event.Price, &event.OnSaleAt, &event.OffSaleAt,
+		&event.CreatedBy, &event.SeriesID, &event.ParentEventID, &event.CategoryID, &event.Tags,
+		&event.Sequence, &event.CreatedAt, &event.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	event.Localize()
+
+	return event, nil
+}
+
+// rowWithExtra scans the event columns followed by one extra computed column.
+type rowWithExtra struct {
+	pgx.Row
+	extra any
+}
+
+func (r rowWithExtra) Scan(dest ...any) error {
+	return r.Row.Scan(append(dest, r.extra)...)
+}
+
+// rowWithPrefix scans columns from a joined table followed by the event
+// columns.
+type rowWithPrefix struct {
+	pgx.Row
+	prefix []any
+}
+
+func (r rowWithPrefix) Scan(dest ...any) error {
+	return r.Row.Scan(append(append([]any{}, r.prefix...), dest...)...)
+}
+
+func collectEvents(rows pgx.Rows) ([]*events.Event, error) {
+	defer rows.Close()
+
+	var events_list []*events.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events_list = append(events_list, event)
+	}
+
+	return events_list, rows.Err()
+}
 
+// eventWriteError turns a reference to a category that does not exist into
+// a validation error.
+func eventWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "events_category_id_fkey" {
+		return fmt.Errorf("validation failed: category_id does not refer to a category")
+	}
 	return err
 }
 
+// insertEvent is shared by every repository that creates events so the
+// column list only lives in one place.
+func insertEvent(ctx context.Context, db dbExecutor, event *events.Event) error {
+	query := `
+		INSERT INTO events (id, name, description, venue, venue_id, event_time, timezone, total_capacity, 
+			available_seats, price, on_sale_at, off_sale_at, created_by, series_id, parent_event_id,
+			category_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
+
+	_, err := db.Exec(ctx, query,
+		event.ID, event.Name, event.Description, event.Venue, event.VenueID, event.EventTime, event.Timezone,
+		event.TotalCapacity, event.AvailableSeats, event.Price, event.OnSaleAt, event.OffSaleAt, event.CreatedBy,
+		event.SeriesID, event.ParentEventID, event.CategoryID, event.CreatedAt, event.UpdatedAt)
+	if err != nil {
+		return eventWriteError(err)
+	}
+
+	if len(event.Tags) > 0 {
+		return setEventTags(ctx, db, event.ID, event.Tags)
+	}
+
+	return nil
+}
+
+// setEventTags replaces the tags of an event, creating tags that do not
+// exist yet. Tags are matched by slug, so "Live Music" and "live-music" are
+// the same tag.
+func setEventTags(ctx context.Context, db dbExecutor, eventID string, tags []string) error {
+	if _, err := db.Exec(ctx, `DELETE FROM event_tags WHERE event_id = $1`, eventID); err != nil {
+		return err
+	}
+
+	for _, name := range tags {
+		slug := catalog.Slugify(name)
+		if slug == "" {
+			continue
+		}
+
+		_, err := db.Exec(ctx, `
+			INSERT INTO tags (id, name, slug, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (slug) DO NOTHING`,
+			uuid.New().String(), strings.TrimSpace(name), slug, time.Now())
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(ctx, `
+			INSERT INTO event_tags (event_id, tag_id)
+			SELECT $1, id FROM tags WHERE slug = $2
+			ON CONFLICT DO NOTHING`,
+			eventID, slug)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *eventRepositoryImpl) Create(event *events.Event) error {
+	ctx := context.Background()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
 func (r *eventRepositoryImpl) Update(event *events.Event) error {
 	query := `
 		UPDATE events 
 		SET name = $2, description = $3, venue = $4, event_time = $5, 
-			total_capacity = $6, available_seats = $7, price = $8, updated_at = $9
+			total_capacity = $6, available_seats = $7, price = $8, category_id = $9,
+			updated_at = $10, venue_id = $11, timezone = $12, on_sale_at = $13,
+			off_sale_at = $14, sequence = sequence + 1
 		WHERE id = $1`
 
 	result, err := r.db.Exec(context.Background(), query,
 		event.ID, event.Name, event.Description, event.Venue, event.EventTime,
-		event.TotalCapacity, event.AvailableSeats, event.Price, event.UpdatedAt)
+		event.TotalCapacity, event.AvailableSeats, event.Price, event.CategoryID, event.UpdatedAt,
+		event.VenueID, event.Timezone, event.OnSaleAt, event.OffSaleAt)
 
 	if err != nil {
-		return err
+		return eventWriteError(err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -54,10 +204,103 @@ func (r *eventRepositoryImpl) Update(event *events.Event) error {
 	return nil
 }
 
+func (r *eventRepositoryImpl) UpdateWithCapacity(ctx context.Context, event *events.Event) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	freedSeats, err := updateEventWithCapacity(ctx, tx, event)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return freedSeats, nil
+}
+
+// updateEventWithCapacity is UpdateWithCapacity within the caller's
+// transaction, so that several events can be updated together.
+func updateEventWithCapacity(ctx context.Context, tx pgx.Tx, event *events.Event) (int, error) {
+	var totalCapacity, availableSeats int
+	err := tx.QueryRow(ctx,
+		`SELECT total_capacity, available_seats FROM events WHERE id = $1 FOR UPDATE`,
+		event.ID).Scan(&totalCapacity, &availableSeats)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("event not found")
+		}
+		return 0, err
+	}
+
+	// Seats offered to waitlisted users are still counted as available until
+	// the offer is taken or expires, so they have to be protected separately.
+	var heldSeats int
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(quantity), 0)
+		FROM waitlist
+		WHERE event_id = $1 AND status = 'notified' AND expires_at > $2`,
+		event.ID, time.Now()).Scan(&heldSeats)
+	if err != nil {
+		return 0, err
+	}
+
+	soldSeats := totalCapacity - availableSeats
+	if event.TotalCapacity < soldSeats+heldSeats {
+		return 0, fmt.Errorf("%w: capacity %d is below %d sold and %d held seats",
+			events.ErrCapacityConflict, event.TotalCapacity, soldSeats, heldSeats)
+	}
+
+	event.AvailableSeats = event.TotalCapacity - soldSeats
+
+	_, err = tx.Exec(ctx, `
+		UPDATE events 
+		SET name = $2, description = $3, venue = $4, event_time = $5, 
+			total_capacity = $6, available_seats = $7, price = $8, category_id = $9,
+			updated_at = $10, venue_id = $11, timezone = $12, on_sale_at = $13,
+			off_sale_at = $14, sequence = sequence + 1
+		WHERE id = $1`,
+		event.ID, event.Name, event.Description, event.Venue, event.EventTime,
+		event.TotalCapacity, event.AvailableSeats, event.Price, event.CategoryID, event.UpdatedAt,
+		event.VenueID, event.Timezone, event.OnSaleAt, event.OffSaleAt)
+	if err != nil {
+		return 0, eventWriteError(err)
+	}
+
+	// A nil Tags slice leaves the tags untouched; an empty one clears them
+	if event.Tags != nil {
+		if err := setEventTags(ctx, tx, event.ID, event.Tags); err != nil {
+			return 0, err
+		}
+	}
+
+	return event.AvailableSeats - availableSeats, nil
+}
+
 func (r *eventRepositoryImpl) Delete(id string) error {
-	query := `DELETE FROM events WHERE id = $1`
+	ctx := context.Background()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Bookings refuse to lose their pass; those of the event's passes go
+	// with the sessions they were made on
+	_, err = tx.Exec(ctx, `
+		UPDATE bookings SET pass_id = NULL
+		WHERE pass_id IN (SELECT id FROM passes WHERE event_id = $1)`,
+		id)
+	if err != nil {
+		return err
+	}
 
-	result, err := r.db.Exec(context.Background(), query, id)
+	result, err := tx.Exec(ctx, `DELETE FROM events WHERE id = $1`, id)
 	if err != nil {
 		return err
 	}
@@ -66,87 +309,52 @@ func (r *eventRepositoryImpl) Delete(id string) error {
 		return fmt.Errorf("event not found")
 	}
 
-	return nil
+	return tx.Commit(ctx)
 }
 
 func (r *eventRepositoryImpl) GetByID(id string) (*events.Event, error) {
-	query := `
-		SELECT id, name, description, venue, event_time, total_capacity, 
-			available_seats, price, created_by, created_at, updated_at
-		FROM events WHERE id = $1`
+	query := `SELECT ` + eventColumns + ` FROM events e WHERE e.id = $1`
 
-	event := &events.Event{}
-	err := r.db.QueryRow(context.Background(), query, id).Scan(
-		&event.ID, &event.Name, &event.Description, &event.Venue, &event.EventTime,
-		&event.TotalCapacity, &event.AvailableSeats, &event.Price, &event.CreatedBy,
-		&event.CreatedAt, &event.UpdatedAt)
+	return scanEvent(r.db.QueryRow(context.Background(), query, id))
+}
 
-	if err != nil {
-		return nil, err
+func (r *eventRepositoryImpl) ListUpcoming(filter events.EventFilter, limit, offset int) ([]*events.Event, error) {
+	where := &eventFilter{conditions: []string{"e.event_time > NOW()", "e.parent_event_id IS NULL"}}
+	where.addCatalogFilters(filter.Category, filter.Tags)
+	if filter.VenueID != "" {
+		where.add("e.venue_id = $%d", filter.VenueID)
 	}
 
-	return event, nil
-}
-
-func (r *eventRepositoryImpl) ListUpcoming(limit, offset int) ([]*events.Event, error) {
-	query := `
-		SELECT id, name, description, venue, event_time, total_capacity, 
-			available_seats, price, created_by, created_at, updated_at
-		FROM events 
-		WHERE event_time > NOW()
-		ORDER BY event_time ASC
-		LIMIT $1 OFFSET $2`
+	args := append(where.args, limit, offset)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM events e
+		WHERE %s
+		ORDER BY e.event_time ASC
+		LIMIT $%d OFFSET $%d`,
+		eventColumns, where.where(), len(args)-1, len(args))
 
-	rows, err := r.db.Query(context.Background(), query, limit, offset)
+	rows, err := r.db.Query(context.Background(), query, args...)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var events_list []*events.Event
-	for rows.Next() {
-		event := &events.Event{}
-		err := rows.Scan(
-			&event.ID, &event.Name, &event.Description, &event.Venue, &event.EventTime,
-			&event.TotalCapacity, &event.AvailableSeats, &event.Price, &event.CreatedBy,
-			&event.CreatedAt, &event.UpdatedAt)
-		if err != nil {
-			return nil, err
-		}
-		events_list = append(events_list, event)
-	}
 
-	return events_list, rows.Err()
+	return collectEvents(rows)
 }
 
 func (r *eventRepositoryImpl) ListAll(limit, offset int) ([]*events.Event, error) {
 	query := `
-		SELECT id, name, description, venue, event_time, total_capacity, 
-			available_seats, price, created_by, created_at, updated_at
-		FROM events 
-		ORDER BY event_time DESC
+		SELECT ` + eventColumns + `
+		FROM events e
+		ORDER BY e.event_time DESC
 		LIMIT $1 OFFSET $2`
 
 	rows, err := r.db.Query(context.Background(), query, limit, offset)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var events_list []*events.Event
-	for rows.Next() {
-		event := &events.Event{}
-		err := rows.Scan(
-			&event.ID, &event.Name, &event.Description, &event.Venue, &event.EventTime,
-			&event.TotalCapacity, &event.AvailableSeats, &event.Price, &event.CreatedBy,
-			&event.CreatedAt, &event.UpdatedAt)
-		if err != nil {
-			return nil, err
-		}
-		events_list = append(events_list, event)
-	}
 
-	return events_list, rows.Err()
+	return collectEvents(rows)
 }
 
 func (r *eventRepositoryImpl) UpdateAvailableSeats(eventID string, quantity int) error {
@@ -167,7 +375,33 @@ func (r *eventRepositoryImpl) UpdateAvailableSeats(eventID string, quantity int)
 	return nil
 }
 
+func (r *eventRepositoryImpl) ListManaged(ctx context.Context, userID string, limit, offset int) ([]*events.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events e
+		WHERE ` + managedByCondition("e", "$1") + `
+		ORDER BY e.event_time DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectEvents(rows)
+}
+
 func (r *eventRepositoryImpl) GetMostPopularEvents(ctx context.Context, limit int) ([]*events.EventAnalytics, error) {
+	return r.mostPopularEvents(ctx, "TRUE", limit)
+}
+
+func (r *eventRepositoryImpl) GetMostPopularManagedEvents(ctx context.Context, userID string, limit int) ([]*events.EventAnalytics, error) {
+	return r.mostPopularEvents(ctx, managedByCondition("e", "$2"), limit, userID)
+}
+
+// mostPopularEvents ranks the events matching where by confirmed bookings.
+// The limit is $1 and args continue from $2.
+func (r *eventRepositoryImpl) mostPopularEvents(ctx context.Context, where string, limit int, args ...any) ([]*events.EventAnalytics, error) {
 	query := `
 		SELECT 
 			e.id as event_id,
@@ -178,11 +412,12 @@ func (r *eventRepositoryImpl) GetMostPopularEvents(ctx context.Context, limit in
 			e.total_capacity as capacity_total
 		FROM events e
 		LEFT JOIN bookings b ON e.id = b.event_id AND b.status = 'confirmed'
+		WHERE ` + where + `
 		GROUP BY e.id, e.name, e.total_capacity
 		ORDER BY total_bookings DESC, total_revenue DESC
 		LIMIT $1`
 
-	rows, err := r.db.Query(context.Background(), query, limit)
+	rows, err := r.db.Query(ctx, query, append([]any{limit}, args...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -208,3 +443,262 @@ func (r *eventRepositoryImpl) GetMostPopularEvents(ctx context.Context, limit in
 
 	return analytics, rows.Err()
 }
+
+func (r *eventRepositoryImpl) ListSessions(ctx context.Context, parentEventID string) ([]*events.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events e
+		WHERE e.parent_event_id = $1
+		ORDER BY e.event_time ASC`
+
+	rows, err := r.db.Query(ctx, query, parentEventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectEvents(rows)
+}
+
+func (r *eventRepositoryImpl) GetSessionUtilization(ctx context.Context, parentEventID string) ([]*events.SessionUtilization, error) {
+	query := `
+		SELECT
+			e.id as session_id,
+			e.name as session_name,
+			e.event_time,
+			e.total_capacity as capacity_total,
+			e.available_seats,
+			COALESCE((
+				SELECT SUM(b.quantity) FROM bookings b
+				WHERE b.event_id = e.id AND b.status = 'confirmed'
+			), 0) as direct_seats,
+			COALESCE((
+				SELECT SUM(b.quantity) FROM bookings b
+				JOIN pass_sessions ps ON ps.pass_id = b.pass_id
+				WHERE ps.session_id = e.id AND b.status = 'confirmed'
+			), 0) as pass_seats
+		FROM events e
+		WHERE e.parent_event_id = $1
+		ORDER BY e.event_time ASC`
+
+	rows, err := r.db.Query(ctx, query, parentEventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var utilization []*events.SessionUtilization
+	for rows.Next() {
+		u := &events.SessionUtilization{}
+		err := rows.Scan(
+			&u.SessionID, &u.SessionName, &u.EventTime, &u.CapacityTotal,
+			&u.AvailableSeats, &u.DirectSeats, &u.PassSeats)
+		if err != nil {
+			return nil, err
+		}
+
+		if u.CapacityTotal > 0 {
+			u.UtilizationRate = float64(u.CapacityTotal-u.AvailableSeats) / float64(u.CapacityTotal) * 100
+		}
+
+		utilization = append(utilization, u)
+	}
+
+	return utilization, rows.Err()
+}
+
+// eventFilter accumulates WHERE conditions and their positional arguments.
+type eventFilter struct {
+	conditions []string
+	args       []any
+}
+
+// add appends a condition whose single placeholder is written as %d.
+func (f *eventFilter) add(condition string, arg any) int {
+	f.args = append(f.args, arg)
+	f.conditions = append(f.conditions, fmt.Sprintf(condition, len(f.args)))
+	return len(f.args)
+}
+
+func (f *eventFilter) where() string {
+	return strings.Join(f.conditions, " AND ")
+}
+
+// addCatalogFilters restricts events to a category (by ID or slug, including
+// its subcategories) and to events carrying every one of the given tags.
+func (f *eventFilter) addCatalogFilters(category string, tags []string) {
+	if category != "" {
+		f.add(`e.category_id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM categories WHERE id = $%[1]d OR slug = $%[1]d
+				UNION ALL
+				SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+			)
+			SELECT id FROM subtree
+		)`, category)
+	}
+
+	for _, tag := range tags {
+		f.add(`EXISTS (
+			SELECT 1 FROM event_tags et JOIN tags t ON t.id = et.tag_id
+			WHERE et.event_id = e.id AND t.slug = $%d
+		)`, catalog.Slugify(tag))
+	}
+}
+
+func (r *eventRepositoryImpl) Search(ctx context.Context, params *events.SearchParams) (*events.SearchResult, error) {
+	filter := &eventFilter{conditions: []string{"e.parent_event_id IS NULL"}}
+
+	rank := "0"
+	if params.Query != "" {
+		n := filter.add("e.search_vector @@ websearch_to_tsquery('english', $%d)", params.Query)
+		rank = fmt.Sprintf("ts_rank(e.search_vector, websearch_to_tsquery('english', $%d))", n)
+	}
+	if params.From != nil {
+		filter.add("e.event_time >= $%d", *params.From)
+	}
+	if params.To != nil {
+		filter.add("e.event_time <= $%d", *params.To)
+	}
+	if params.MinPrice != nil {
+		filter.add("e.price >= $%d", *params.MinPrice)
+	}
+	if params.MaxPrice != nil {
+		filter.add("e.price <= $%d", *params.MaxPrice)
+	}
+	if params.Venue != "" {
+		filter.add("LOWER(e.venue) = LOWER($%d)", params.Venue)
+	}
+	filter.addCatalogFilters(params.Category, params.Tags)
+	switch params.Availability {
+	case events.AvailabilityHasSeats:
+		filter.conditions = append(filter.conditions, "e.available_seats > 0")
+	case events.AvailabilitySoldOut:
+		filter.conditions = append(filter.conditions, "e.available_seats = 0")
+	}
+
+	var orderBy string
+	switch params.Sort {
+	case events.SearchSortDateDesc:
+		orderBy = "e.event_time DESC"
+	case events.SearchSortPrice:
+		orderBy = "e.price ASC, e.event_time ASC"
+	case events.SearchSortPriceDesc:
+		orderBy = "e.price DESC, e.event_time ASC"
+	case events.SearchSortPopularity:
+		orderBy = "(e.total_capacity - e.available_seats) DESC, e.event_time ASC"
+	case events.SearchSortRelevance:
+		orderBy = rank + " DESC, e.event_time ASC"
+	default:
+		orderBy = "e.event_time ASC"
+	}
+
+	where := filter.where()
+	args := append([]any(nil), filter.args...)
+	args = append(args, params.Limit, params.Offset)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM events e
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`,
+		eventColumns, where, orderBy, len(args)-1, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	eventsList, err := collectEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &events.SearchResult{Events: eventsList, Facets: &events.SearchFacets{}}
+
+	// Totals, availability and price buckets come from a single pass
+	var hasSeats, soldOut, free, upTo25, upTo50, upTo100, over100 int
+	err = r.db.QueryRow(ctx, `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE e.available_seats > 0),
+			COUNT(*) FILTER (WHERE e.available_seats = 0),
+			COUNT(*) FILTER (WHERE e.price = 0),
+			COUNT(*) FILTER (WHERE e.price > 0 AND e.price <= 25),
+			COUNT(*) FILTER (WHERE e.price > 25 AND e.price <= 50),
+			COUNT(*) FILTER (WHERE e.price > 50 AND e.price <= 100),
+			COUNT(*) FILTER (WHERE e.price > 100)
+		FROM events e
+		WHERE `+where, filter.args...).Scan(
+		&result.Total, &hasSeats, &soldOut, &free, &upTo25, &upTo50, &upTo100, &over100)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Facets.Availability = []events.FacetCount{
+		{Value: string(events.AvailabilityHasSeats), Count: hasSeats},
+		{Value: string(events.AvailabilitySoldOut), Count: soldOut},
+	}
+	result.Facets.PriceRanges = []events.FacetCount{
+		{Value: "free", Count: free},
+		{Value: "0-25", Count: upTo25},
+		{Value: "25-50", Count: upTo50},
+		{Value: "50-100", Count: upTo100},
+		{Value: "100+", Count: over100},
+	}
+
+	result.Facets.Venues, err = r.facetCounts(ctx, `
+		SELECT e.venue, COUNT(*)
+		FROM events e
+		WHERE `+where+`
+		GROUP BY e.venue
+		ORDER BY COUNT(*) DESC, e.venue ASC
+		LIMIT 20`, filter.args)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Facets.Categories, err = r.facetCounts(ctx, `
+		SELECT c.slug, COUNT(*)
+		FROM events e
+		JOIN categories c ON c.id = e.category_id
+		WHERE `+where+`
+		GROUP BY c.slug
+		ORDER BY COUNT(*) DESC, c.slug ASC`, filter.args)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Facets.Tags, err = r.facetCounts(ctx, `
+		SELECT t.slug, COUNT(*)
+		FROM events e
+		JOIN event_tags et ON et.event_id = e.id
+		JOIN tags t ON t.id = et.tag_id
+		WHERE `+where+`
+		GROUP BY t.slug
+		ORDER BY COUNT(*) DESC, t.slug ASC
+		LIMIT 20`, filter.args)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *eventRepositoryImpl) facetCounts(ctx context.Context, query string, args []any) ([]events.FacetCount, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	facets := []events.FacetCount{}
+	for rows.Next() {
+		var facet events.FacetCount
+		if err := rows.Scan(&facet.Value, &facet.Count); err != nil {
+			return nil, err
+		}
+		facets = append(facets, facet)
+	}
+
+	return facets, rows.Err()
+}
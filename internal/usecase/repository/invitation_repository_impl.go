@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/internal/domain/invitation"
+	"evently/internal/domain/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type invitationRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewInvitationRepository(db *pgxpool.Pool) invitation.InvitationRepository {
+	return &invitationRepositoryImpl{db: db}
+}
+
+const invitationColumns = `id, email, role, invited_by, status, expires_at, accepted_at, revoked_at, created_at`
+
+func scanInvitation(row pgx.Row) (*invitation.Invitation, error) {
+	inv := &invitation.Invitation{}
+	err := row.Scan(&inv.ID, &inv.Email, &inv.Role, &inv.InvitedBy, &inv.Status, &inv.ExpiresAt,
+		&inv.AcceptedAt, &inv.RevokedAt, &inv.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (r *invitationRepositoryImpl) Create(ctx context.Context, inv *invitation.Invitation) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE invitations SET status = 'revoked', revoked_at = $2
+		WHERE LOWER(email) = LOWER($1) AND status = 'pending'`,
+		inv.Email, inv.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO invitations (id, email, role, invited_by, status, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		inv.ID, inv.Email, inv.Role, inv.InvitedBy, inv.Status, inv.ExpiresAt, inv.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *invitationRepositoryImpl) GetByID(ctx context.Context, id string) (*invitation.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM invitations WHERE id = $1`
+
+	return scanInvitation(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *invitationRepositoryImpl) List(ctx context.Context, status invitation.Status, now time.Time, limit, offset int) ([]*invitation.Invitation, error) {
+	where := "TRUE"
+	args := []any{limit, offset}
+	switch status {
+	case invitation.StatusPending:
+		where = "status = 'pending' AND expires_at > $3"
+		args = append(args, now)
+	case invitation.StatusExpired:
+		where = "status = 'pending' AND expires_at <= $3"
+		args = append(args, now)
+	case invitation.StatusAccepted, invitation.StatusRevoked:
+		where = "status = $3"
+		args = append(args, string(status))
+	}
+
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM invitations
+		WHERE ` + where + `
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []*invitation.Invitation
+	for rows.Next() {
+		inv, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, inv)
+	}
+
+	return invitations, rows.Err()
+}
+
+func (r *invitationRepositoryImpl) Revoke(ctx context.Context, id string, at time.Time) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE invitations SET status = 'revoked', revoked_at = $2
+		WHERE id = $1 AND status = 'pending'`,
+		id, at)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("pending invitation not found")
+	}
+
+	return nil
+}
+
+func (r *invitationRepositoryImpl) Accept(ctx context.Context, id string, user *model.User, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Marking the row first makes concurrent accepts of the same invitation
+	// wait on it, and all but one find it no longer pending
+	result, err := tx.Exec(ctx, `
+		UPDATE invitations SET status = 'accepted', accepted_at = $2
+		WHERE id = $1 AND status = 'pending' AND expires_at > $2`,
+		id, at)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("invitation is no longer valid")
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO users (id, name, email, password, role, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		user.ID, user.Name, user.Email, user.Password, user.Role, user.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
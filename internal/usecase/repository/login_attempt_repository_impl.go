@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"evently/internal/domain/auth"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type loginAttemptRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewLoginAttemptRepository(db *pgxpool.Pool) auth.LoginAttemptRepository {
+	return &loginAttemptRepositoryImpl{db: db}
+}
+
+func (r *loginAttemptRepositoryImpl) BeginAttempt(ctx context.Context, email, ip string, since, now time.Time, throttle auth.ThrottlePolicy) (*auth.LoginAttempt, time.Duration, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Concurrent attempts for the email or the ip wait here until this one
+	// is recorded. The email lock is always taken first.
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('login_email:' || $1))`, email); err != nil {
+		return nil, 0, err
+	}
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('login_ip:' || $1))`, ip); err != nil {
+		return nil, 0, err
+	}
+
+	stats := &auth.FailureStats{}
+	err = tx.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM login_failures WHERE email = $1 AND created_at > $3),
+			(SELECT MAX(created_at) FROM login_failures WHERE email = $1 AND created_at > $3),
+			(SELECT COUNT(*) FROM login_failures WHERE ip = $2 AND created_at > $3),
+			(SELECT MAX(created_at) FROM login_failures WHERE ip = $2 AND created_at > $3),
+			(SELECT locked_until FROM login_lockouts WHERE email = $1 AND locked_until > $4)`,
+		email, ip, since, now).Scan(
+		&stats.EmailFailures, &stats.LastEmailFailure,
+		&stats.IPFailures, &stats.LastIPFailure,
+		&stats.LockedUntil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if wait := throttle(stats, now); wait > 0 {
+		return nil, wait, nil
+	}
+
+	attempt := &auth.LoginAttempt{
+		ID:            uuid.New().String(),
+		EmailFailures: stats.EmailFailures + 1,
+		IPFailures:    stats.IPFailures + 1,
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO login_failures (id, email, ip, created_at)
+		VALUES ($1, $2, $3, $4)`,
+		attempt.ID, email, ip, now)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	return attempt, 0, nil
+}
+
+func (r *loginAttemptRepositoryImpl) ForgetAttempt(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM login_failures WHERE id = $1`, id)
+
+	return err
+}
+
+func (r *loginAttemptRepositoryImpl) Lock(ctx context.Context, email string, until time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO login_lockouts (email, locked_until)
+		VALUES ($1, $2)
+		ON CONFLICT (email) DO UPDATE SET locked_until = EXCLUDED.locked_until, created_at = NOW()`,
+		email, until)
+
+	return err
+}
+
+func (r *loginAttemptRepositoryImpl) ClearFailures(ctx context.Context, email string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM login_failures WHERE email = $1`, email)
+
+	return err
+}
+
+func (r *loginAttemptRepositoryImpl) Unlock(ctx context.Context, email string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM login_lockouts WHERE email = $1`, email); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM login_failures WHERE email = $1`, email); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *loginAttemptRepositoryImpl) DeleteExpired(ctx context.Context, before, now time.Time) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	failures, err := tx.Exec(ctx, `DELETE FROM login_failures WHERE created_at <= $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	lockouts, err := tx.Exec(ctx, `DELETE FROM login_lockouts WHERE locked_until <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return int(failures.RowsAffected() + lockouts.RowsAffected()), nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/internal/domain/mfa"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type mfaRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewMFARepository(db *pgxpool.Pool) mfa.MFARepository {
+	return &mfaRepositoryImpl{db: db}
+}
+
+func (r *mfaRepositoryImpl) Get(ctx context.Context, userID string) (*mfa.Enrollment, error) {
+	enrollment := &mfa.Enrollment{}
+	err := r.db.QueryRow(ctx, `
+		SELECT user_id, secret, enabled_at, last_used_step, created_at
+		FROM user_mfa WHERE user_id = $1`, userID).Scan(
+		&enrollment.UserID, &enrollment.Secret, &enrollment.EnabledAt,
+		&enrollment.LastUsedStep, &enrollment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return enrollment, nil
+}
+
+func (r *mfaRepositoryImpl) SavePending(ctx context.Context, enrollment *mfa.Enrollment) error {
+	result, err := r.db.Exec(ctx, `
+		INSERT INTO user_mfa (user_id, secret, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, created_at = EXCLUDED.created_at, last_used_step = 0
+		WHERE user_mfa.enabled_at IS NULL`,
+		enrollment.UserID, enrollment.Secret, enrollment.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("validation failed: two-factor authentication is already enabled")
+	}
+
+	return nil
+}
+
+func (r *mfaRepositoryImpl) Enable(ctx context.Context, userID string, step int64, at time.Time, codeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE user_mfa SET enabled_at = $2, last_used_step = $3
+		WHERE user_id = $1 AND enabled_at IS NULL`,
+		userID, at, step)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("validation failed: no pending two-factor setup")
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *mfaRepositoryImpl) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE user_mfa SET last_used_step = $2
+		WHERE user_id = $1 AND last_used_step < $2`,
+		userID, step)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *mfaRepositoryImpl) UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) (bool, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE mfa_recovery_codes SET used_at = $3
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`,
+		userID, codeHash, at)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *mfaRepositoryImpl) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID string, codeHashes []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	for _, hash := range codeHashes {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO mfa_recovery_codes (id, user_id, code_hash)
+			VALUES ($1, $2, $3)`,
+			uuid.New().String(), userID, hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *mfaRepositoryImpl) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM mfa_recovery_codes
+		WHERE user_id = $1 AND used_at IS NULL`, userID).Scan(&count)
+
+	return count, err
+}
+
+func (r *mfaRepositoryImpl) Delete(ctx context.Context, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
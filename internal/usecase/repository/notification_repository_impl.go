@@ -2,10 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"evently/internal/domain/model"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -31,6 +34,27 @@ func (r *notificationRepositoryImpl) Create(notification *model.Notification) er
 	return err
 }
 
+func (r *notificationRepositoryImpl) GetByID(id string) (*model.Notification, error) {
+	query := `
+		SELECT id, user_id, event_id, type, title, message, is_read, created_at, updated_at
+		FROM notifications
+		WHERE id = $1`
+
+	notification := &model.Notification{}
+	err := r.db.QueryRow(context.Background(), query, id).Scan(
+		&notification.ID, &notification.UserID, &notification.EventID, &notification.Type,
+		&notification.Title, &notification.Message, &notification.IsRead,
+		&notification.CreatedAt, &notification.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("notification not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
 func (r *notificationRepositoryImpl) GetByUserID(userID string, limit, offset int) ([]*model.Notification, error) {
 	query := `
 		SELECT id, user_id, event_id, type, title, message, is_read, created_at, updated_at
@@ -61,10 +85,50 @@ func (r *notificationRepositoryImpl) GetByUserID(userID string, limit, offset in
 	return notifications, rows.Err()
 }
 
-func (r *notificationRepositoryImpl) MarkAsRead(id string) error {
-	query := `UPDATE notifications SET is_read = true WHERE id = $1`
+func (r *notificationRepositoryImpl) List(userID string, unreadOnly bool, after *model.NotificationCursor, limit int) ([]*model.Notification, error) {
+	var afterTime *time.Time
+	var afterID string
+	if after != nil {
+		afterTime, afterID = &after.CreatedAt, after.ID
+	}
 
-	result, err := r.db.Exec(context.Background(), query, id)
+	query := `
+		SELECT id, user_id, event_id, type, title, message, is_read, created_at, updated_at
+		FROM notifications
+		WHERE user_id = $1
+		  AND (NOT $2 OR is_read = false)
+		  AND ($3::TIMESTAMPTZ IS NULL OR (created_at, id) < ($3, $4))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5`
+
+	rows, err := r.db.Query(context.Background(), query, userID, unreadOnly, afterTime, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []*model.Notification{}
+	for rows.Next() {
+		notification := &model.Notification{}
+		err := rows.Scan(
+			&notification.ID, &notification.UserID, &notification.EventID, &notification.Type,
+			&notification.Title, &notification.Message, &notification.IsRead,
+			&notification.CreatedAt, &notification.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, rows.Err()
+}
+
+func (r *notificationRepositoryImpl) MarkAsRead(id, userID string) error {
+	query := `
+		UPDATE notifications SET is_read = true, updated_at = $3
+		WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(context.Background(), query, id, userID, time.Now())
 	if err != nil {
 		return err
 	}
@@ -77,12 +141,29 @@ func (r *notificationRepositoryImpl) MarkAsRead(id string) error {
 }
 
 func (r *notificationRepositoryImpl) MarkAllAsRead(userID string) error {
-	query := `UPDATE notifications SET is_read = true WHERE user_id = $1`
+	query := `
+		UPDATE notifications SET is_read = true, updated_at = $2
+		WHERE user_id = $1 AND is_read = false`
 
-	_, err := r.db.Exec(context.Background(), query, userID)
+	_, err := r.db.Exec(context.Background(), query, userID, time.Now())
 	return err
 }
 
+func (r *notificationRepositoryImpl) Delete(id, userID string) error {
+	query := `DELETE FROM notifications WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(context.Background(), query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("notification not found")
+	}
+
+	return nil
+}
+
 func (r *notificationRepositoryImpl) GetUnreadCount(userID string) (int, error) {
 	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND is_read = false`
 
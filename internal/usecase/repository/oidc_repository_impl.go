@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"evently/internal/domain/oidc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type oidcRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewOIDCRepository(db *pgxpool.Pool) oidc.OIDCRepository {
+	return &oidcRepositoryImpl{db: db}
+}
+
+func (r *oidcRepositoryImpl) SaveState(ctx context.Context, state *oidc.LoginState) error {
+	// Abandoned logins are cleared whenever a new one starts
+	_, err := r.db.Exec(ctx, `DELETE FROM oidc_login_states WHERE expires_at < $1`, state.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO oidc_login_states (state_hash, nonce, code_verifier, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		state.StateHash, state.Nonce, state.CodeVerifier, state.ExpiresAt, state.CreatedAt)
+
+	return err
+}
+
+func (r *oidcRepositoryImpl) ConsumeState(ctx context.Context, stateHash string, now time.Time) (*oidc.LoginState, error) {
+	state := &oidc.LoginState{}
+	err := r.db.QueryRow(ctx, `
+		DELETE FROM oidc_login_states
+		WHERE state_hash = $1 AND expires_at > $2
+		RETURNING state_hash, nonce, code_verifier, expires_at, created_at`,
+		stateHash, now).Scan(&state.StateHash, &state.Nonce, &state.CodeVerifier, &state.ExpiresAt, &state.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, oidc.ErrInvalidState
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func (r *oidcRepositoryImpl) GetIdentity(ctx context.Context, issuer, subject string) (*oidc.Identity, error) {
+	identity := &oidc.Identity{}
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, issuer, subject, email, created_at, last_login_at
+		FROM user_identities
+		WHERE issuer = $1 AND subject = $2`,
+		issuer, subject).Scan(&identity.ID, &identity.UserID, &identity.Issuer, &identity.Subject,
+		&identity.Email, &identity.CreatedAt, &identity.LastLoginAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("identity not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+func (r *oidcRepositoryImpl) CreateIdentity(ctx context.Context, identity *oidc.Identity) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_identities (id, user_id, issuer, subject, email, created_at, last_login_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		identity.ID, identity.UserID, identity.Issuer, identity.Subject, identity.Email,
+		identity.CreatedAt, identity.LastLoginAt)
+
+	return err
+}
+
+func (r *oidcRepositoryImpl) TouchIdentity(ctx context.Context, id, email string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE user_identities SET email = $2, last_login_at = $3
+		WHERE id = $1`,
+		id, email, at)
+
+	return err
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"evently/internal/domain/organizer"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type organizerRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewOrganizerRepository(db *pgxpool.Pool) organizer.OrganizerRepository {
+	return &organizerRepositoryImpl{db: db}
+}
+
+// managedByCondition matches rows of the events alias that the user given by
+// the userParam placeholder created or collaborates on. Sessions are matched
+// through their parent event.
+func managedByCondition(alias, userParam string) string {
+	return fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM events root
+			WHERE root.id = COALESCE(%[1]s.parent_event_id, %[1]s.id)
+				AND (root.created_by = %[2]s OR EXISTS (
+					SELECT 1 FROM event_collaborators ec
+					WHERE ec.event_id = root.id AND ec.user_id = %[2]s
+				))
+		)`, alias, userParam)
+}
+
+func (r *organizerRepositoryImpl) CanManageEvent(ctx context.Context, eventID, userID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM events e
+			WHERE e.id = $1 AND ` + managedByCondition("e", "$2") + `
+		)`
+
+	var ok bool
+	err := r.db.QueryRow(ctx, query, eventID, userID).Scan(&ok)
+
+	return ok, err
+}
+
+func (r *organizerRepositoryImpl) AddCollaborator(ctx context.Context, c *organizer.Collaborator) error {
+	query := `
+		INSERT INTO event_collaborators (event_id, user_id, added_by, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, user_id) DO NOTHING`
+
+	_, err := r.db.Exec(ctx, query, c.EventID, c.UserID, c.AddedBy, c.CreatedAt)
+
+	return err
+}
+
+func (r *organizerRepositoryImpl) RemoveCollaborator(ctx context.Context, eventID, userID string) error {
+	result, err := r.db.Exec(ctx,
+		`DELETE FROM event_collaborators WHERE event_id = $1 AND user_id = $2`, eventID, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("collaborator not found")
+	}
+
+	return nil
+}
+
+func (r *organizerRepositoryImpl) ListCollaborators(ctx context.Context, eventID string) ([]*organizer.Collaborator, error) {
+	query := `
+		SELECT ec.event_id, ec.user_id, u.name, u.email, ec.added_by, ec.created_at
+		FROM event_collaborators ec
+		JOIN users u ON u.id = ec.user_id
+		WHERE ec.event_id = $1
+		ORDER BY ec.created_at ASC`
+
+	rows, err := r.db.Query(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collaborators := []*organizer.Collaborator{}
+	for rows.Next() {
+		c := &organizer.Collaborator{}
+		err := rows.Scan(&c.EventID, &c.UserID, &c.Name, &c.Email, &c.AddedBy, &c.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		collaborators = append(collaborators, c)
+	}
+
+	return collaborators, rows.Err()
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"evently/internal/domain/pass"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type passRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewPassRepository(db *pgxpool.Pool) pass.PassRepository {
+	return &passRepositoryImpl{db: db}
+}
+
+func (r *passRepositoryImpl) Create(ctx context.Context, newPass *pass.Pass) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertPass(ctx, tx, newPass); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// insertPass stores a pass with its sessions.
+func insertPass(ctx context.Context, db dbExecutor, newPass *pass.Pass) error {
+	query := `
+		INSERT INTO passes (id, event_id, name, description, price, on_sale_at, off_sale_at,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := db.Exec(ctx, query,
+		newPass.ID, newPass.EventID, newPass.Name, newPass.Description, newPass.Price,
+		newPass.OnSaleAt, newPass.OffSaleAt, newPass.CreatedAt, newPass.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, sessionID := range newPass.SessionIDs {
+		_, err = db.Exec(ctx,
+			`INSERT INTO pass_sessions (pass_id, session_id) VALUES ($1, $2)`,
+			newPass.ID, sessionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *passRepositoryImpl) GetByID(ctx context.Context, id string) (*pass.Pass, error) {
+	query := `
+		SELECT p.id, p.event_id, p.name, p.description, p.price,
+			ARRAY(SELECT ps.session_id FROM pass_sessions ps WHERE ps.pass_id = p.id ORDER BY ps.session_id),
+			p.on_sale_at, p.off_sale_at, p.created_at, p.updated_at
+		FROM passes p WHERE p.id = $1`
+
+	p := &pass.Pass{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&p.ID, &p.EventID, &p.Name, &p.Description, &p.Price, &p.SessionIDs,
+		&p.OnSaleAt, &p.OffSaleAt, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (r *passRepositoryImpl) ListByEvent(ctx context.Context, eventID string) ([]*pass.Pass, error) {
+	query := `
+		SELECT p.id, p.event_id, p.name, p.description, p.price,
+			ARRAY(SELECT ps.session_id FROM pass_sessions ps WHERE ps.pass_id = p.id ORDER BY ps.session_id),
+			p.on_sale_at, p.off_sale_at, p.created_at, p.updated_at
+		FROM passes p
+		WHERE p.event_id = $1
+		ORDER BY p.price ASC, p.name ASC`
+
+	rows, err := r.db.Query(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var passes []*pass.Pass
+	for rows.Next() {
+		p := &pass.Pass{}
+		err := rows.Scan(
+			&p.ID, &p.EventID, &p.Name, &p.Description, &p.Price, &p.SessionIDs,
+			&p.OnSaleAt, &p.OffSaleAt, &p.CreatedAt, &p.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		passes = append(passes, p)
+	}
+
+	return passes, rows.Err()
+}
+
+func (r *passRepositoryImpl) Delete(ctx context.Context, eventID, id string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// The lock waits for pass bookings being made right now, which hold a
+	// key share lock on the row through bookings.pass_id
+	var locked string
+	err = tx.QueryRow(ctx,
+		`SELECT id FROM passes WHERE id = $1 AND event_id = $2 FOR UPDATE`,
+		id, eventID).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("pass not found")
+		}
+		return err
+	}
+
+	var confirmed int
+	err = tx.QueryRow(ctx,
+		`SELECT COUNT(*) FROM bookings WHERE pass_id = $1 AND status = 'confirmed'`,
+		id).Scan(&confirmed)
+	if err != nil {
+		return err
+	}
+	if confirmed > 0 {
+		return fmt.Errorf("%w: %d confirmed bookings", pass.ErrPassInUse, confirmed)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE bookings SET pass_id = NULL WHERE pass_id = $1`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM passes WHERE id = $1`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/internal/domain/presale"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type presaleRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewPresaleRepository(db *pgxpool.Pool) presale.PresaleRepository {
+	return &presaleRepositoryImpl{db: db}
+}
+
+const presaleColumns = `id, event_id, name, kind, starts_at, ends_at, source_event_ids, created_at`
+
+func collectPresales(rows pgx.Rows) ([]*presale.Presale, error) {
+	defer rows.Close()
+
+	var presales []*presale.Presale
+	for rows.Next() {
+		p := &presale.Presale{}
+		err := rows.Scan(&p.ID, &p.EventID, &p.Name, &p.Kind, &p.StartsAt, &p.EndsAt,
+			&p.SourceEventIDs, &p.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		presales = append(presales, p)
+	}
+
+	return presales, rows.Err()
+}
+
+func (r *presaleRepositoryImpl) Create(ctx context.Context, p *presale.Presale, accessCodeHash *string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	sourceEventIDs := p.SourceEventIDs
+	if sourceEventIDs == nil {
+		sourceEventIDs = []string{}
+	}
+
+	query := `
+		INSERT INTO presales (id, event_id, name, kind, starts_at, ends_at, access_code_hash,
+			source_event_ids, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = tx.Exec(ctx, query,
+		p.ID, p.EventID, p.Name, p.Kind, p.StartsAt, p.EndsAt, accessCodeHash,
+		sourceEventIDs, p.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range p.UserIDs {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO presale_users (presale_id, user_id) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING`,
+			p.ID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *presaleRepositoryImpl) Delete(ctx context.Context, eventID, presaleID string) error {
+	result, err := r.db.Exec(ctx,
+		`DELETE FROM presales WHERE id = $1 AND event_id = $2`, presaleID, eventID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("presale not found")
+	}
+
+	return nil
+}
+
+func (r *presaleRepositoryImpl) ListByEvent(ctx context.Context, eventID string) ([]*presale.Presale, error) {
+	query := `
+		SELECT ` + presaleColumns + `
+		FROM presales
+		WHERE event_id = $1
+		ORDER BY starts_at ASC`
+
+	rows, err := r.db.Query(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectPresales(rows)
+}
+
+func (r *presaleRepositoryImpl) HasAccess(ctx context.Context, eventID, userID, accessCodeHash string, at time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM presales p
+			WHERE p.event_id = $1 AND p.starts_at <= $4 AND p.ends_at > $4
+				AND (
+					(p.kind = 'access_code' AND $3 <> '' AND p.access_code_hash = $3)
+					OR (p.kind = 'user_list' AND EXISTS (
+						SELECT 1 FROM presale_users pu
+						WHERE pu.presale_id = p.id AND pu.user_id = $2
+					))
+					OR (p.kind = 'previous_attendees' AND EXISTS (
+						SELECT 1 FROM bookings b
+						WHERE b.user_id = $2 AND b.status = 'confirmed'
+							AND b.event_id = ANY(p.source_event_ids)
+					))
+				)
+		)`
+
+	var ok bool
+	err := r.db.QueryRow(ctx, query, eventID, userID, accessCodeHash, at).Scan(&ok)
+
+	return ok, err
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"evently/internal/domain/privacy"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type privacyRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewPrivacyRepository(db *pgxpool.Pool) privacy.PrivacyRepository {
+	return &privacyRepositoryImpl{db: db}
+}
+
+func (r *privacyRepositoryImpl) ScheduleDeletion(ctx context.Context, userID string, at *time.Time) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE users SET deletion_scheduled_at = $2
+		WHERE id = $1 AND anonymized_at IS NULL`,
+		userID, at)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+func (r *privacyRepositoryImpl) ListDueForDeletion(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id FROM users
+		WHERE deletion_scheduled_at <= $1 AND anonymized_at IS NULL
+		ORDER BY deletion_scheduled_at
+		LIMIT $2`,
+		now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (r *privacyRepositoryImpl) Anonymize(ctx context.Context, userID string, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var email string
+	err = tx.QueryRow(ctx, `
+		SELECT LOWER(email) FROM users
+		WHERE id = $1 AND anonymized_at IS NULL
+		FOR UPDATE`,
+		userID).Scan(&email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("user not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	// The placeholder address keeps the unique constraint satisfied and
+	// can never receive mail. An empty hash matches no password.
+	_, err = tx.Exec(ctx, `
+		UPDATE users SET
+			name = 'Deleted user',
+			email = 'deleted-' || id || '@anonymized.invalid',
+			password = '',
+			role = 'user',
+			email_verified_at = NULL,
+			pending_email = NULL,
+			phone = NULL,
+			disabled_at = $2,
+			deletion_scheduled_at = NULL,
+			anonymized_at = $2
+		WHERE id = $1`,
+		userID, at)
+	if err != nil {
+		return err
+	}
+
+	// Keys stay listed, revoked, so audit entries made with them still
+	// resolve
+	_, err = tx.Exec(ctx, `
+		UPDATE api_keys SET revoked_at = $2
+		WHERE created_by = $1 AND revoked_at IS NULL`,
+		userID, at)
+	if err != nil {
+		return err
+	}
+
+	statements := []string{
+		`DELETE FROM waitlist WHERE user_id = $1`,
+		`DELETE FROM notifications WHERE user_id = $1`,
+		`DELETE FROM push_subscriptions WHERE user_id = $1`,
+		`DELETE FROM calendar_feed_tokens WHERE user_id = $1`,
+		`DELETE FROM presale_users WHERE user_id = $1`,
+		`DELETE FROM event_collaborators WHERE user_id = $1`,
+		`DELETE FROM refresh_tokens WHERE user_id = $1`,
+		`DELETE FROM account_tokens WHERE user_id = $1`,
+		`DELETE FROM mfa_recovery_codes WHERE user_id = $1`,
+		`DELETE FROM user_mfa WHERE user_id = $1`,
+		`DELETE FROM user_identities WHERE user_id = $1`,
+		`UPDATE audit_logs SET metadata = metadata - 'email' WHERE target_type = 'user' AND target_id = $1`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement, userID); err != nil {
+			return err
+		}
+	}
+
+	// Records keyed by the address itself
+	statements = []string{
+		`DELETE FROM login_failures WHERE email = $1`,
+		`DELETE FROM login_lockouts WHERE email = $1`,
+		`DELETE FROM invitations WHERE LOWER(email) = $1`,
+		`UPDATE audit_logs SET target_id = '' WHERE target_type = 'email' AND target_id = $1`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement, email); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"evently/internal/domain/dispatch"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pushSubscriptionRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewPushSubscriptionRepository(db *pgxpool.Pool) dispatch.PushSubscriptionRepository {
+	return &pushSubscriptionRepositoryImpl{db: db}
+}
+
+const pushSubscriptionColumns = `id, user_id, endpoint, p256dh, auth, created_at`
+
+func scanPushSubscription(row pgx.Row) (*dispatch.PushSubscription, error) {
+	subscription := &dispatch.PushSubscription{}
+	err := row.Scan(&subscription.ID, &subscription.UserID, &subscription.Endpoint,
+		&subscription.P256dh, &subscription.Auth, &subscription.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (r *pushSubscriptionRepositoryImpl) Save(ctx context.Context, subscription *dispatch.PushSubscription) (*dispatch.PushSubscription, error) {
+	// A browser has one endpoint at a time, whoever signed in last owns it
+	return scanPushSubscription(r.db.QueryRow(ctx, `
+		INSERT INTO push_subscriptions (id, user_id, endpoint, p256dh, auth, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (endpoint) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			p256dh = EXCLUDED.p256dh,
+			auth = EXCLUDED.auth
+		RETURNING `+pushSubscriptionColumns,
+		subscription.ID, subscription.UserID, subscription.Endpoint, subscription.P256dh,
+		subscription.Auth, subscription.CreatedAt))
+}
+
+func (r *pushSubscriptionRepositoryImpl) GetByID(ctx context.Context, id string) (*dispatch.PushSubscription, error) {
+	subscription, err := scanPushSubscription(r.db.QueryRow(ctx,
+		`SELECT `+pushSubscriptionColumns+` FROM push_subscriptions WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("push subscription not found")
+	}
+	return subscription, err
+}
+
+func (r *pushSubscriptionRepositoryImpl) ListByUser(ctx context.Context, userID string) ([]*dispatch.PushSubscription, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+pushSubscriptionColumns+`
+		FROM push_subscriptions
+		WHERE user_id = $1
+		ORDER BY created_at DESC`,
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []*dispatch.PushSubscription{}
+	for rows.Next() {
+		subscription, err := scanPushSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+func (r *pushSubscriptionRepositoryImpl) Delete(ctx context.Context, id, userID string) error {
+	result, err := r.db.Exec(ctx, `
+		DELETE FROM push_subscriptions
+		WHERE id = $1 AND ($2 = '' OR user_id = $2)`,
+		id, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("push subscription not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"evently/internal/domain/auth"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type refreshTokenRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewRefreshTokenRepository(db *pgxpool.Pool) auth.RefreshTokenRepository {
+	return &refreshTokenRepositoryImpl{db: db}
+}
+
+func (r *refreshTokenRepositoryImpl) Create(ctx context.Context, token *auth.RefreshToken) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		token.ID, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+
+	return err
+}
+
+func (r *refreshTokenRepositoryImpl) GetByHash(ctx context.Context, tokenHash string) (*auth.RefreshToken, error) {
+	token := &auth.RefreshToken{}
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, family_id, token_hash, expires_at, created_at, used_at, replaced_by, revoked_at
+		FROM refresh_tokens
+		WHERE token_hash = $1`, tokenHash).Scan(
+		&token.ID, &token.UserID, &token.FamilyID, &token.TokenHash, &token.ExpiresAt,
+		&token.CreatedAt, &token.UsedAt, &token.ReplacedBy, &token.RevokedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (r *refreshTokenRepositoryImpl) Rotate(ctx context.Context, usedID string, next *auth.RefreshToken, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Two concurrent refreshes with the same token serialize on the row;
+	// the loser finds it already used
+	result, err := tx.Exec(ctx, `
+		UPDATE refresh_tokens SET used_at = $2, replaced_by = $3
+		WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL`,
+		usedID, at, next.ID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return auth.ErrRefreshTokenReused
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		next.ID, next.UserID, next.FamilyID, next.TokenHash, next.ExpiresAt, next.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *refreshTokenRepositoryImpl) RevokeFamily(ctx context.Context, familyID string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE refresh_tokens SET revoked_at = $2
+		WHERE family_id = $1 AND revoked_at IS NULL`,
+		familyID, at)
+
+	return err
+}
+
+func (r *refreshTokenRepositoryImpl) RevokeAllForUser(ctx context.Context, userID string, at time.Time) (int, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE refresh_tokens SET revoked_at = $2
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2`,
+		userID, at)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.RowsAffected()), nil
+}
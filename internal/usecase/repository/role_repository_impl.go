@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"evently/internal/domain/rbac"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type roleRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewRoleRepository(db *pgxpool.Pool) rbac.RoleRepository {
+	return &roleRepositoryImpl{db: db}
+}
+
+const roleColumns = `
+	r.name, r.description, r.is_system, r.created_at, r.updated_at,
+	ARRAY(SELECT rp.permission FROM role_permissions rp WHERE rp.role = r.name ORDER BY rp.permission)`
+
+func scanRole(row pgx.Row) (*rbac.Role, error) {
+	role := &rbac.Role{}
+	err := row.Scan(&role.Name, &role.Description, &role.IsSystem, &role.CreatedAt, &role.UpdatedAt,
+		&role.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *roleRepositoryImpl) ListPermissions(ctx context.Context) ([]*rbac.Permission, error) {
+	rows, err := r.db.Query(ctx, `SELECT name, description FROM permissions ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var permissions []*rbac.Permission
+	for rows.Next() {
+		p := &rbac.Permission{}
+		if err := rows.Scan(&p.Name, &p.Description); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, p)
+	}
+
+	return permissions, rows.Err()
+}
+
+func (r *roleRepositoryImpl) ListRoles(ctx context.Context) ([]*rbac.Role, error) {
+	query := `
+		SELECT ` + roleColumns + `
+		FROM roles r
+		ORDER BY r.is_system DESC, r.name ASC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []*rbac.Role
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+func (r *roleRepositoryImpl) GetRole(ctx context.Context, name string) (*rbac.Role, error) {
+	query := `
+		SELECT ` + roleColumns + `
+		FROM roles r
+		WHERE r.name = $1`
+
+	return scanRole(r.db.QueryRow(ctx, query, name))
+}
+
+func (r *roleRepositoryImpl) CreateRole(ctx context.Context, role *rbac.Role) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO roles (name, description, is_system, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		role.Name, role.Description, role.IsSystem, role.CreatedAt, role.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := setRolePermissions(ctx, tx, role); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *roleRepositoryImpl) UpdateRole(ctx context.Context, role *rbac.Role) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE roles SET description = $2, updated_at = $3
+		WHERE name = $1`,
+		role.Name, role.Description, role.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("role not found")
+	}
+
+	if err := setRolePermissions(ctx, tx, role); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// setRolePermissions replaces the permissions of the role.
+func setRolePermissions(ctx context.Context, tx pgx.Tx, role *rbac.Role) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM role_permissions WHERE role = $1`, role.Name); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO role_permissions (role, permission)
+		SELECT $1, UNNEST($2::VARCHAR[])
+		ON CONFLICT DO NOTHING`,
+		role.Name, role.Permissions)
+
+	return err
+}
+
+func (r *roleRepositoryImpl) DeleteRole(ctx context.Context, name string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var inUse bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE role = $1)`, name).Scan(&inUse)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return rbac.ErrRoleInUse
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM roles WHERE name = $1 AND NOT is_system`, name)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("role not found")
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *roleRepositoryImpl) SetUserRole(ctx context.Context, userID, role string) error {
+	result, err := r.db.Exec(ctx, `UPDATE users SET role = $2 WHERE id = $1`, userID, role)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
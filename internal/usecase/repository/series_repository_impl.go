@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/series"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type seriesRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewSeriesRepository(db *pgxpool.Pool) series.SeriesRepository {
+	return &seriesRepositoryImpl{db: db}
+}
+
+const seriesColumns = `id, name, description, venue, venue_id, start_time, timezone, rrule, exceptions,
+			total_capacity, price, created_by, created_at, updated_at`
+
+func scanSeries(row pgx.Row) (*series.Series, error) {
+	s := &series.Series{}
+	err := row.Scan(
+		&s.ID, &s.Name, &s.Description, &s.Venue, &s.VenueID, &s.StartTime, &s.Timezone, &s.RRule, &s.Exceptions,
+		&s.TotalCapacity, &s.Price, &s.CreatedBy, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (r *seriesRepositoryImpl) CreateWithOccurrences(ctx context.Context, newSeries *series.Series, occurrences []*events.Event) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO event_series (id, name, description, venue, venue_id, start_time, timezone, rrule, exceptions,
+			total_capacity, price, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	_, err = tx.Exec(ctx, query,
+		newSeries.ID, newSeries.Name, newSeries.Description, newSeries.Venue, newSeries.VenueID, newSeries.StartTime, newSeries.Timezone,
+		newSeries.RRule, newSeries.Exceptions, newSeries.TotalCapacity, newSeries.Price,
+		newSeries.CreatedBy, newSeries.CreatedAt, newSeries.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, occurrence := range occurrences {
+		if err := insertEvent(ctx, tx, occurrence); err != nil {
+			return fmt.Errorf("failed to create occurrence: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *seriesRepositoryImpl) UpdateWithOccurrences(ctx context.Context, s *series.Series, occurrences []*events.Event) ([]int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	freedSeats := make([]int, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		freed, err := updateEventWithCapacity(ctx, tx, occurrence)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update occurrence %s: %w", occurrence.ID, err)
+		}
+		freedSeats = append(freedSeats, freed)
+	}
+
+	if s != nil {
+		query := `
+			UPDATE event_series
+			SET name = $2, description = $3, venue = $4, venue_id = $5, total_capacity = $6,
+				price = $7, updated_at = $8
+			WHERE id = $1`
+
+		result, err := tx.Exec(ctx, query,
+			s.ID, s.Name, s.Description, s.Venue, s.VenueID, s.TotalCapacity, s.Price, s.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.RowsAffected() == 0 {
+			return nil, fmt.Errorf("series not found")
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return freedSeats, nil
+}
+
+func (r *seriesRepositoryImpl) GetByID(ctx context.Context, id string) (*series.Series, error) {
+	query := `SELECT ` + seriesColumns + ` FROM event_series WHERE id = $1`
+
+	return scanSeries(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *seriesRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*series.Series, error) {
+	query := `
+		SELECT ` + seriesColumns + `
+		FROM event_series
+		ORDER BY start_time DESC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*series.Series
+	for rows.Next() {
+		s, err := scanSeries(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *seriesRepositoryImpl) GetOccurrences(ctx context.Context, seriesID string, from time.Time, limit, offset int) ([]*events.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events e
+		WHERE e.series_id = $1 AND e.event_time >= $2
+		ORDER BY e.event_time ASC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.Query(ctx, query, seriesID, from, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectEvents(rows)
+}
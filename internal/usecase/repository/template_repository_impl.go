@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"evently/internal/domain/events"
+	"evently/internal/domain/pass"
+	"evently/internal/domain/template"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type templateRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewTemplateRepository(db *pgxpool.Pool) template.TemplateRepository {
+	return &templateRepositoryImpl{db: db}
+}
+
+const templateColumns = `id, name, description, config, created_by, created_at, updated_at`
+
+func scanTemplate(row pgx.Row) (*template.Template, error) {
+	t := &template.Template{}
+	err := row.Scan(&t.ID, &t.Name, &t.Description, &t.Config, &t.CreatedBy, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (r *templateRepositoryImpl) Create(ctx context.Context, t *template.Template) error {
+	query := `
+		INSERT INTO event_templates (id, name, description, config, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.Exec(ctx, query,
+		t.ID, t.Name, t.Description, t.Config, t.CreatedBy, t.CreatedAt, t.UpdatedAt)
+
+	return templateWriteError(err, t)
+}
+
+// templateWriteError reports a taken template name as ErrDuplicateName.
+func templateWriteError(err error, t *template.Template) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return fmt.Errorf("%w: %q", template.ErrDuplicateName, t.Name)
+	}
+	return err
+}
+
+func (r *templateRepositoryImpl) Update(ctx context.Context, t *template.Template) error {
+	query := `
+		UPDATE event_templates
+		SET name = $2, description = $3, config = $4, updated_at = $5
+		WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, t.ID, t.Name, t.Description, t.Config, t.UpdatedAt)
+	if err != nil {
+		return templateWriteError(err, t)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("template not found")
+	}
+
+	return nil
+}
+
+func (r *templateRepositoryImpl) Delete(ctx context.Context, id string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM event_templates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("template not found")
+	}
+
+	return nil
+}
+
+func (r *templateRepositoryImpl) GetByID(ctx context.Context, id string) (*template.Template, error) {
+	query := `SELECT ` + templateColumns + ` FROM event_templates WHERE id = $1`
+
+	return scanTemplate(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *templateRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*template.Template, error) {
+	query := `
+		SELECT ` + templateColumns + `
+		FROM event_templates
+		ORDER BY name ASC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []*template.Template
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+
+	return templates, rows.Err()
+}
+
+func (r *templateRepositoryImpl) CreateClone(ctx context.Context, event *events.Event, sessions []*events.Event, passes []*pass.Pass) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertEvent(ctx, tx, event); err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if err := insertEvent(ctx, tx, session); err != nil {
+			return err
+		}
+	}
+	for _, p := range passes {
+		if err := insertPass(ctx, tx, p); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -2,9 +2,15 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"evently/internal/domain/audit"
 	"evently/internal/domain/model"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -16,26 +22,37 @@ func NewUserRepository(db *pgxpool.Pool) model.UserRepository {
 	return &userRepositoryImpl{db: db}
 }
 
+const userColumns = `id, name, email, password, role, email_verified_at, pending_email, phone, disabled_at,
+	deletion_scheduled_at, anonymized_at, created_at`
+
+func scanUser(row pgx.Row) (*model.User, error) {
+	user := &model.User{}
+	err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Role,
+		&user.EmailVerifiedAt, &user.PendingEmail, &user.Phone, &user.DisabledAt, &user.DeletionScheduledAt, &user.AnonymizedAt,
+		&user.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 func (r *userRepositoryImpl) Create(user *model.User) error {
 	query := `
-		INSERT INTO users (id, name, email, password, role, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+		INSERT INTO users (id, name, email, password, role, email_verified_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
 
 	_, err := r.db.Exec(context.Background(), query,
-		user.ID, user.Name, user.Email, user.Password, user.Role, user.CreatedAt)
+		user.ID, user.Name, user.Email, user.Password, user.Role, user.EmailVerifiedAt, user.CreatedAt)
 
 	return err
 }
 
 func (r *userRepositoryImpl) GetByID(id string) (*model.User, error) {
 	query := `
-		SELECT id, name, email, password, role, created_at
+		SELECT ` + userColumns + `
 		FROM users WHERE id = $1`
 
-	user := &model.User{}
-	err := r.db.QueryRow(context.Background(), query, id).Scan(
-		&user.ID, &user.Name, &user.Email, &user.Password, &user.Role, &user.CreatedAt)
-
+	user, err := scanUser(r.db.QueryRow(context.Background(), query, id))
 	if err != nil {
 		return nil, err
 	}
@@ -45,16 +62,177 @@ func (r *userRepositoryImpl) GetByID(id string) (*model.User, error) {
 
 func (r *userRepositoryImpl) GetByEmail(email string) (*model.User, error) {
 	query := `
-		SELECT id, name, email, password, role, created_at
+		SELECT ` + userColumns + `
 		FROM users WHERE email = $1`
 
-	user := &model.User{}
-	err := r.db.QueryRow(context.Background(), query, email).Scan(
-		&user.ID, &user.Name, &user.Email, &user.Password, &user.Role, &user.CreatedAt)
-
+	user, err := scanUser(r.db.QueryRow(context.Background(), query, email))
 	if err != nil {
 		return nil, err
 	}
 
 	return user, nil
 }
+
+func (r *userRepositoryImpl) CountByRole(role string) (int, error) {
+	var count int
+	err := r.db.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM users WHERE role = $1`, role).Scan(&count)
+
+	return count, err
+}
+
+func (r *userRepositoryImpl) UpdatePassword(id, hashedPassword string) error {
+	_, err := r.db.Exec(context.Background(),
+		`UPDATE users SET password = $2 WHERE id = $1`, id, hashedPassword)
+
+	return err
+}
+
+func (r *userRepositoryImpl) ChangePassword(ctx context.Context, id, hashedPassword string, at time.Time, entry *audit.Entry) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `UPDATE users SET password = $2 WHERE id = $1`, id, hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE refresh_tokens SET revoked_at = $2
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2`,
+		id, at)
+	if err != nil {
+		return err
+	}
+
+	if err := insertAuditEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *userRepositoryImpl) MarkEmailVerified(id string, at time.Time) error {
+	_, err := r.db.Exec(context.Background(),
+		`UPDATE users SET email_verified_at = $2 WHERE id = $1 AND email_verified_at IS NULL`, id, at)
+
+	return err
+}
+
+func (r *userRepositoryImpl) UpdateName(id, name string) error {
+	_, err := r.db.Exec(context.Background(),
+		`UPDATE users SET name = $2 WHERE id = $1`, id, name)
+
+	return err
+}
+
+func (r *userRepositoryImpl) UpdatePhone(id string, phone *string) error {
+	_, err := r.db.Exec(context.Background(),
+		`UPDATE users SET phone = $2 WHERE id = $1`, id, phone)
+
+	return err
+}
+
+func (r *userRepositoryImpl) SetPendingEmail(id string, email *string) error {
+	_, err := r.db.Exec(context.Background(),
+		`UPDATE users SET pending_email = $2 WHERE id = $1`, id, email)
+
+	return err
+}
+
+func (r *userRepositoryImpl) ConfirmEmailChange(id string, at time.Time) error {
+	result, err := r.db.Exec(context.Background(), `
+		UPDATE users SET email = pending_email, pending_email = NULL, email_verified_at = $2
+		WHERE id = $1 AND pending_email IS NOT NULL`,
+		id, at)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return fmt.Errorf("validation failed: the new email is already in use")
+	}
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("validation failed: no email change is pending")
+	}
+
+	return nil
+}
+
+func (r *userRepositoryImpl) SetDisabled(id string, at *time.Time) error {
+	result, err := r.db.Exec(context.Background(),
+		`UPDATE users SET disabled_at = $2 WHERE id = $1`, id, at)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+func (r *userRepositoryImpl) List(filter model.UserFilter) ([]*model.User, int, error) {
+	rows, err := r.db.Query(context.Background(), `
+		SELECT `+userColumns+`, COUNT(*) OVER ()
+		FROM users
+		WHERE ($1 = '' OR name ILIKE '%' || $1 || '%' OR email ILIKE '%' || $1 || '%')
+		  AND ($2 = '' OR role = $2)
+		  AND ($3 = '' OR ($3 = 'active' AND disabled_at IS NULL) OR ($3 = 'disabled' AND disabled_at IS NOT NULL))
+		ORDER BY created_at DESC, id
+		LIMIT $4 OFFSET $5`,
+		filter.Query, filter.Role, filter.Status, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []*model.User{}
+	total := 0
+	for rows.Next() {
+		user := &model.User{}
+		err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Role,
+			&user.EmailVerifiedAt, &user.PendingEmail, &user.Phone, &user.DisabledAt, &user.DeletionScheduledAt, &user.AnonymizedAt,
+			&user.CreatedAt, &total)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+
+	return users, total, rows.Err()
+}
+
+func (r *userRepositoryImpl) GetActivitySummary(userID string) (*model.ActivitySummary, error) {
+	summary := &model.ActivitySummary{}
+	err := r.db.QueryRow(context.Background(), `
+		SELECT
+			COUNT(*) FILTER (WHERE b.status = 'confirmed'),
+			COUNT(*) FILTER (WHERE b.status = 'pending'),
+			COUNT(*) FILTER (WHERE b.status = 'cancelled'),
+			COUNT(*) FILTER (WHERE b.status = 'confirmed' AND e.event_time > NOW()),
+			COALESCE(SUM(b.quantity) FILTER (WHERE b.status = 'confirmed'), 0)
+		FROM bookings b
+		JOIN events e ON e.id = b.event_id
+		WHERE b.user_id = $1`, userID).Scan(
+		&summary.Bookings.Confirmed, &summary.Bookings.Pending, &summary.Bookings.Cancelled,
+		&summary.Bookings.Upcoming, &summary.Bookings.Tickets)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.QueryRow(context.Background(), `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'active'),
+			COUNT(*) FILTER (WHERE status = 'notified')
+		FROM waitlist
+		WHERE user_id = $1`, userID).Scan(&summary.Waitlist.Active, &summary.Waitlist.Notified)
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
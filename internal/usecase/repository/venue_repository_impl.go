@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"evently/internal/domain/venue"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type venueRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewVenueRepository(db *pgxpool.Pool) venue.VenueRepository {
+	return &venueRepositoryImpl{db: db}
+}
+
+const venueColumns = `id, name, address, timezone, latitude, longitude, max_capacity,
+			wheelchair_accessible, accessibility_notes, created_at, updated_at`
+
+func scanVenue(row pgx.Row) (*venue.Venue, error) {
+	v := &venue.Venue{}
+	err := row.Scan(
+		&v.ID, &v.Name, &v.Address, &v.Timezone, &v.Latitude, &v.Longitude, &v.MaxCapacity,
+		&v.WheelchairAccessible, &v.AccessibilityNotes, &v.CreatedAt, &v.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (r *venueRepositoryImpl) Create(ctx context.Context, v *venue.Venue) error {
+	query := `
+		INSERT INTO venues (id, name, address, timezone, latitude, longitude, max_capacity,
+			wheelchair_accessible, accessibility_notes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.db.Exec(ctx, query,
+		v.ID, v.Name, v.Address, v.Timezone, v.Latitude, v.Longitude, v.MaxCapacity,
+		v.WheelchairAccessible, v.AccessibilityNotes, v.CreatedAt, v.UpdatedAt)
+
+	return err
+}
+
+func (r *venueRepositoryImpl) Update(ctx context.Context, v *venue.Venue) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE venues
+		SET name = $2, address = $3, timezone = $4, latitude = $5, longitude = $6,
+			max_capacity = $7, wheelchair_accessible = $8, accessibility_notes = $9,
+			updated_at = $10
+		WHERE id = $1`
+
+	result, err := tx.Exec(ctx, query,
+		v.ID, v.Name, v.Address, v.Timezone, v.Latitude, v.Longitude, v.MaxCapacity,
+		v.WheelchairAccessible, v.AccessibilityNotes, v.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("venue not found")
+	}
+
+	// Events keep a copy of the venue name for display and full-text search
+	if _, err := tx.Exec(ctx, `UPDATE events SET venue = $2 WHERE venue_id = $1`, v.ID, v.Name); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE event_series SET venue = $2 WHERE venue_id = $1`, v.ID, v.Name); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *venueRepositoryImpl) Delete(ctx context.Context, id string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM venues WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("venue not found")
+	}
+
+	return nil
+}
+
+func (r *venueRepositoryImpl) GetByID(ctx context.Context, id string) (*venue.Venue, error) {
+	query := `SELECT ` + venueColumns + ` FROM venues WHERE id = $1`
+
+	return scanVenue(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *venueRepositoryImpl) GetByName(ctx context.Context, name string) (*venue.Venue, error) {
+	query := `
+		SELECT ` + venueColumns + `
+		FROM venues
+		WHERE LOWER(regexp_replace(TRIM(name), '\s+', ' ', 'g')) = LOWER(regexp_replace(TRIM($1), '\s+', ' ', 'g'))`
+
+	return scanVenue(r.db.QueryRow(ctx, query, name))
+}
+
+func (r *venueRepositoryImpl) List(ctx context.Context, search string, limit, offset int) ([]*venue.Venue, error) {
+	query := `
+		SELECT ` + venueColumns + `
+		FROM venues
+		WHERE $1 = '' OR name ILIKE '%' || $1 || '%' OR address ILIKE '%' || $1 || '%'
+		ORDER BY name ASC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(ctx, query, search, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var venues []*venue.Venue
+	for rows.Next() {
+		v, err := scanVenue(rows)
+		if err != nil {
+			return nil, err
+		}
+		venues = append(venues, v)
+	}
+
+	return venues, rows.Err()
+}
+
+func (r *venueRepositoryImpl) MaxUpcomingCapacity(ctx context.Context, venueID string) (int, error) {
+	query := `
+		SELECT COALESCE(MAX(total_capacity), 0)
+		FROM events
+		WHERE venue_id = $1 AND event_time > NOW()`
+
+	var capacity int
+	err := r.db.QueryRow(ctx, query, venueID).Scan(&capacity)
+
+	return capacity, err
+}
+
+func (r *venueRepositoryImpl) FindNearbyEvents(ctx context.Context, lat, lng, radiusKM float64, limit, offset int) ([]*venue.NearbyEvent, error) {
+	// Great-circle distance with the haversine formula. The bounding box on
+	// latitude lets the coordinates index discard far away venues first; one
+	// degree of latitude is about 111.045 km.
+	query := `
+		WITH nearby AS (
+			SELECT id, 2 * 6371 * asin(sqrt(
+				power(sin(radians(latitude - $1) / 2), 2) +
+				cos(radians($1)) * cos(radians(latitude)) *
+				power(sin(radians(longitude - $2) / 2), 2)
+			)) AS distance_km
+			FROM venues
+			WHERE latitude IS NOT NULL AND longitude IS NOT NULL
+				AND latitude BETWEEN $1 - $3 / 111.045 AND $1 + $3 / 111.045
+		)
+		SELECT ` + eventColumns + `, n.distance_km
+		FROM events e
+		JOIN nearby n ON n.id = e.venue_id
+		WHERE n.distance_km <= $3 AND e.event_time > NOW() AND e.parent_event_id IS NULL
+		ORDER BY n.distance_km ASC, e.event_time ASC
+		LIMIT $4 OFFSET $5`
+
+	rows, err := r.db.Query(ctx, query, lat, lng, radiusKM, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*venue.NearbyEvent
+	for rows.Next() {
+		var distance float64
+		event, err := scanEvent(rowWithExtra{rows, &distance})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &venue.NearbyEvent{Event: event, DistanceKM: distance})
+	}
+
+	return results, rows.Err()
+}
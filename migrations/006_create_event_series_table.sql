@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS event_series (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    venue VARCHAR(255) NOT NULL,
+    start_time TIMESTAMP NOT NULL,
+    rrule VARCHAR(255) NOT NULL,
+    exceptions TIMESTAMP[] NOT NULL DEFAULT '{}',
+    total_capacity INTEGER NOT NULL CHECK (total_capacity > 0),
+    price DECIMAL(10,2) NOT NULL DEFAULT 0.00 CHECK (price >= 0),
+    created_by VARCHAR(36),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE events ADD COLUMN series_id VARCHAR(36) REFERENCES event_series(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_events_series_id ON events(series_id, event_time) WHERE series_id IS NOT NULL;
+
+-- +goose Down
+ALTER TABLE events DROP COLUMN IF EXISTS series_id;
+DROP TABLE IF EXISTS event_series;
@@ -0,0 +1,39 @@
+-- +goose Up
+ALTER TABLE events ADD COLUMN parent_event_id VARCHAR(36) REFERENCES events(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_events_parent_event_id ON events(parent_event_id, event_time) WHERE parent_event_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS passes (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    price DECIMAL(10,2) NOT NULL DEFAULT 0.00 CHECK (price >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_passes_event_id ON passes(event_id);
+
+CREATE TABLE IF NOT EXISTS pass_sessions (
+    pass_id VARCHAR(36) NOT NULL,
+    session_id VARCHAR(36) NOT NULL,
+
+    PRIMARY KEY (pass_id, session_id),
+    FOREIGN KEY (pass_id) REFERENCES passes(id) ON DELETE CASCADE,
+    FOREIGN KEY (session_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_pass_sessions_session_id ON pass_sessions(session_id);
+
+ALTER TABLE bookings ADD COLUMN pass_id VARCHAR(36) REFERENCES passes(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_bookings_pass_id ON bookings(pass_id) WHERE pass_id IS NOT NULL;
+
+-- +goose Down
+ALTER TABLE bookings DROP COLUMN IF EXISTS pass_id;
+DROP TABLE IF EXISTS pass_sessions;
+DROP TABLE IF EXISTS passes;
+ALTER TABLE events DROP COLUMN IF EXISTS parent_event_id;
@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE events ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(venue, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'C')
+) STORED;
+
+CREATE INDEX idx_events_search_vector ON events USING GIN (search_vector);
+CREATE INDEX idx_events_price ON events(price);
+CREATE INDEX idx_events_venue_lower ON events(LOWER(venue));
+CREATE INDEX idx_events_popularity ON events((total_capacity - available_seats) DESC);
+CREATE INDEX idx_events_has_seats ON events(event_time) WHERE available_seats > 0;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_events_has_seats;
+DROP INDEX IF EXISTS idx_events_popularity;
+DROP INDEX IF EXISTS idx_events_venue_lower;
+DROP INDEX IF EXISTS idx_events_price;
+DROP INDEX IF EXISTS idx_events_search_vector;
+ALTER TABLE events DROP COLUMN IF EXISTS search_vector;
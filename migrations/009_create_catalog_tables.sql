@@ -0,0 +1,66 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS categories (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    parent_id VARCHAR(36),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (parent_id) REFERENCES categories(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS event_tags (
+    event_id VARCHAR(36) NOT NULL,
+    tag_id VARCHAR(36) NOT NULL,
+
+    PRIMARY KEY (event_id, tag_id),
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_event_tags_tag_id ON event_tags(tag_id);
+
+ALTER TABLE events ADD COLUMN category_id VARCHAR(36) REFERENCES categories(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_events_category_id ON events(category_id);
+
+CREATE TABLE IF NOT EXISTS collections (
+    id VARCHAR(36) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(36),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS collection_events (
+    collection_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    position INTEGER NOT NULL,
+
+    PRIMARY KEY (collection_id, event_id),
+    FOREIGN KEY (collection_id) REFERENCES collections(id) ON DELETE CASCADE,
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_collection_events_position ON collection_events(collection_id, position);
+
+-- +goose Down
+DROP TABLE IF EXISTS collection_events;
+DROP TABLE IF EXISTS collections;
+ALTER TABLE events DROP COLUMN IF EXISTS category_id;
+DROP TABLE IF EXISTS event_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,51 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS venues (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    address TEXT NOT NULL DEFAULT '',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    max_capacity INTEGER NOT NULL CHECK (max_capacity > 0),
+    wheelchair_accessible BOOLEAN NOT NULL DEFAULT FALSE,
+    accessibility_notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- One row per hall regardless of case or spacing
+CREATE UNIQUE INDEX idx_venues_normalized_name ON venues(LOWER(regexp_replace(TRIM(name), '\s+', ' ', 'g')));
+CREATE INDEX idx_venues_coordinates ON venues(latitude, longitude) WHERE latitude IS NOT NULL AND longitude IS NOT NULL;
+
+ALTER TABLE events ADD COLUMN venue_id VARCHAR(36) REFERENCES venues(id) ON DELETE RESTRICT;
+ALTER TABLE event_series ADD COLUMN venue_id VARCHAR(36) REFERENCES venues(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_events_venue_id ON events(venue_id);
+
+-- Collapse the existing free-text venues into venue rows. Spellings that
+-- only differ in case or whitespace become the same venue, sized to the
+-- largest event already held there.
+INSERT INTO venues (id, name, max_capacity, created_at, updated_at)
+SELECT gen_random_uuid()::text, MIN(regexp_replace(TRIM(v.venue), '\s+', ' ', 'g')), MAX(v.capacity), NOW(), NOW()
+FROM (
+    SELECT venue, total_capacity AS capacity FROM events
+    UNION ALL
+    SELECT venue, total_capacity AS capacity FROM event_series
+) v
+WHERE TRIM(v.venue) <> ''
+GROUP BY LOWER(regexp_replace(TRIM(v.venue), '\s+', ' ', 'g'));
+
+UPDATE events e
+SET venue_id = v.id, venue = v.name
+FROM venues v
+WHERE LOWER(regexp_replace(TRIM(e.venue), '\s+', ' ', 'g')) = LOWER(v.name);
+
+UPDATE event_series s
+SET venue_id = v.id, venue = v.name
+FROM venues v
+WHERE LOWER(regexp_replace(TRIM(s.venue), '\s+', ' ', 'g')) = LOWER(v.name);
+
+-- +goose Down
+ALTER TABLE event_series DROP COLUMN IF EXISTS venue_id;
+ALTER TABLE events DROP COLUMN IF EXISTS venue_id;
+DROP TABLE IF EXISTS venues;
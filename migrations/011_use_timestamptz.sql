@@ -0,0 +1,109 @@
+-- +goose Up
+-- Every event carries the IANA time zone it takes place in. Existing events
+-- inherit the zone of their venue.
+ALTER TABLE events ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE event_series ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+UPDATE events e SET timezone = v.timezone FROM venues v WHERE v.id = e.venue_id;
+UPDATE event_series s SET timezone = v.timezone FROM venues v WHERE v.id = s.venue_id;
+
+-- Event times were stored as wall-clock values without an offset; read them
+-- as local time at the event.
+ALTER TABLE events ALTER COLUMN event_time TYPE TIMESTAMPTZ USING event_time AT TIME ZONE timezone;
+ALTER TABLE event_series ALTER COLUMN start_time TYPE TIMESTAMPTZ USING start_time AT TIME ZONE timezone;
+
+-- Array elements cannot be converted in a USING clause that needs a subquery
+ALTER TABLE event_series ADD COLUMN exceptions_tz TIMESTAMPTZ[] NOT NULL DEFAULT '{}';
+UPDATE event_series
+SET exceptions_tz = ARRAY(SELECT x AT TIME ZONE timezone FROM unnest(exceptions) AS x);
+ALTER TABLE event_series DROP COLUMN exceptions;
+ALTER TABLE event_series RENAME COLUMN exceptions_tz TO exceptions;
+
+-- Bookkeeping timestamps come from the server clock and NOW(); without USING
+-- they are read in the session time zone, which is what wrote them.
+ALTER TABLE events
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE event_series
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE bookings
+    ALTER COLUMN booking_time TYPE TIMESTAMPTZ,
+    ALTER COLUMN cancelled_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE waitlist
+    ALTER COLUMN joined_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN notified_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN expires_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE notifications
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE passes
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE categories
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE tags
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE collections
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE venues
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE venues
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE collections
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE tags
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE categories
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE passes
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE notifications
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE waitlist
+    ALTER COLUMN joined_at TYPE TIMESTAMP,
+    ALTER COLUMN notified_at TYPE TIMESTAMP,
+    ALTER COLUMN expires_at TYPE TIMESTAMP,
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE bookings
+    ALTER COLUMN booking_time TYPE TIMESTAMP,
+    ALTER COLUMN cancelled_at TYPE TIMESTAMP,
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE event_series
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE events
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE event_series ADD COLUMN exceptions_local TIMESTAMP[] NOT NULL DEFAULT '{}';
+UPDATE event_series
+SET exceptions_local = ARRAY(SELECT x AT TIME ZONE timezone FROM unnest(exceptions) AS x);
+ALTER TABLE event_series DROP COLUMN exceptions;
+ALTER TABLE event_series RENAME COLUMN exceptions_local TO exceptions;
+
+ALTER TABLE event_series ALTER COLUMN start_time TYPE TIMESTAMP USING start_time AT TIME ZONE timezone;
+ALTER TABLE events ALTER COLUMN event_time TYPE TIMESTAMP USING event_time AT TIME ZONE timezone;
+
+ALTER TABLE event_series DROP COLUMN IF EXISTS timezone;
+ALTER TABLE events DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Only a hash of each feed token is stored; the token itself is part of the
+-- feed URL handed to the user.
+CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+    user_id VARCHAR(36) PRIMARY KEY,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Bumped on every change so calendar apps replace their copy of the event
+ALTER TABLE events ADD COLUMN sequence INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE events DROP COLUMN IF EXISTS sequence;
+DROP TABLE IF EXISTS calendar_feed_tokens;
@@ -0,0 +1,52 @@
+-- +goose Up
+-- NULL on-sale means on sale from creation; NULL off-sale means until the
+-- event starts.
+ALTER TABLE events ADD COLUMN on_sale_at TIMESTAMPTZ;
+ALTER TABLE events ADD COLUMN off_sale_at TIMESTAMPTZ;
+ALTER TABLE events ADD CONSTRAINT chk_events_sale_window
+    CHECK (on_sale_at IS NULL OR off_sale_at IS NULL OR on_sale_at < off_sale_at);
+
+ALTER TABLE passes ADD COLUMN on_sale_at TIMESTAMPTZ;
+ALTER TABLE passes ADD COLUMN off_sale_at TIMESTAMPTZ;
+ALTER TABLE passes ADD CONSTRAINT chk_passes_sale_window
+    CHECK (on_sale_at IS NULL OR off_sale_at IS NULL OR on_sale_at < off_sale_at);
+
+-- Presales let selected buyers in before the general on-sale time, either
+-- with a shared access code, by invitation, or as previous attendees of
+-- other events.
+CREATE TABLE IF NOT EXISTS presales (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('access_code', 'user_list', 'previous_attendees')),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    access_code_hash VARCHAR(64),
+    source_event_ids VARCHAR(36)[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CHECK (starts_at < ends_at),
+    CHECK ((kind = 'access_code') = (access_code_hash IS NOT NULL)),
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_presales_event_id ON presales(event_id, starts_at);
+
+CREATE TABLE IF NOT EXISTS presale_users (
+    presale_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+
+    PRIMARY KEY (presale_id, user_id),
+    FOREIGN KEY (presale_id) REFERENCES presales(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS presale_users;
+DROP TABLE IF EXISTS presales;
+ALTER TABLE passes DROP CONSTRAINT IF EXISTS chk_passes_sale_window;
+ALTER TABLE passes DROP COLUMN IF EXISTS off_sale_at;
+ALTER TABLE passes DROP COLUMN IF EXISTS on_sale_at;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_events_sale_window;
+ALTER TABLE events DROP COLUMN IF EXISTS off_sale_at;
+ALTER TABLE events DROP COLUMN IF EXISTS on_sale_at;
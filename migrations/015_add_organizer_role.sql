@@ -0,0 +1,27 @@
+-- +goose Up
+-- Organizers create and manage their own events. Events are owned by their
+-- creator and shared with collaborators; sessions follow their parent event.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('user', 'organizer', 'admin'));
+
+CREATE TABLE IF NOT EXISTS event_collaborators (
+    event_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    added_by VARCHAR(36),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (event_id, user_id),
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (added_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_event_collaborators_user_id ON event_collaborators(user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS event_collaborators;
+UPDATE users SET role = 'user' WHERE role = 'organizer';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('user', 'admin'));
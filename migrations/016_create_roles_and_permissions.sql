@@ -0,0 +1,66 @@
+-- +goose Up
+-- Roles are named sets of permissions. Users have exactly one role; the
+-- built-in roles cannot be deleted and the admin role always holds every
+-- permission.
+CREATE TABLE IF NOT EXISTS permissions (
+    name VARCHAR(50) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    is_system BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role VARCHAR(50) NOT NULL,
+    permission VARCHAR(50) NOT NULL,
+
+    PRIMARY KEY (role, permission),
+    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE,
+    FOREIGN KEY (permission) REFERENCES permissions(name) ON DELETE CASCADE
+);
+
+INSERT INTO permissions (name, description) VALUES
+    ('events:write', 'Create events and manage the events you own or collaborate on'),
+    ('events:manage_all', 'Manage every event regardless of ownership'),
+    ('bookings:create', 'Book tickets and join waitlists'),
+    ('bookings:read_all', 'View bookings of other users'),
+    ('analytics:read', 'View analytics of the events you can manage'),
+    ('catalog:write', 'Manage categories, tags and collections'),
+    ('venues:write', 'Manage venues'),
+    ('series:write', 'Manage recurring event series'),
+    ('templates:write', 'Manage event templates and clone events'),
+    ('roles:manage', 'Manage roles and assign them to users');
+
+INSERT INTO roles (name, description, is_system) VALUES
+    ('user', 'Books tickets', TRUE),
+    ('organizer', 'Runs their own events', TRUE),
+    ('admin', 'Full access', TRUE);
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('user', 'bookings:create'),
+    ('organizer', 'bookings:create'),
+    ('organizer', 'events:write'),
+    ('organizer', 'analytics:read');
+
+INSERT INTO role_permissions (role, permission)
+SELECT 'admin', name FROM permissions;
+
+-- The role column now references roles instead of a fixed list
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT fk_users_role
+    FOREIGN KEY (role) REFERENCES roles(name) ON UPDATE CASCADE;
+
+-- +goose Down
+ALTER TABLE users DROP CONSTRAINT IF EXISTS fk_users_role;
+UPDATE users SET role = 'user' WHERE role NOT IN ('user', 'organizer', 'admin');
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('user', 'organizer', 'admin'));
+
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS permissions;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Accounts with roles other than the default are created by invitation. The
+-- invitation token is a signed JWT whose ID is the row ID; the row records
+-- whether it is still usable.
+CREATE TABLE IF NOT EXISTS invitations (
+    id VARCHAR(36) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    invited_by VARCHAR(36),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'revoked')),
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (role) REFERENCES roles(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (invited_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_invitations_email ON invitations(LOWER(email));
+CREATE INDEX idx_invitations_status ON invitations(status, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS invitations;
@@ -0,0 +1,30 @@
+-- +goose Up
+-- Refresh tokens are stored as SHA-256 hashes. Each refresh replaces the
+-- token with a new one in the same family; presenting a token that was
+-- already replaced revokes the whole family.
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    family_id VARCHAR(36) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    used_at TIMESTAMPTZ,
+    replaced_by VARCHAR(36),
+    revoked_at TIMESTAMPTZ,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_refresh_tokens_user ON refresh_tokens(user_id) WHERE revoked_at IS NULL;
+CREATE INDEX idx_refresh_tokens_family ON refresh_tokens(family_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:manage', 'Manage user accounts and their sessions');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'users:manage');
+
+-- +goose Down
+DELETE FROM permissions WHERE name = 'users:manage';
+DROP TABLE IF EXISTS refresh_tokens;
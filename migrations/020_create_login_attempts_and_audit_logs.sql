@@ -0,0 +1,41 @@
+-- +goose Up
+-- Failed logins are tracked per email and per client IP so throttling works
+-- across instances. Emails are stored lower-cased and are not required to
+-- belong to an account, which keeps unknown and known emails
+-- indistinguishable.
+CREATE TABLE IF NOT EXISTS login_failures (
+    id VARCHAR(36) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    ip VARCHAR(45) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_failures_email ON login_failures(email, created_at DESC);
+CREATE INDEX idx_login_failures_ip ON login_failures(ip, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    email VARCHAR(255) PRIMARY KEY,
+    locked_until TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id VARCHAR(36) PRIMARY KEY,
+    actor_id VARCHAR(36),
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50) NOT NULL DEFAULT '',
+    target_id VARCHAR(255) NOT NULL DEFAULT '',
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at DESC);
+CREATE INDEX idx_audit_logs_action ON audit_logs(action, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS login_lockouts;
+DROP TABLE IF EXISTS login_failures;
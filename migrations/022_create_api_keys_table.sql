@@ -0,0 +1,35 @@
+-- +goose Up
+-- API keys for services and partners. A key is "evk_<prefix>_<secret>";
+-- the prefix identifies it in listings and logs, and only the SHA-256 hash
+-- of the whole key is stored. Requests act on behalf of the admin who
+-- created the key, limited to its scopes and, when event_ids is not empty,
+-- to those events.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL UNIQUE,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    event_ids TEXT[] NOT NULL DEFAULT '{}',
+    created_by VARCHAR(36) NOT NULL,
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Actions taken with an API key name the key next to the acting user
+ALTER TABLE audit_logs ADD COLUMN api_key_id VARCHAR(36);
+
+INSERT INTO permissions (name, description) VALUES
+    ('api_keys:manage', 'Create and revoke API keys');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'api_keys:manage');
+
+-- +goose Down
+DELETE FROM permissions WHERE name = 'api_keys:manage';
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS api_key_id;
+DROP TABLE IF EXISTS api_keys;
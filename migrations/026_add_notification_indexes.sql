@@ -0,0 +1,10 @@
+-- +goose Up
+-- Notifications are paged newest first by (created_at, id) per user
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created
+    ON notifications(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread
+    ON notifications(user_id, created_at DESC, id DESC) WHERE is_read = false;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_user_unread;
+DROP INDEX IF EXISTS idx_notifications_user_created;
@@ -0,0 +1,45 @@
+-- +goose Up
+-- Every API instance LISTENs on these channels and pushes the changes to
+-- its streaming clients, whichever instance made them. Payloads stay small:
+-- NOTIFY rejects anything over 8000 bytes.
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_notification_created() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('evently_notifications',
+        json_build_object('id', NEW.id, 'user_id', NEW.user_id)::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER notifications_notify_created
+    AFTER INSERT ON notifications
+    FOR EACH ROW EXECUTE FUNCTION notify_notification_created();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_event_availability() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('evently_availability',
+        json_build_object(
+            'event_id', NEW.id,
+            'available_seats', NEW.available_seats,
+            'total_capacity', NEW.total_capacity
+        )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER events_notify_availability
+    AFTER UPDATE OF available_seats, total_capacity ON events
+    FOR EACH ROW
+    WHEN (OLD.available_seats IS DISTINCT FROM NEW.available_seats
+       OR OLD.total_capacity IS DISTINCT FROM NEW.total_capacity)
+    EXECUTE FUNCTION notify_event_availability();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS events_notify_availability ON events;
+DROP FUNCTION IF EXISTS notify_event_availability();
+DROP TRIGGER IF EXISTS notifications_notify_created ON notifications;
+DROP FUNCTION IF EXISTS notify_notification_created();
@@ -0,0 +1,71 @@
+-- +goose Up
+-- Text messages go to the phone number users add to their profile
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone VARCHAR(20);
+
+-- Browsers that accepted web push for a user. The endpoint is the push
+-- service URL of one browser; p256dh and auth are the keys payloads are
+-- encrypted for.
+CREATE TABLE IF NOT EXISTS push_subscriptions (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    endpoint TEXT NOT NULL UNIQUE,
+    p256dh VARCHAR(128) NOT NULL,
+    auth VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_push_subscriptions_user ON push_subscriptions(user_id);
+
+-- A background worker picks up new notifications and plans one delivery
+-- per channel the user can be reached on. Notifications from before
+-- delivery existed are not sent out.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS delivery_planned_at TIMESTAMPTZ;
+UPDATE notifications SET delivery_planned_at = NOW();
+
+CREATE INDEX idx_notifications_unplanned ON notifications(created_at)
+    WHERE delivery_planned_at IS NULL;
+
+-- Deliveries are retried with growing delays until they are sent or dead.
+-- locked_until lets another worker take over a delivery whose worker went
+-- away while sending.
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id VARCHAR(36) PRIMARY KEY,
+    notification_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sending', 'sent', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    locked_until TIMESTAMPTZ,
+    last_error TEXT,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE (notification_id, channel, recipient),
+    FOREIGN KEY (notification_id) REFERENCES notifications(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_notification_deliveries_due ON notification_deliveries(next_attempt_at)
+    WHERE status = 'pending';
+CREATE INDEX idx_notification_deliveries_locked ON notification_deliveries(locked_until)
+    WHERE status = 'sending';
+CREATE INDEX idx_notification_deliveries_status ON notification_deliveries(status, created_at DESC);
+
+INSERT INTO permissions (name, description) VALUES
+    ('notifications:manage', 'View and retry notification deliveries');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'notifications:manage');
+
+-- +goose Down
+DELETE FROM permissions WHERE name = 'notifications:manage';
+DROP TABLE IF EXISTS notification_deliveries;
+DROP INDEX IF EXISTS idx_notifications_unplanned;
+ALTER TABLE notifications DROP COLUMN IF EXISTS delivery_planned_at;
+DROP TABLE IF EXISTS push_subscriptions;
+ALTER TABLE users DROP COLUMN IF EXISTS phone;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Deleting a pass must not take its paid bookings with it; the application
+-- refuses while confirmed bookings remain and unlinks the cancelled ones
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_pass_id_fkey;
+ALTER TABLE bookings ADD CONSTRAINT bookings_pass_id_fkey
+    FOREIGN KEY (pass_id) REFERENCES passes(id) ON DELETE RESTRICT;
+
+-- +goose Down
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_pass_id_fkey;
+ALTER TABLE bookings ADD CONSTRAINT bookings_pass_id_fkey
+    FOREIGN KEY (pass_id) REFERENCES passes(id) ON DELETE CASCADE;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Tickets are scanned at the door; checked_in_at is set once per booking
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMPTZ;
+
+INSERT INTO permissions (name, description) VALUES
+    ('checkin:scan', 'Check in tickets at the door of the events you can manage');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('organizer', 'checkin:scan'),
+    ('admin', 'checkin:scan');
+
+-- +goose Down
+DELETE FROM permissions WHERE name = 'checkin:scan';
+ALTER TABLE bookings DROP COLUMN IF EXISTS checked_in_at;